@@ -1,6 +1,7 @@
 package axc
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math/big"
 )
@@ -40,3 +41,46 @@ func (b Bloom) MarshalText() ([]byte, error) {
 func (b *Bloom) UnmarshalText(input []byte) error {
 	return UnmarshalFixedText("Bloom", input, b[:])
 }
+
+// Add adds d to the filter. Future calls of Test(d) will return true.
+func (b *Bloom) Add(d []byte) {
+	i1, v1, i2, v2, i3, v3 := bloomValues(d)
+	b[i1] |= v1
+	b[i2] |= v2
+	b[i3] |= v3
+}
+
+// Test checks if the given topic is present in the bloom filter
+func (b Bloom) Test(topic []byte) bool {
+	i1, v1, i2, v2, i3, v3 := bloomValues(topic)
+	return v1 == v1&b[i1] &&
+		v2 == v2&b[i2] &&
+		v3 == v3&b[i3]
+}
+
+// LogsBloom returns the bloom filter of the logs, as it is computed for receipts and headers.
+func LogsBloom(logs []*Log) Bloom {
+	var bin Bloom
+	for _, log := range logs {
+		bin.Add(log.Address.Bytes())
+		for _, topic := range log.Topics {
+			bin.Add(topic.Bytes())
+		}
+	}
+	return bin
+}
+
+// bloomValues returns the bytes (index-value pairs) to set for the given data
+func bloomValues(data []byte) (uint, byte, uint, byte, uint, byte) {
+	hash := Keccak256(data)
+	// the actual bits to flip
+	v1 := byte(1 << (hash[1] & 0x7))
+	v2 := byte(1 << (hash[3] & 0x7))
+	v3 := byte(1 << (hash[5] & 0x7))
+	// the indices for the bytes to OR in
+	i1 := BloomByteLength - uint((binary.BigEndian.Uint16(hash)&0x7ff)>>3) - 1
+	i2 := BloomByteLength - uint((binary.BigEndian.Uint16(hash[2:])&0x7ff)>>3) - 1
+	i3 := BloomByteLength - uint((binary.BigEndian.Uint16(hash[4:])&0x7ff)>>3) - 1
+
+	return i1, v1, i2, v2, i3, v3
+}
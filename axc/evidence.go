@@ -0,0 +1,150 @@
+package axc
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/axc/rlp"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// extraVanity is the fixed number of extra-data prefix bytes reserved for signer vanity
+	extraVanity = 32
+
+	// DefaultEpochLength is the number of blocks after which the parlia validator set is rotated
+	DefaultEpochLength int64 = 200
+)
+
+var (
+	ErrMissingVanity           = errors.New("extra-data 32 byte vanity prefix missing")
+	ErrMissingSignature        = errors.New("extra-data 65 byte signature suffix missing")
+	ErrExtraValidators         = errors.New("non-epoch block contains validator list in extra-data")
+	ErrInvalidEpochValidators  = errors.New("invalid validator list in epoch block extra-data")
+	ErrHeightMismatch          = errors.New("the numbers of two block headers are not the same")
+	ErrParentHashMismatch      = errors.New("the parent hash of two block headers are not the same")
+	ErrIdenticalHeaders        = errors.New("the two block headers are the same")
+	ErrSignerMismatch          = errors.New("the signers of two block headers are not the same")
+	ErrSignerNotInValidatorSet = errors.New("the signer is not in the validator set")
+)
+
+// Hash returns the keccak256 hash of the RLP encoding of the whole header,
+// which is the block hash on the side chain.
+func (h *Header) Hash() (hash Hash) {
+	hasher := sha3.NewLegacyKeccak256()
+	err := rlp.Encode(hasher, []interface{}{
+		h.ParentHash,
+		h.UncleHash,
+		h.Coinbase,
+		h.Root,
+		h.TxHash,
+		h.ReceiptHash,
+		h.Bloom,
+		big.NewInt(h.Difficulty),
+		big.NewInt(h.Number),
+		h.GasLimit,
+		h.GasUsed,
+		h.Time,
+		h.Extra,
+		h.MixDigest,
+		h.Nonce,
+	})
+	if err != nil {
+		panic("can't encode: " + err.Error())
+	}
+	hasher.Sum(hash[:0])
+	return hash
+}
+
+// IsEpoch returns whether the header is at an epoch boundary, where parlia
+// embeds the next validator set in the extra-data.
+func (h *Header) IsEpoch(epochLength int64) bool {
+	return epochLength > 0 && h.Number%epochLength == 0
+}
+
+// VerifyExtra checks the layout of the extra-data: a vanity prefix, a signature
+// suffix and, only on epoch blocks, a list of validator addresses in between.
+func (h *Header) VerifyExtra(epochLength int64) error {
+	if len(h.Extra) < extraVanity {
+		return ErrMissingVanity
+	}
+	if len(h.Extra) < extraVanity+extraSeal {
+		return ErrMissingSignature
+	}
+	validatorBytes := len(h.Extra) - extraVanity - extraSeal
+	if !h.IsEpoch(epochLength) && validatorBytes != 0 {
+		return ErrExtraValidators
+	}
+	if h.IsEpoch(epochLength) && (validatorBytes == 0 || validatorBytes%AddressLength != 0) {
+		return ErrInvalidEpochValidators
+	}
+	return nil
+}
+
+// GetValidators returns the validator set embedded in the extra-data of an epoch block.
+func (h *Header) GetValidators(epochLength int64) ([]Address, error) {
+	if err := h.VerifyExtra(epochLength); err != nil {
+		return nil, err
+	}
+	if !h.IsEpoch(epochLength) {
+		return nil, ErrExtraValidators
+	}
+	validatorBytes := h.Extra[extraVanity : len(h.Extra)-extraSeal]
+	validators := make([]Address, len(validatorBytes)/AddressLength)
+	for i := range validators {
+		copy(validators[i][:], validatorBytes[i*AddressLength:(i+1)*AddressLength])
+	}
+	return validators, nil
+}
+
+// CheckEquivocation verifies, without recovering the signers, that the two headers
+// are well formed and are two different blocks built on the same parent.
+func CheckEquivocation(header1, header2 *Header, epochLength int64) error {
+	if err := header1.VerifyExtra(epochLength); err != nil {
+		return err
+	}
+	if err := header2.VerifyExtra(epochLength); err != nil {
+		return err
+	}
+	if header1.Number != header2.Number {
+		return ErrHeightMismatch
+	}
+	if header1.ParentHash.Cmp(header2.ParentHash) != 0 {
+		return ErrParentHashMismatch
+	}
+	if header1.Hash().Cmp(header2.Hash()) == 0 {
+		return ErrIdenticalHeaders
+	}
+	return nil
+}
+
+// VerifyDoubleSign verifies that the two headers are conflicting blocks sealed by
+// the same signer and returns the signer.
+func VerifyDoubleSign(header1, header2 *Header, chainID *big.Int, epochLength int64) (Address, error) {
+	if err := CheckEquivocation(header1, header2, epochLength); err != nil {
+		return Address{}, err
+	}
+	signer1, err := header1.ExtractSignerFromHeader(chainID)
+	if err != nil {
+		return Address{}, err
+	}
+	signer2, err := header2.ExtractSignerFromHeader(chainID)
+	if err != nil {
+		return Address{}, err
+	}
+	if !bytes.Equal(signer1.Bytes(), signer2.Bytes()) {
+		return Address{}, ErrSignerMismatch
+	}
+	return signer1, nil
+}
+
+// VerifySignerInValidatorSet checks that the signer belongs to the given validator set.
+func VerifySignerInValidatorSet(signer Address, validators []Address) error {
+	for _, val := range validators {
+		if bytes.Equal(val.Bytes(), signer.Bytes()) {
+			return nil
+		}
+	}
+	return ErrSignerNotInValidatorSet
+}
@@ -0,0 +1,69 @@
+package axc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const doubleSignHeadersJson = `[{"parentHash":"0x6116de25352c93149542e950162c7305f207bbc17b0eb725136b78c80aed79cc","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0xe7cb9d2fd449f7bd11126bff55266e7b74936f2f230e21d44d75c04b7780dfeb","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x20000","number":"0x1","gasLimit":"0x47e7c4","gasUsed":"0x0","timestamp":"0x5ea6a002","extraData":"0x0000000000000000000000000000000000000000000000000000000000000000fc3e4bbcd4936a8e1fd9fc45461d071ca571ca80fbed85e0cc52e007ed557aff0a6ea1875b4e13171d301037036b3a26af3c7c2b317487323fd7557df717856b00","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","hash":"0x1532065752393ff2f6e7ef9b64f80d6e10efe42a4d9bdd8149fcbac6f86b365b"},{"parentHash":"0x6116de25352c93149542e950162c7305f207bbc17b0eb725136b78c80aed79cc","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0xe7cb9d2fd449f7bd11126bff55266e7b74936f2f230e21d44d75c04b7780dfeb","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x20000","number":"0x1","gasLimit":"0x47e7c4","gasUsed":"0x64","timestamp":"0x5ea6a002","extraData":"0x00000000000000000000000000000000000000000000000000000000000000003a849df14e9cc1502f218431c449f239a51fddb1fd408ca37e61834adf921f0c21fd269c86acf7f0b40aa7ce691bbd7f446d8234a4a6b19a98c77614da9a5fcb01","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","hash":"0x811a42453f826f05e9d85998551636f59eb740d5b03fe2416700058a4f31ca1e"}]`
+
+func loadDoubleSignHeaders(t *testing.T) []Header {
+	headers := make([]Header, 0)
+	err := json.Unmarshal([]byte(doubleSignHeadersJson), &headers)
+	require.NoError(t, err)
+	require.Len(t, headers, 2)
+	return headers
+}
+
+func TestHeader_GetValidators(t *testing.T) {
+	h := Header{Number: 400, Extra: make([]byte, extraVanity+2*AddressLength+extraSeal)}
+	h.Extra[extraVanity+AddressLength-1] = 1
+	h.Extra[extraVanity+2*AddressLength-1] = 2
+	validators, err := h.GetValidators(DefaultEpochLength)
+	require.NoError(t, err)
+	require.Len(t, validators, 2)
+	require.EqualValues(t, 1, validators[0][AddressLength-1])
+	require.EqualValues(t, 2, validators[1][AddressLength-1])
+	require.NoError(t, VerifySignerInValidatorSet(validators[1], validators))
+	require.Equal(t, ErrSignerNotInValidatorSet, VerifySignerInValidatorSet(Address{}, validators))
+
+	h.Extra = h.Extra[1:]
+	require.Equal(t, ErrInvalidEpochValidators, h.VerifyExtra(DefaultEpochLength))
+
+	h.Number = 401
+	require.Equal(t, ErrExtraValidators, h.VerifyExtra(DefaultEpochLength))
+
+	h.Extra = make([]byte, extraVanity+extraSeal-1)
+	require.Equal(t, ErrMissingSignature, h.VerifyExtra(DefaultEpochLength))
+}
+
+func TestVerifyDoubleSign(t *testing.T) {
+	chainID := big.NewInt(56)
+	headers := loadDoubleSignHeaders(t)
+
+	signer, err := VerifyDoubleSign(&headers[0], &headers[1], chainID, DefaultEpochLength)
+	require.NoError(t, err)
+	require.Equal(t, "0xed24ff64903c07B5bD57C898CE0967D407aFCB0d", signer.String())
+
+	_, err = VerifyDoubleSign(&headers[0], &headers[0], chainID, DefaultEpochLength)
+	require.Equal(t, ErrIdenticalHeaders, err)
+
+	h := headers[1]
+	h.Number = headers[0].Number + 1
+	_, err = VerifyDoubleSign(&headers[0], &h, chainID, DefaultEpochLength)
+	require.Equal(t, ErrHeightMismatch, err)
+
+	h = headers[1]
+	h.ParentHash = Hash{}
+	_, err = VerifyDoubleSign(&headers[0], &h, chainID, DefaultEpochLength)
+	require.Equal(t, ErrParentHashMismatch, err)
+
+	// the seal no longer matches the content, so a different signer is recovered
+	h = headers[1]
+	h.GasUsed = headers[1].GasUsed + 1
+	_, err = VerifyDoubleSign(&headers[0], &h, chainID, DefaultEpochLength)
+	require.Equal(t, ErrSignerMismatch, err)
+}
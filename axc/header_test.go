@@ -38,4 +38,6 @@ func TestHeader_UnmarshalJSON(t *testing.T) {
 	signer, err := h.ExtractSignerFromHeader(chainID)
 	require.NoError(t, err)
 	require.Equal(t, "0x72b61c6014342d914470eC7aC2975bE345796c2b", signer.String())
+
+	require.Equal(t, "0x8b6eeece6cedbb23038e7e5c2ce647fbdffa04972247d60a7564e81897e8bc30", h.Hash().Hex())
 }
@@ -0,0 +1,142 @@
+package axc
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/cosmos/cosmos-sdk/axc/rlp"
+)
+
+var (
+	ErrMissingProofNode = errors.New("missing trie node in proof")
+	ErrInvalidProofNode = errors.New("invalid trie node in proof")
+	ErrKeyNotInTrie     = errors.New("key does not exist in trie")
+)
+
+// VerifyProof checks the merkle patricia trie proof for the key against the root hash and returns
+// the value of the key. The proof contains the RLP encoded trie nodes on the path from the root
+// to the value, in any order.
+func VerifyProof(rootHash Hash, key []byte, proof [][]byte) ([]byte, error) {
+	return verifyProof(rootHash, key, proofNodes(proof))
+}
+
+// proofNodes indexes the trie nodes of a proof by hash
+func proofNodes(proof [][]byte) map[Hash][]byte {
+	nodes := make(map[Hash][]byte, len(proof))
+	for _, node := range proof {
+		nodes[BytesToHash(Keccak256(node))] = node
+	}
+	return nodes
+}
+
+func verifyProof(rootHash Hash, key []byte, nodes map[Hash][]byte) ([]byte, error) {
+	node, ok := nodes[rootHash]
+	if !ok {
+		return nil, ErrMissingProofNode
+	}
+	path := keybytesToNibbles(key)
+	for {
+		elems, err := splitTrieNode(node)
+		if err != nil {
+			return nil, err
+		}
+
+		var ref []byte
+		switch len(elems) {
+		case 17: // full node
+			if len(path) == 0 {
+				return trieValue(elems[16])
+			}
+			ref, path = elems[path[0]], path[1:]
+		case 2: // short node, either an extension or a leaf
+			compactKey, _, err := rlp.SplitString(elems[0])
+			if err != nil {
+				return nil, ErrInvalidProofNode
+			}
+			nodePath, isLeaf := compactToNibbles(compactKey)
+			if len(path) < len(nodePath) || !bytes.Equal(path[:len(nodePath)], nodePath) {
+				return nil, ErrKeyNotInTrie
+			}
+			path = path[len(nodePath):]
+			if isLeaf {
+				if len(path) != 0 {
+					return nil, ErrKeyNotInTrie
+				}
+				return trieValue(elems[1])
+			}
+			ref = elems[1]
+		default:
+			return nil, ErrInvalidProofNode
+		}
+
+		// a child is either embedded in its parent when the encoding is shorter than 32 bytes, or referenced by hash
+		kind, content, _, err := rlp.Split(ref)
+		if err != nil {
+			return nil, ErrInvalidProofNode
+		}
+		switch {
+		case kind == rlp.List:
+			node = ref
+		case len(content) == 0:
+			return nil, ErrKeyNotInTrie
+		case len(content) == HashLength:
+			if node, ok = nodes[BytesToHash(content)]; !ok {
+				return nil, ErrMissingProofNode
+			}
+		default:
+			return nil, ErrInvalidProofNode
+		}
+	}
+}
+
+// splitTrieNode splits the RLP encoded trie node into the encodings of its elements
+func splitTrieNode(node []byte) ([][]byte, error) {
+	content, _, err := rlp.SplitList(node)
+	if err != nil {
+		return nil, ErrInvalidProofNode
+	}
+	elems := make([][]byte, 0, 17)
+	for len(content) > 0 {
+		_, _, rest, err := rlp.Split(content)
+		if err != nil {
+			return nil, ErrInvalidProofNode
+		}
+		elems = append(elems, content[:len(content)-len(rest)])
+		content = rest
+	}
+	return elems, nil
+}
+
+func trieValue(elem []byte) ([]byte, error) {
+	value, _, err := rlp.SplitString(elem)
+	if err != nil {
+		return nil, ErrInvalidProofNode
+	}
+	if len(value) == 0 {
+		return nil, ErrKeyNotInTrie
+	}
+	return value, nil
+}
+
+func keybytesToNibbles(key []byte) []byte {
+	nibbles := make([]byte, len(key)*2)
+	for i, b := range key {
+		nibbles[i*2] = b / 16
+		nibbles[i*2+1] = b % 16
+	}
+	return nibbles
+}
+
+// compactToNibbles decodes the hex-prefix encoded path of a short node
+func compactToNibbles(compact []byte) (nibbles []byte, isLeaf bool) {
+	if len(compact) == 0 {
+		return nil, false
+	}
+	nibbles = keybytesToNibbles(compact)
+	isLeaf = nibbles[0] >= 2
+	// odd length paths keep the first nibble after the flag
+	if nibbles[0]&1 == 1 {
+		return nibbles[1:], isLeaf
+	}
+	return nibbles[2:], isLeaf
+}
@@ -0,0 +1,152 @@
+package axc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/axc/rlp"
+)
+
+func mustEncode(t *testing.T, val interface{}) []byte {
+	bz, err := rlp.EncodeToBytes(val)
+	require.NoError(t, err)
+	return bz
+}
+
+func encodeBranch(t *testing.T, children map[int][]byte) []byte {
+	elems := make([]rlp.RawValue, 17)
+	for i := range elems {
+		if child, ok := children[i]; ok {
+			elems[i] = mustEncode(t, Keccak256(child))
+		} else {
+			elems[i] = mustEncode(t, []byte{})
+		}
+	}
+	return mustEncode(t, elems)
+}
+
+func encodeLeaf(t *testing.T, compactKey, value []byte) []byte {
+	return mustEncode(t, []interface{}{compactKey, value})
+}
+
+// buildReceiptTrie builds the receipt trie of three receipts by hand, keyed by rlp(0) = 0x80, rlp(1) = 0x01
+// and rlp(2) = 0x02, and returns the root and the proofs of each receipt.
+func buildReceiptTrie(t *testing.T, receipts [3][]byte) (Hash, [3][][]byte) {
+	leaf0 := encodeLeaf(t, []byte{0x30}, receipts[0]) // odd leaf, remaining path [0]
+	leaf1 := encodeLeaf(t, []byte{0x20}, receipts[1]) // even leaf, empty remaining path
+	leaf2 := encodeLeaf(t, []byte{0x20}, receipts[2])
+	branch := encodeBranch(t, map[int][]byte{1: leaf1, 2: leaf2})
+	root := encodeBranch(t, map[int][]byte{0: branch, 8: leaf0})
+
+	return BytesToHash(Keccak256(root)), [3][][]byte{
+		{root, leaf0},
+		{leaf1, root, branch},
+		{root, branch, leaf2},
+	}
+}
+
+func newTestReceipts(t *testing.T) ([]*Receipt, [3][]byte) {
+	receipts := make([]*Receipt, 3)
+	var encoded [3][]byte
+	for i := range receipts {
+		log := &Log{
+			Address: Address{byte(i + 1)},
+			Topics:  []Hash{BytesToHash([]byte{0xaa, byte(i)}), BytesToHash([]byte{byte(i)})},
+			Data:    []byte{0x01, 0x02, byte(i)},
+		}
+		receipts[i] = &Receipt{
+			PostStateOrStatus: []byte{0x01},
+			CumulativeGasUsed: uint64(21000 * (i + 1)),
+			Bloom:             LogsBloom([]*Log{log}),
+			Logs:              []*Log{log},
+		}
+		encoded[i] = mustEncode(t, receipts[i])
+	}
+	// the last one is a typed receipt
+	encoded[2] = append([]byte{0x02}, encoded[2]...)
+	return receipts, encoded
+}
+
+func TestVerifyReceiptProof(t *testing.T) {
+	receipts, encoded := newTestReceipts(t)
+	root, proofs := buildReceiptTrie(t, encoded)
+
+	for i := range receipts {
+		receipt, err := VerifyReceiptProof(root, uint64(i), proofs[i])
+		require.NoError(t, err)
+		require.Equal(t, receipts[i].CumulativeGasUsed, receipt.CumulativeGasUsed)
+		require.Equal(t, receipts[i].Logs, receipt.Logs)
+		require.False(t, receipt.Failed())
+	}
+
+	// proof of another receipt
+	_, err := VerifyReceiptProof(root, 0, proofs[1])
+	require.Equal(t, ErrMissingProofNode, err)
+
+	// proof is not complete
+	_, err = VerifyReceiptProof(root, 2, proofs[2][:2])
+	require.Equal(t, ErrMissingProofNode, err)
+
+	// key is not in trie
+	_, err = VerifyReceiptProof(root, 3, proofs[2])
+	require.Equal(t, ErrKeyNotInTrie, err)
+
+	// proof against another root
+	_, err = VerifyReceiptProof(Hash{0x01}, 0, proofs[0])
+	require.Equal(t, ErrMissingProofNode, err)
+}
+
+func TestVerifyBlockReceipts(t *testing.T) {
+	receipts, encoded := newTestReceipts(t)
+	root, proofs := buildReceiptTrie(t, encoded)
+	proof := append(append(append([][]byte{}, proofs[0]...), proofs[1]...), proofs[2]...)
+
+	proved, err := VerifyBlockReceipts(root, 3, proof)
+	require.NoError(t, err)
+	require.Len(t, proved, 3)
+	for i := range receipts {
+		require.Equal(t, receipts[i].Logs, proved[i].Logs)
+	}
+
+	// a receipt is left out
+	_, err = VerifyBlockReceipts(root, 2, proof)
+	require.Equal(t, ErrExtraReceipt, err)
+
+	// the block has less receipts
+	_, err = VerifyBlockReceipts(root, 4, proof)
+	require.Equal(t, ErrKeyNotInTrie, err)
+
+	// proof is not complete
+	_, err = VerifyBlockReceipts(root, 3, append(append([][]byte{}, proofs[0]...), proofs[1]...))
+	require.Equal(t, ErrMissingProofNode, err)
+}
+
+func TestVerifyLogProof(t *testing.T) {
+	receipts, encoded := newTestReceipts(t)
+	root, proofs := buildReceiptTrie(t, encoded)
+
+	logs := make([]*Log, 0)
+	for _, receipt := range receipts {
+		logs = append(logs, receipt.Logs...)
+	}
+	header := &Header{ReceiptHash: root, Bloom: LogsBloom(logs)}
+
+	log, err := VerifyLogProof(header, 1, 0, proofs[1])
+	require.NoError(t, err)
+	require.Equal(t, receipts[1].Logs[0], log)
+
+	_, err = VerifyLogProof(header, 1, 1, proofs[1])
+	require.Equal(t, ErrLogNotFound, err)
+
+	header.Bloom = LogsBloom(receipts[0].Logs)
+	_, err = VerifyLogProof(header, 1, 0, proofs[1])
+	require.Equal(t, ErrLogNotInBloom, err)
+}
+
+func TestBloom(t *testing.T) {
+	var bloom Bloom
+	bloom.Add([]byte("testtest"))
+	require.True(t, bloom.Test([]byte("testtest")))
+	require.False(t, bloom.Test([]byte("test")))
+}
@@ -0,0 +1,122 @@
+package axc
+
+import (
+	"errors"
+
+	"github.com/cosmos/cosmos-sdk/axc/rlp"
+)
+
+var (
+	ErrInvalidReceipt  = errors.New("invalid receipt encoding")
+	ErrLogNotFound     = errors.New("log index out of range of receipt logs")
+	ErrLogNotInBloom   = errors.New("log is not in the bloom of header")
+	ErrReceiptTxFailed = errors.New("the transaction of receipt failed")
+	ErrExtraReceipt    = errors.New("the receipt trie has more receipts than the transaction count")
+)
+
+// Log represents a contract log event emitted on the side chain.
+type Log struct {
+	Address Address
+	Topics  []Hash
+	Data    []byte
+}
+
+// Receipt represents the consensus fields of a transaction receipt on the side chain.
+type Receipt struct {
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Bloom             Bloom
+	Logs              []*Log
+}
+
+// Failed returns whether the transaction of the receipt was reverted, a failed status is encoded as empty bytes
+func (r *Receipt) Failed() bool {
+	return len(r.PostStateOrStatus) == 0
+}
+
+// DecodeReceipt decodes the consensus encoding of a receipt, both legacy and typed receipts are supported.
+func DecodeReceipt(bz []byte) (*Receipt, error) {
+	if len(bz) == 0 {
+		return nil, ErrInvalidReceipt
+	}
+	// typed receipts are prefixed by the transaction type, which is never a valid RLP list prefix
+	if bz[0] < 0x7f {
+		bz = bz[1:]
+	}
+	var receipt Receipt
+	if err := rlp.DecodeBytes(bz, &receipt); err != nil {
+		return nil, ErrInvalidReceipt
+	}
+	return &receipt, nil
+}
+
+// VerifyReceiptProof proves the receipt of the transaction at txIndex is in the receipt trie with the given root.
+func VerifyReceiptProof(receiptRoot Hash, txIndex uint64, proof [][]byte) (*Receipt, error) {
+	key, err := rlp.EncodeToBytes(txIndex)
+	if err != nil {
+		return nil, err
+	}
+	value, err := VerifyProof(receiptRoot, key, proof)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeReceipt(value)
+}
+
+// VerifyBlockReceipts proves the receipts are all the receipts of the block with the given receipt root. The proof
+// contains the trie nodes of the receipts of the txCount transactions, and proves there is no receipt at txCount.
+func VerifyBlockReceipts(receiptRoot Hash, txCount uint64, proof [][]byte) ([]*Receipt, error) {
+	nodes := proofNodes(proof)
+	receipts := make([]*Receipt, 0, txCount)
+	for txIndex := uint64(0); txIndex <= txCount; txIndex++ {
+		key, err := rlp.EncodeToBytes(txIndex)
+		if err != nil {
+			return nil, err
+		}
+		value, err := verifyProof(receiptRoot, key, nodes)
+		if txIndex == txCount {
+			// the transactions are indexed contiguously, so the block ends at the first absent index
+			if err == ErrKeyNotInTrie {
+				return receipts, nil
+			}
+			if err == nil {
+				return nil, ErrExtraReceipt
+			}
+			return nil, err
+		}
+		if err != nil {
+			return nil, err
+		}
+		receipt, err := DecodeReceipt(value)
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, nil
+}
+
+// VerifyLogProof proves the log at logIndex of the receipt of the transaction at txIndex was emitted in
+// the block of the header, and returns the log.
+func VerifyLogProof(header *Header, txIndex, logIndex uint64, proof [][]byte) (*Log, error) {
+	receipt, err := VerifyReceiptProof(header.ReceiptHash, txIndex, proof)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Failed() {
+		return nil, ErrReceiptTxFailed
+	}
+	if logIndex >= uint64(len(receipt.Logs)) {
+		return nil, ErrLogNotFound
+	}
+	log := receipt.Logs[logIndex]
+	if !header.Bloom.Test(log.Address.Bytes()) {
+		return nil, ErrLogNotInBloom
+	}
+	for _, topic := range log.Topics {
+		if !header.Bloom.Test(topic.Bytes()) {
+			return nil, ErrLogNotInBloom
+		}
+	}
+	return log, nil
+}
@@ -21,6 +21,7 @@ import (
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/pubsub"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/version"
@@ -40,6 +41,10 @@ const (
 	TxSourceKey = "txSrc"
 	//this number should be around the size of the transactions in a block, TODO: configurable
 	TxMsgCacheSize = 4000
+	// max number of msgs in a tx once the multi-message tx is activated, it's part of the consensus
+	MaxMsgsPerTx = 16
+	// max units consumed by the txs of a block once the resource metering is activated, it's part of the consensus
+	MaxBlockResource uint64 = 100000000
 )
 
 // BaseApp reflects the ABCI application implementation.
@@ -52,8 +57,14 @@ type BaseApp struct {
 	router      Router               // handle any kind of message
 	queryRouter QueryRouter          // router for redirecting query calls
 	codespacer  *sdk.Codespacer      // handle module codespacing
+	maxMsgs     int                  // max number of msgs in a tx accepted into the mempool of the node
 	collect     sdk.CollectConfig
 
+	// may be nil, the state access of the txs checked by the node is metered with it
+	resourceConfig *sdk.ResourceConfig
+	maxTxResource  uint64 // max units consumed by a tx accepted into the mempool of the node
+	blockResource  uint64 // units consumed by the txs delivered in the current block
+
 	TxDecoder sdk.TxDecoder // unmarshal []byte into sdk.Tx
 
 	anteHandler sdk.AnteHandler // ante handler for fee and auth
@@ -65,6 +76,7 @@ type BaseApp struct {
 	endBlocker       sdk.EndBlocker   // logic to run after all txs, and to determine valset changes
 	addrPeerFilter   sdk.PeerFilter   // filter peers by address and port
 	pubkeyPeerFilter sdk.PeerFilter   // filter peers by public key
+	pubServer        *pubsub.Server   // server the NewBlockEvent of every block is published to
 
 	//--------------------
 	// Volatile
@@ -113,9 +125,11 @@ func NewBaseApp(name string, logger log.Logger, db dbm.DB, txDecoder sdk.TxDecod
 		collect:     collectConfig,
 		txMsgCache:  cache,
 		Pool:        new(sdk.Pool),
+		maxMsgs:     MaxMsgsPerTx,
 	}
 
 	sdk.UpgradeMgr.AddConfig(sdk.MainNetConfig) // TODO: make this configurable
+	app.queryRouter.AddRoute(UpgradeQueryRoute, NewUpgradeQuerier())
 
 	// Register the undefined & root codespaces, which should not be used by
 	// any modules.
@@ -514,6 +528,7 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 	}
 
 	sdk.UpgradeMgr.SetHeight(req.Header.Height)
+	app.blockResource = 0
 
 	// Initialize the DeliverTx state. If this is the first block, it should
 	// already be initialized in InitChain. Otherwise app.DeliverState will be
@@ -527,6 +542,11 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 		app.DeliverState.Ctx = app.DeliverState.Ctx.WithBlockHash(req.Hash).WithBlockHeader(req.Header).WithBlockHeight(req.Header.Height)
 	}
 
+	// the events published while executing the block follow its NewBlockEvent
+	if app.pubServer != nil {
+		app.pubServer.Publish(pubsub.NewBlockEvent{Height: req.Header.Height})
+	}
+
 	if app.beginBlocker != nil {
 		res = app.beginBlocker(app.DeliverState.Ctx, req)
 	}
@@ -584,10 +604,11 @@ func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx)
 	}
 
 	return abci.ResponseCheckTx{
-		Code:   uint32(result.Code),
-		Data:   result.Data,
-		Log:    result.Log,
-		Events: result.GetEvents(),
+		Code:    uint32(result.Code),
+		Data:    result.Data,
+		Log:     result.Log,
+		GasUsed: int64(result.ResourceUsed),
+		Events:  result.GetEvents(),
 	}
 }
 
@@ -674,10 +695,11 @@ func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliv
 
 	// Tell the blockchain engine (i.e. Tendermint).
 	return abci.ResponseDeliverTx{
-		Code:   uint32(result.Code),
-		Data:   result.Data,
-		Log:    result.Log,
-		Events: result.GetEvents(),
+		Code:    uint32(result.Code),
+		Data:    result.Data,
+		Log:     result.Log,
+		GasUsed: int64(result.ResourceUsed),
+		Events:  result.GetEvents(),
 	}
 }
 
@@ -695,9 +717,15 @@ func (app *BaseApp) PreDeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDe
 
 // Basic validator for msgs
 func validateBasicTxMsgs(msgs []sdk.Msg) sdk.Error {
-	if msgs == nil || len(msgs) != 1 {
-		// TODO: probably shouldn't be ErrInternal. Maybe new ErrInvalidMessage, or ?
-		return sdk.ErrInternal("Tx.GetMsgs() must return exactly one message")
+	if !sdk.IsUpgrade(sdk.MultiMsgTx) {
+		if msgs == nil || len(msgs) != 1 {
+			// TODO: probably shouldn't be ErrInternal. Maybe new ErrInvalidMessage, or ?
+			return sdk.ErrInternal("Tx.GetMsgs() must return exactly one message")
+		}
+	} else if len(msgs) == 0 {
+		return sdk.ErrInternal("Tx.GetMsgs() must return at least one message")
+	} else if len(msgs) > MaxMsgsPerTx {
+		return sdk.ErrTooManyMsgs(fmt.Sprintf("tx contains %d msgs, the max is %d", len(msgs), MaxMsgsPerTx))
 	}
 
 	for _, msg := range msgs {
@@ -739,14 +767,15 @@ func (app *BaseApp) getContextWithCache(mode sdk.RunTxMode, tx sdk.Tx, txHash st
 	return ctx.WithMultiStore(msCache).WithAccountCache(accountCache), msCache, accountCache
 }
 
-// Iterates through msgs and executes them
+// Iterates through msgs and executes them, the execution stops on the first failed msg and the tx fails as a whole
 func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode sdk.RunTxMode) (result sdk.Result) {
 	// accumulate results
 	logs := make([]string, 0, len(msgs))
 	var data []byte   // NOTE: we just append them all (?!)
 	var tags sdk.Tags // also just append them all
 	var events sdk.Events
-	var code sdk.ABCICodeType
+	var msgResults []sdk.MsgResult
+	multiMsgs := len(msgs) > 1
 	for msgIdx, msg := range msgs {
 		// Match route.
 		msgRoute := msg.Route()
@@ -757,34 +786,57 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode sdk.RunTxMode)
 
 		msgResult := handler(ctx.WithRunTxMode(mode), msg)
 		msgResult.Tags = append(msgResult.Tags, sdk.MakeTag("action", []byte(msg.Type())))
+		if multiMsgs {
+			// the events of the msgs are told apart by the index
+			msgIdxAttr := sdk.NewAttribute("msg_index", strconv.Itoa(msgIdx))
+			for i := range msgResult.Events {
+				msgResult.Events[i] = msgResult.Events[i].AppendAttributes(msgIdxAttr)
+			}
+		}
+
+		// Stop execution and return on first failed message, the results of the executed msgs are discarded
+		// with their state changes.
+		if !msgResult.IsOK() {
+			log := msgResult.Log
+			if multiMsgs {
+				log = "Msg " + strconv.Itoa(msgIdx) + " failed: " + log
+			}
+			return sdk.Result{
+				Code:   msgResult.Code,
+				Data:   msgResult.Data,
+				Log:    log,
+				Tags:   msgResult.Tags,
+				Events: msgResult.Events,
+			}
+		}
 
 		// Append Data and Tags
 		data = append(data, msgResult.Data...)
 		tags = append(tags, msgResult.Tags...)
 		events = append(events, msgResult.Events...)
-
-		// Stop execution and return on first failed message.
-		if !msgResult.IsOK() {
-			logs = append(logs, msgResult.Log)
-			code = msgResult.Code
-			break
+		if multiMsgs {
+			msgResults = append(msgResults, sdk.MsgResult{
+				Data:   msgResult.Data,
+				Log:    msgResult.Log,
+				Tags:   msgResult.Tags,
+				Events: msgResult.Events,
+			})
 		}
 
 		// Construct usable logs in multi-message transactions.
 		logs = append(logs, "Msg "+strconv.Itoa(msgIdx)+": "+msgResult.Log)
 	}
-	// A tx must only contain one msg. If the msg execution is success, record it
-	if code == sdk.ABCICodeOK {
-		routerName := msgs[0].Route()
-		ctx.RouterCallRecord()[routerName] = true
+	// All the msgs are executed successfully, record their routes
+	for _, msg := range msgs {
+		ctx.RouterCallRecord()[msg.Route()] = true
 	}
 	result = sdk.Result{
-		Code: code,
 		Data: data,
 		Log:  strings.Join(logs, "\n"),
 		// TODO: FeeAmount/FeeDenom
-		Tags:   tags,
-		Events: events,
+		Tags:       tags,
+		Events:     events,
+		MsgResults: msgResults,
 	}
 
 	return result
@@ -815,6 +867,37 @@ func getAccountCache(app *BaseApp, mode sdk.RunTxMode) sdk.AccountCache {
 	return app.DeliverState.AccountCache
 }
 
+// newResourceMeter returns the meter of a tx and the config it charges, or nil if the tx is not metered. Once the
+// resource metering is activated, the delivered txs share the units left in the block with the default config. The
+// txs checked or simulated by the node are metered with its own config if any, or else against a whole block.
+func (app *BaseApp) newResourceMeter(mode sdk.RunTxMode) (sdk.ResourceMeter, sdk.ResourceConfig) {
+	if mode == sdk.RunTxModeDeliver || mode == sdk.RunTxModeDeliverAfterPre {
+		if !sdk.IsUpgrade(sdk.ResourceMetering) {
+			return nil, sdk.ResourceConfig{}
+		}
+		return sdk.NewResourceMeter(MaxBlockResource - app.blockResource), sdk.DefaultResourceConfig()
+	}
+	if app.resourceConfig != nil {
+		return sdk.NewResourceMeter(app.maxTxResource), *app.resourceConfig
+	}
+	if sdk.IsUpgrade(sdk.ResourceMetering) {
+		return sdk.NewResourceMeter(MaxBlockResource), sdk.DefaultResourceConfig()
+	}
+	return nil, sdk.ResourceConfig{}
+}
+
+// consumeBlockResource charges the units consumed by the delivered tx to the block, whether the tx succeeds or not
+func (app *BaseApp) consumeBlockResource(mode sdk.RunTxMode, meter sdk.ResourceMeter) {
+	if mode != sdk.RunTxModeDeliver && mode != sdk.RunTxModeDeliverAfterPre {
+		return
+	}
+	consumed := meter.Consumed()
+	if consumed > meter.Limit() {
+		consumed = meter.Limit()
+	}
+	app.blockResource += consumed
+}
+
 // RunTx processes a transaction. The transactions is proccessed via an
 // anteHandler. txBytes may be nil in some cases, eg. in tests. Also, in the
 // future we may support "internal" transactions.
@@ -822,18 +905,42 @@ func (app *BaseApp) RunTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result
 	// meter so we initialize upfront.
 	ctx, msCache, accountCache := app.getContextWithCache(mode, tx, txHash)
 
+	meter, resourceConfig := app.newResourceMeter(mode)
+	if meter != nil {
+		ctx = ctx.WithResourceMeter(meter, resourceConfig)
+	}
+
 	defer func() {
 		if r := recover(); r != nil {
-			log := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
-			result = sdk.ErrInternal(log).Result()
+			switch rType := r.(type) {
+			case sdk.ErrorOutOfResource:
+				log := fmt.Sprintf("out of resource in location: %v, resource used: %d, limit: %d",
+					rType.Descriptor, meter.Consumed(), meter.Limit())
+				result = sdk.ErrOutOfResource(log).Result()
+			default:
+				log := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
+				result = sdk.ErrInternal(log).Result()
+			}
 		}
 
+		if meter != nil {
+			result.ResourceUsed = meter.Consumed()
+			app.consumeBlockResource(mode, meter)
+		}
 	}()
 
+	if meter != nil && meter.Limit() == 0 {
+		return sdk.ErrOutOfResource(fmt.Sprintf("block resource limit %d is reached", MaxBlockResource)).Result()
+	}
+
 	var msgs = tx.GetMsgs()
 	if err := validateBasicTxMsgs(msgs); err != nil {
 		return err.Result()
 	}
+	// the node may accept fewer msgs into its mempool than the consensus allows
+	if (mode == sdk.RunTxModeCheck || mode == sdk.RunTxModeCheckAfterPre || mode == sdk.RunTxModeReCheck) && len(msgs) > app.maxMsgs {
+		return sdk.ErrTooManyMsgs(fmt.Sprintf("tx contains %d msgs, the max of the node is %d", len(msgs), app.maxMsgs)).Result()
+	}
 
 	// run the ante handler
 	ctx = ctx.WithValue(TxHashKey, txHash)
@@ -857,6 +964,11 @@ func (app *BaseApp) RunTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result
 		msgs,
 		mode)
 
+	// the handlers may recover the panic of the meter, the tx fails anyway
+	if result.IsOK() && meter != nil && meter.IsExceeded() {
+		result = sdk.ErrOutOfResource(fmt.Sprintf("resource used: %d, limit: %d", meter.Consumed(), meter.Limit())).Result()
+	}
+
 	if mode == sdk.RunTxModeSimulate {
 		return
 	}
@@ -865,7 +977,9 @@ func (app *BaseApp) RunTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result
 	if result.IsOK() {
 		if mode == sdk.RunTxModeDeliver || mode == sdk.RunTxModeDeliverAfterPre {
 			if app.collect.CollectAccountBalance {
-				app.Pool.AddAddrs(msgs[0].GetInvolvedAddresses())
+				for _, msg := range msgs {
+					app.Pool.AddAddrs(msg.GetInvolvedAddresses())
+				}
 			}
 			if app.collect.CollectTxs {
 				// Should we add all msg here with no distinction ？
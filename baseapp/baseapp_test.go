@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,6 +17,7 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/pubsub"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -222,6 +224,32 @@ func testChangeNameHelper(name string) func(*BaseApp) {
 
 // Test that txs can be unmarshalled and read and that
 // correct error codes are returned when not
+func TestNewBlockEvent(t *testing.T) {
+	server := pubsub.NewServer(defaultLogger())
+	require.NoError(t, server.Start())
+	defer server.Stop()
+	sub, err := server.NewSubscriber("test", defaultLogger())
+	require.NoError(t, err)
+	heights := make(chan int64, 2)
+	require.NoError(t, sub.Subscribe(pubsub.NewBlockTopic, func(event pubsub.Event) {
+		heights <- event.(pubsub.NewBlockEvent).Height
+	}))
+
+	app := setupBaseApp(t, func(app *BaseApp) { app.SetPubSubServer(server) })
+	app.InitChain(abci.RequestInitChain{})
+	for height := int64(1); height <= 2; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		app.EndBlock(abci.RequestEndBlock{Height: height})
+		app.Commit()
+		select {
+		case published := <-heights:
+			require.Equal(t, height, published)
+		case <-time.After(time.Second):
+			t.Fatalf("new block event of height %d is not published", height)
+		}
+	}
+}
+
 func TestTxDecoder(t *testing.T) {
 	// TODO
 }
@@ -596,6 +624,139 @@ func TestMultiMsgDeliverTx(t *testing.T) {
 	}
 }
 
+// Once the multi-message tx is activated, all the msgs of the tx are executed atomically.
+func TestMultiMsgDeliverTxAfterUpgrade(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.MultiMsgTx, 1)
+	defer sdk.UpgradeMgr.Reset()
+
+	// count the executed msgs, the msg with the counter 99 fails
+	deliverKey := []byte("deliver-key")
+	handler := func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		var counter int64
+		switch m := msg.(type) {
+		case msgCounter:
+			counter = m.Counter
+		case msgCounter2:
+			counter = m.Counter
+		}
+		if counter == 99 {
+			return sdk.ErrUnknownRequest("counter 99").Result()
+		}
+		store := ctx.KVStore(capKey1)
+		setIntOnStore(store, deliverKey, getIntFromStore(store, deliverKey)+1)
+		return sdk.Result{Data: []byte{byte(counter)}, Log: "ok", Events: sdk.Events{sdk.NewEvent("counter")}}
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, handler)
+		bapp.Router().AddRoute(routeMsgCounter2, handler)
+	}
+
+	app := setupBaseApp(t, routerOpt, SetMaxMsgsPerTx(3))
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+
+	res := app.Deliver(&txTest{Msgs: []sdk.Msg{msgCounter{1}, msgCounter2{2}}})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, []byte{1, 2}, res.Data)
+	require.Equal(t, "Msg 0: ok\nMsg 1: ok", res.Log)
+	require.Len(t, res.MsgResults, 2)
+	require.Equal(t, []byte{2}, res.MsgResults[1].Data)
+	require.Equal(t, []byte("msg_index"), res.MsgResults[1].Events[0].Attributes[0].Key)
+	require.Equal(t, []byte("1"), res.MsgResults[1].Events[0].Attributes[0].Value)
+	require.Len(t, res.Events, 2)
+	require.True(t, app.DeliverState.Ctx.RouterCallRecord()[routeMsgCounter])
+	require.True(t, app.DeliverState.Ctx.RouterCallRecord()[routeMsgCounter2])
+
+	store := app.DeliverState.Ctx.KVStore(capKey1)
+	require.Equal(t, int64(2), getIntFromStore(store, deliverKey))
+
+	// the executed msgs are reverted if a msg fails
+	res = app.Deliver(&txTest{Msgs: []sdk.Msg{msgCounter{1}, msgCounter2{99}}})
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownRequest), res.Code)
+	require.Contains(t, res.Log, "Msg 1 failed")
+	require.Nil(t, res.MsgResults)
+	require.Equal(t, int64(2), getIntFromStore(store, deliverKey))
+
+	// the max of the node only limits the txs entering the mempool
+	tooManyForNode := &txTest{Msgs: []sdk.Msg{msgCounter{1}, msgCounter{2}, msgCounter{3}, msgCounter{4}}}
+	res = app.Check(tooManyForNode)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeTooManyMsgs), res.Code)
+	res = app.Deliver(tooManyForNode)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(6), getIntFromStore(store, deliverKey))
+
+	tooMany := &txTest{}
+	for i := 0; i <= MaxMsgsPerTx; i++ {
+		tooMany.Msgs = append(tooMany.Msgs, msgCounter{int64(i)})
+	}
+	res = app.Deliver(tooMany)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeTooManyMsgs), res.Code)
+	require.Equal(t, int64(6), getIntFromStore(store, deliverKey))
+}
+
+func TestResourceMetering(t *testing.T) {
+	defer sdk.UpgradeMgr.Reset()
+	deliverKey := []byte("deliver-key")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			store := ctx.KVStore(capKey1)
+			setIntOnStore(store, deliverKey, getIntFromStore(store, deliverKey)+1)
+			return sdk.Result{}
+		})
+	}
+
+	// the tx reading and writing a counter consumes 151 units, and 152 units once the counter is set
+	app := setupBaseApp(t, routerOpt, SetResourceMetering(sdk.DefaultResourceConfig(), 150))
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+
+	// the simulation reports the units consumed with the config of the node, and rejects the tx exceeding its max
+	cdc := codec.New()
+	registerTestCodec(cdc)
+	txBytes, err := cdc.MarshalBinaryLengthPrefixed(newTxCounter(0, 0))
+	require.Nil(t, err)
+	queryResult := app.Query(abci.RequestQuery{Path: "/app/simulate", Data: txBytes})
+	require.True(t, queryResult.IsOK(), queryResult.Log)
+	var simRes sdk.Result
+	codec.Cdc.MustUnmarshalBinaryLengthPrefixed(queryResult.Value, &simRes)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfResource), simRes.Code, simRes.Log)
+	require.Equal(t, uint64(151), simRes.ResourceUsed)
+
+	// the config of the node is not applied to the delivered txs
+	deliverRes := app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+	require.True(t, deliverRes.IsOK(), deliverRes.Log)
+	require.Equal(t, int64(0), deliverRes.GasUsed)
+
+	res := app.Deliver(newTxCounter(1, 1))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, uint64(0), res.ResourceUsed)
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	// the delivered txs are metered with the default config once the metering is activated
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ResourceMetering, 2)
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	res = app.Deliver(newTxCounter(2, 2))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, uint64(152), res.ResourceUsed)
+
+	// the tx fails once the block runs out of the units, and its state changes are discarded
+	app.blockResource = MaxBlockResource - 100
+	res = app.Deliver(newTxCounter(3, 3))
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfResource), res.Code, res.Log)
+	require.Equal(t, int64(3), getIntFromStore(app.DeliverState.Ctx.KVStore(capKey1), deliverKey))
+
+	res = app.Deliver(newTxCounter(4, 4))
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfResource), res.Code, res.Log)
+	require.Equal(t, uint64(0), res.ResourceUsed)
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	// the units are reset in the next block
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	res = app.Deliver(newTxCounter(3, 3))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, uint64(152), res.ResourceUsed)
+}
+
 // Interleave calls to Check and Deliver and ensure
 // that there is no cross-talk. Check sees results of the previous Check calls
 // and Deliver sees that of the previous Deliver calls, but they don't see eachother.
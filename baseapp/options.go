@@ -4,6 +4,7 @@ package baseapp
 import (
 	"fmt"
 
+	"github.com/cosmos/cosmos-sdk/pubsub"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -30,6 +31,30 @@ func SetPruning(pruning string) func(*BaseApp) {
 	}
 }
 
+// SetMaxMsgsPerTx sets the max number of msgs in a tx accepted into the mempool of the node once the multi-message
+// tx is activated, the delivered txs are only limited by MaxMsgsPerTx
+func SetMaxMsgsPerTx(maxMsgs int) func(*BaseApp) {
+	if maxMsgs <= 0 || maxMsgs > MaxMsgsPerTx {
+		panic(fmt.Sprintf("invalid max msgs per tx: %d", maxMsgs))
+	}
+	return func(bap *BaseApp) {
+		bap.maxMsgs = maxMsgs
+	}
+}
+
+// SetResourceMetering meters the state access of the txs checked or simulated by the node with the units of the
+// config, the txs consuming more than maxTxResource units are not accepted into its mempool. The delivered txs are
+// only metered once the resource metering is activated, with the default config and MaxBlockResource.
+func SetResourceMetering(config sdk.ResourceConfig, maxTxResource uint64) func(*BaseApp) {
+	if maxTxResource == 0 {
+		panic("max tx resource should be positive")
+	}
+	return func(bap *BaseApp) {
+		bap.resourceConfig = &config
+		bap.maxTxResource = maxTxResource
+	}
+}
+
 func (app *BaseApp) SetName(name string) {
 	if app.sealed {
 		panic("SetName() on sealed BaseApp")
@@ -100,6 +125,13 @@ func (app *BaseApp) SetPubKeyPeerFilter(pf sdk.PeerFilter) {
 	app.pubkeyPeerFilter = pf
 }
 
+func (app *BaseApp) SetPubSubServer(server *pubsub.Server) {
+	if app.sealed {
+		panic("SetPubSubServer() on sealed BaseApp")
+	}
+	app.pubServer = server
+}
+
 func (app *BaseApp) Router() Router {
 	if app.sealed {
 		panic("Router() on sealed BaseApp")
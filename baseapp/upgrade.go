@@ -0,0 +1,40 @@
+package baseapp
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// nolint
+const (
+	UpgradeQueryRoute = "upgrade"
+	QueryUpgradePlan  = "plan"
+)
+
+// UpgradePlan is the result of the upgrade plan query
+type UpgradePlan struct {
+	Height   int64             `json:"height"` // height of the latest block the upgrades are applied to
+	Upgrades []sdk.UpgradeInfo `json:"upgrades"`
+}
+
+// NewUpgradeQuerier returns the querier of the upgrades registered into sdk.UpgradeMgr
+func NewUpgradeQuerier() sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 || path[0] != QueryUpgradePlan {
+			return nil, sdk.ErrUnknownRequest("unknown upgrade query endpoint")
+		}
+
+		plan := UpgradePlan{
+			Height:   sdk.UpgradeMgr.GetHeight(),
+			Upgrades: sdk.UpgradeMgr.GetUpgradeInfos(),
+		}
+		res, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return nil, sdk.ErrInternal(err.Error())
+		}
+		return res, nil
+	}
+}
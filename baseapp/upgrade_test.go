@@ -0,0 +1,31 @@
+package baseapp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestQueryUpgradePlan(t *testing.T) {
+	defer sdk.UpgradeMgr.Reset()
+	app := setupBaseApp(t)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.BEP9, 10)
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 10}})
+
+	res := app.Query(abci.RequestQuery{Path: "/custom/upgrade/plan"})
+	require.Equal(t, uint32(sdk.ABCICodeOK), res.Code, res.Log)
+
+	var plan UpgradePlan
+	require.NoError(t, json.Unmarshal(res.Value, &plan))
+	require.Equal(t, int64(10), plan.Height)
+	require.Equal(t, sdk.BEP9, plan.Upgrades[0].Name)
+	require.True(t, plan.Upgrades[0].Activated)
+
+	res = app.Query(abci.RequestQuery{Path: "/custom/upgrade/unknown"})
+	require.NotEqual(t, uint32(sdk.ABCICodeOK), res.Code)
+}
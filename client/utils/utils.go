@@ -109,6 +109,7 @@ func printTxResult(result sdk.Result) {
 	fmt.Println(fmt.Sprintf("log: %v", result.Log))
 	fmt.Println(fmt.Sprintf("fee_amount: %v", result.FeeAmount))
 	fmt.Println(fmt.Sprintf("fee_denom: %v", result.FeeDenom))
+	fmt.Println(fmt.Sprintf("resource_used: %v", result.ResourceUsed))
 	for _, tag := range result.Tags {
 		fmt.Println(fmt.Sprintf("tag: %s = %s", string(tag.Key), string(tag.Value)))
 	}
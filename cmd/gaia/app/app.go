@@ -8,6 +8,7 @@ import (
 	"sort"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
@@ -15,12 +16,15 @@ import (
 
 	bam "github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/pubsub"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/lightclient"
 	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
@@ -63,6 +67,7 @@ type GaiaApp struct {
 	tkeyParams       *sdk.TransientStoreKey
 	keyIbc           *sdk.KVStoreKey
 	keySide          *sdk.KVStoreKey
+	keyLightClient   *sdk.KVStoreKey
 
 	// Manage getting and setting accounts
 	accountKeeper       auth.AccountKeeper
@@ -75,6 +80,12 @@ type GaiaApp struct {
 	govKeeper           gov.Keeper
 	paramsKeeper        params.Keeper
 	ibcKeeper           ibc.Keeper
+	scKeeper            sidechain.Keeper
+	lightClientKeeper   lightclient.Keeper
+
+	// the events of the modules are published once the server is started
+	pubServer *pubsub.Server
+	pubBridge *pubsub.Bridge
 }
 
 // NewGaiaApp returns a reference to an initialized GaiaApp.
@@ -102,8 +113,12 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		tkeyParams:       sdk.NewTransientStoreKey("transient_params"),
 		keyIbc:           sdk.NewKVStoreKey("ibc"),
 		keySide:          sdk.NewKVStoreKey("sc"),
+		keyLightClient:   sdk.NewKVStoreKey("lightclient"),
 	}
 
+	app.pubServer = pubsub.NewServer(logger.With("module", "pubsub"))
+	app.SetPubSubServer(app.pubServer)
+
 	// define the accountKeeper
 	app.accountKeeper = auth.NewAccountKeeper(
 		app.cdc,
@@ -121,8 +136,9 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		app.cdc,
 		app.keyParams, app.tkeyParams,
 	)
+	app.scKeeper = sidechain.NewKeeper(app.keySide, app.paramsKeeper.Subspace(sidechain.DefaultParamspace), app.cdc)
 	app.ibcKeeper = ibc.NewKeeper(app.keyIbc, app.paramsKeeper.Subspace(ibc.DefaultParamspace), ibc.DefaultCodespace,
-		sidechain.NewKeeper(app.keySide, app.paramsKeeper.Subspace(sidechain.DefaultParamspace), app.cdc))
+		app.scKeeper)
 	app.stakeKeeper = stake.NewKeeper(
 		app.cdc,
 		app.keyStake, app.keyStakeReward, app.tkeyStake,
@@ -130,6 +146,7 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		app.RegisterCodespace(stake.DefaultCodespace),
 		sdk.ChainID(0), "",
 	)
+	app.stakeKeeper.SetPbsbServer(app.pubServer)
 	app.mintKeeper = mint.NewKeeper(app.cdc, app.keyMint,
 		app.paramsKeeper.Subspace(mint.DefaultParamspace),
 		app.stakeKeeper,
@@ -148,6 +165,7 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		app.RegisterCodespace(slashing.DefaultCodespace),
 		app.bankKeeper,
 	)
+	app.slashingKeeper.SetPbsbServer(app.pubServer)
 	app.govKeeper = gov.NewKeeper(
 		app.cdc,
 		app.keyGov,
@@ -155,6 +173,15 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		app.RegisterCodespace(gov.DefaultCodespace),
 		app.Pool,
 	)
+	app.scKeeper.SetGovKeeper(&app.govKeeper)
+	app.lightClientKeeper = lightclient.NewKeeper(app.cdc, app.keyLightClient,
+		app.paramsKeeper.Subspace(lightclient.DefaultParamspace), app.RegisterCodespace(lightclient.DefaultCodespace), app.scKeeper)
+	app.lightClientKeeper.SetGovKeeper(&app.govKeeper)
+	app.slashingKeeper.SetSideChain(&app.scKeeper)
+	app.slashingKeeper.SetLightClientKeeper(app.lightClientKeeper)
+	app.govKeeper.AddHooks(gov.ProposalTypeRegisterSideChain, sidechain.NewSideChainRegistrationHook(app.cdc, &app.scKeeper))
+	app.govKeeper.AddHooks(gov.ProposalTypeCreateLightClient, lightclient.NewCreateClientHooks(app.cdc, app.lightClientKeeper))
+	lightclient.RegisterUpgradeBeginBlocker(app.lightClientKeeper)
 
 	// register the staking hooks
 	app.stakeKeeper = app.stakeKeeper.WithHooks(
@@ -166,20 +193,28 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		AddRoute("stake", stake.NewStakeHandler(app.stakeKeeper)).
 		AddRoute("distr", distr.NewHandler(app.distrKeeper)).
 		AddRoute("slashing", slashing.NewSlashingHandler(app.slashingKeeper)).
-		AddRoute("gov", gov.NewHandler(app.govKeeper))
+		AddRoute("gov", gov.NewHandler(app.govKeeper)).
+		AddRoute(lightclient.MsgRoute, lightclient.NewHandler(app.lightClientKeeper))
 
 	app.QueryRouter().
 		AddRoute("gov", gov.NewQuerier(app.govKeeper)).
-		AddRoute("stake", stake.NewQuerier(app.stakeKeeper, app.cdc))
+		AddRoute("stake", stake.NewQuerier(app.stakeKeeper, app.cdc)).
+		AddRoute("ibc", ibc.NewQuerier(app.ibcKeeper, app.cdc, app.GetCommitMultiStore().(sdk.Queryable)))
+
+	// the side chain stores are committed once the side chains can be registered by gov
+	sdk.UpgradeMgr.RegisterStoreKeysByName(sdk.SideChainRegistry, app.keySide.Name())
+	sdk.UpgradeMgr.RegisterStoreKeysByName(sdk.LightClient, app.keyLightClient.Name())
+	sdk.UpgradeMgr.RegisterMsgTypesByName(sdk.LightClient, lightclient.MsgSubmitSideChainHeaders{}.Type())
 
 	// initialize BaseApp
 	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyStake, app.keyStakeReward, app.keyMint, app.keyDistr,
-		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams, app.keyIbc)
+		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams, app.keyIbc, app.keySide, app.keyLightClient)
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountKeeper))
+	app.SetAnteHandler(auth.NewFeeAnteHandler(app.accountKeeper, auth.NewAnteHandler(app.accountKeeper)))
 	app.MountStoresTransient(app.tkeyParams, app.tkeyStake, app.tkeyDistr)
 	app.SetEndBlocker(app.EndBlocker)
+	registerFeeCalculators()
 
 	err := app.LoadCMSLatestVersion()
 	if err != nil {
@@ -194,9 +229,92 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		cmn.Exit(err.Error())
 	}
 
+	// schedule the upgrades of the passed software upgrade proposals
+	app.govKeeper.LoadUpgradePlans(app.NewContext(sdk.RunTxModeCheck, abci.Header{}).WithBlockHeight(app.LastBlockHeight()))
+	// the light clients work on the side chains registered by gov
+	if err := app.scKeeper.LoadSideChainRegistrations(app.NewContext(sdk.RunTxModeCheck, abci.Header{})); err != nil {
+		cmn.Exit(err.Error())
+	}
+
 	return app
 }
 
+// feeMsgTypes are the types of the msgs routed by gaia, the decodable msgs which are not routed, e.g. the side chain
+// msgs of slashing, are rejected as not supported by the fee ante handler
+var feeMsgTypes = []string{
+	bank.MsgSend{}.Type(),
+	stake.MsgCreateValidator{}.Type(),
+	stake.MsgEditValidator{}.Type(),
+	stake.MsgDelegate{}.Type(),
+	stake.MsgRedelegate{}.Type(),
+	stake.MsgBeginUnbonding{}.Type(),
+	distr.MsgSetWithdrawAddress{}.Type(),
+	distr.MsgWithdrawDelegatorRewardsAll{}.Type(),
+	distr.MsgWithdrawDelegatorReward{}.Type(),
+	distr.MsgWithdrawValidatorRewardsAll{}.Type(),
+	slashing.MsgUnjail{}.Type(),
+	gov.MsgSubmitProposal{}.Type(),
+	gov.MsgDeposit{}.Type(),
+	gov.MsgVote{}.Type(),
+	gov.MsgSideChainSubmitProposal{}.Type(),
+	gov.MsgSideChainDeposit{}.Type(),
+	gov.MsgSideChainVote{}.Type(),
+	lightclient.MsgSubmitSideChainHeaders{}.Type(),
+}
+
+// registerFeeCalculators makes the msgs of gaia free unless the fee calculators have been registered for them,
+// e.g. by the fee params of paramHub
+func registerFeeCalculators() {
+	for _, msgType := range feeMsgTypes {
+		if fees.GetCalculator(msgType) == nil {
+			fees.RegisterCalculator(msgType, fees.FreeFeeCalculator())
+		}
+	}
+}
+
+// DeliverTx commits the fee of the tx into fees.Pool once the tx succeeds
+func (app *GaiaApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliverTx) {
+	res = app.BaseApp.DeliverTx(req)
+	if res.IsOK() {
+		txHash := cmn.HexBytes(tmhash.Sum(req.Tx)).String()
+		fees.Pool.CommitFee(txHash)
+	}
+	return res
+}
+
+// EnableStakeSnapshotArchive keeps the validator-set snapshots of the latest keepDays days in the db, so that
+// the rewards can be audited with the archived snapshot queries of stake
+func (app *GaiaApp) EnableStakeSnapshotArchive(db dbm.DB, keepDays int) {
+	app.stakeKeeper.EnableSnapshotArchive(db, keepDays)
+}
+
+// EnablePubSubBridge streams the events published by the modules to the out-of-process clients of a local
+// WebSocket endpoint, it takes effect once the pubsub server is started
+func (app *GaiaApp) EnablePubSubBridge(cfg pubsub.BridgeConfig) {
+	app.pubBridge = pubsub.NewBridge(app.pubServer, app.cdc, cfg, app.Logger.With("module", "pubsubBridge"))
+}
+
+// EnablePubSubJournal records the events published by the modules in the db, so that the subscribers can replay
+// them from a height after a restart. The events of the latest keepHeights heights are kept, 0 means all.
+func (app *GaiaApp) EnablePubSubJournal(db dbm.DB, keepHeights int64) {
+	journal := pubsub.NewJournal(db, keepHeights)
+	journal.RegisterEvent(pubsub.NewBlockEvent{}, "pubsub/NewBlockEvent")
+	stake.RegisterJournalEvents(journal)
+	slashing.RegisterJournalEvents(journal)
+	app.pubServer.SetJournal(journal)
+}
+
+// StartPubSub starts publishing the events of the modules, and the bridge if it is enabled
+func (app *GaiaApp) StartPubSub() error {
+	if err := app.pubServer.Start(); err != nil {
+		return err
+	}
+	if app.pubBridge != nil {
+		return app.pubBridge.Start()
+	}
+	return nil
+}
+
 // custom tx codec
 func MakeCodec() *codec.Codec {
 	var cdc = codec.New()
@@ -205,6 +323,7 @@ func MakeCodec() *codec.Codec {
 	distr.RegisterCodec(cdc)
 	slashing.RegisterCodec(cdc)
 	gov.RegisterCodec(cdc)
+	lightclient.RegisterCodec(cdc)
 	auth.RegisterCodec(cdc)
 	sdk.RegisterCodec(cdc)
 	codec.RegisterCrypto(cdc)
@@ -233,10 +352,20 @@ func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.R
 	gov.EndBlocker(ctx, app.govKeeper)
 	validatorUpdates, _ := stake.EndBlocker(ctx, app.stakeKeeper)
 	ibc.EndBlocker(ctx, app.ibcKeeper)
+	// the side chains are registered before the light clients of the same block are created
+	sidechain.EndBlock(ctx, app.scKeeper)
+	lightclient.EndBlocker(ctx, app.lightClientKeeper)
 
 	// Add these new validators to the addr -> pubkey map.
 	app.slashingKeeper.AddValidators(ctx, validatorUpdates)
 
+	// the fees of the block are distributed with the rewards in the next block. Gaia doesn't tell FeeForProposer from
+	// FeeForAll, the distribution gives the proposer its bonus share of all the collected fees either way
+	if blockFees := fees.Pool.BlockFees(); !blockFees.IsEmpty() {
+		app.feeCollectionKeeper.AddCollectedFees(ctx, blockFees.Tokens)
+	}
+	fees.Pool.Clear()
+
 	return abci.ResponseEndBlock{
 		ValidatorUpdates: validatorUpdates,
 		Events:           ctx.EventManager().ABCIEvents(),
@@ -2,24 +2,31 @@ package app
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"testing"
+	"time"
 
 	bam "github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/pubsub"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
 	"github.com/cosmos/cosmos-sdk/x/mint"
+	"github.com/cosmos/cosmos-sdk/x/mock"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/libs/db"
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -199,3 +206,183 @@ func TestGaiadExport(t *testing.T) {
 	_, _, err := newGapp.ExportAppStateAndValidators()
 	require.NoError(t, err, "ExportAppStateAndValidators should not have an error")
 }
+
+func TestGaiaAppFees(t *testing.T) {
+	defer fees.UnsetAllCalculators()
+	defer fees.Pool.Clear()
+
+	// the calculator registered before the app is kept, and the other msgs are free
+	send := bank.MsgSend{}.Type()
+	fees.UnsetAllCalculators()
+	fees.RegisterCalculator(send, fees.FixedFeeCalculator(10, sdk.FeeForProposer))
+	gapp := NewGaiaApp(log.NewNopLogger(), db.NewMemDB(), nil)
+	require.NotNil(t, fees.GetCalculator(gov.MsgVote{}.Type()))
+	require.NotNil(t, fees.GetCalculator(gov.MsgSideChainVote{}.Type()))
+
+	priv := ed25519.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	acc := &auth.BaseAccount{Address: addr, Coins: sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 100)}}
+	genesisState := GenesisState{
+		Accounts:     []GenesisAccount{NewGenesisAccount(acc)},
+		StakeData:    stake.DefaultGenesisState(),
+		DistrData:    distr.DefaultGenesisState(),
+		SlashingData: slashing.DefaultGenesisState(),
+	}
+	stateBytes, err := codec.MarshalJSONIndent(gapp.cdc, genesisState)
+	require.NoError(t, err)
+	gapp.InitChain(abci.RequestInitChain{AppStateBytes: stateBytes})
+	gapp.Commit()
+
+	gapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	coins := sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 40)}
+	msg := bank.NewMsgSend([]bank.Input{bank.NewInput(addr, coins)}, []bank.Output{bank.NewOutput(addr, coins)})
+	tx := mock.GenTx([]sdk.Msg{msg}, []int64{0}, []int64{0}, priv)
+	res := gapp.DeliverTx(abci.RequestDeliverTx{Tx: gapp.cdc.MustMarshalBinaryLengthPrefixed(tx)})
+	require.True(t, res.IsOK(), res.Log)
+
+	ctx := gapp.DeliverState.Ctx
+	require.Equal(t, int64(90), gapp.accountKeeper.GetAccount(ctx, addr).GetCoins().AmountOf(sdk.NativeTokenSymbol))
+	require.Equal(t, sdk.NewFee(sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 10)}, sdk.FeeForProposer), fees.Pool.BlockFees())
+
+	// the fees for the proposer and the ones for all are collapsed in the block
+	fees.RegisterCalculator(send, fees.FixedFeeCalculator(20, sdk.FeeForAll))
+	tx = mock.GenTx([]sdk.Msg{msg}, []int64{0}, []int64{1}, priv)
+	res = gapp.DeliverTx(abci.RequestDeliverTx{Tx: gapp.cdc.MustMarshalBinaryLengthPrefixed(tx)})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.NewFee(sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 30)}, sdk.FeeForAll), fees.Pool.BlockFees())
+
+	// the msgs which are not routed by gaia are not supported
+	unjail := slashing.NewMsgSideChainUnjail(sdk.ValAddress(addr), "axc")
+	tx = mock.GenTx([]sdk.Msg{unjail}, []int64{0}, []int64{2}, priv)
+	res = gapp.DeliverTx(abci.RequestDeliverTx{Tx: gapp.cdc.MustMarshalBinaryLengthPrefixed(tx)})
+	require.EqualValues(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeMsgNotSupported), res.Code, res.Log)
+
+	// all the fees of the block are collected to be distributed with the proposer bonus
+	gapp.EndBlock(abci.RequestEndBlock{})
+	require.Equal(t, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 30)}, gapp.feeCollectionKeeper.GetCollectedFees(ctx))
+	require.True(t, fees.Pool.BlockFees().IsEmpty())
+}
+
+func TestGaiaAppPubSubBridge(t *testing.T) {
+	gapp := NewGaiaApp(log.NewNopLogger(), db.NewMemDB(), nil)
+	cfg := pubsub.DefaultBridgeConfig()
+	cfg.Addr = "127.0.0.1:0"
+	cfg.Topics = []pubsub.Topic{stake.Topic}
+	gapp.EnablePubSubBridge(cfg)
+	require.NoError(t, gapp.StartPubSub())
+	defer gapp.pubBridge.Stop()
+	defer gapp.pubServer.Stop()
+
+	conn, _, err := websocket.DefaultDialer.Dial(
+		fmt.Sprintf("ws://%s%s?client_id=test&from_height=1", gapp.pubBridge.Addr(), pubsub.BridgeEventsPath), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	stateBytes, err := codec.MarshalJSONIndent(gapp.cdc, GenesisState{
+		StakeData:    stake.DefaultGenesisState(),
+		DistrData:    distr.DefaultGenesisState(),
+		SlashingData: slashing.DefaultGenesisState(),
+	})
+	require.NoError(t, err)
+	gapp.InitChain(abci.RequestInitChain{AppStateBytes: stateBytes})
+	gapp.Commit()
+	gapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+
+	// the new block events published by the base app are streamed
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	var frame struct {
+		Height int64        `json:"height"`
+		Topic  pubsub.Topic `json:"topic"`
+	}
+	require.NoError(t, conn.ReadJSON(&frame))
+	require.Equal(t, pubsub.NewBlockTopic, frame.Topic)
+	require.EqualValues(t, 1, frame.Height)
+}
+
+func TestGaiaAppPubSubJournal(t *testing.T) {
+	journalDB := db.NewMemDB()
+	gapp := NewGaiaApp(log.NewNopLogger(), db.NewMemDB(), nil)
+	gapp.EnablePubSubJournal(journalDB, 0)
+	require.NoError(t, gapp.StartPubSub())
+	defer gapp.pubServer.Stop()
+
+	stateBytes, err := codec.MarshalJSONIndent(gapp.cdc, GenesisState{
+		StakeData:    stake.DefaultGenesisState(),
+		DistrData:    distr.DefaultGenesisState(),
+		SlashingData: slashing.DefaultGenesisState(),
+	})
+	require.NoError(t, err)
+	gapp.InitChain(abci.RequestInitChain{AppStateBytes: stateBytes})
+	gapp.Commit()
+	gapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	validator := stake.NewValidator(sdk.ValAddress(ed25519.GenPrivKey().PubKey().Address()), ed25519.GenPrivKey().PubKey(), stake.Description{})
+	gapp.stakeKeeper.SetValidator(gapp.DeliverState.Ctx, validator)
+
+	// the events of the modules are recorded after the new block event of their block
+	journal := pubsub.NewJournal(journalDB, 0)
+	journal.RegisterEvent(pubsub.NewBlockEvent{}, "pubsub/NewBlockEvent")
+	stake.RegisterJournalEvents(journal)
+	var entries []pubsub.JournalEntry
+	require.Eventually(t, func() bool {
+		entries = entries[:0]
+		require.NoError(t, journal.Iterate(1, func(entry pubsub.JournalEntry) bool {
+			entries = append(entries, entry)
+			return false
+		}))
+		return len(entries) == 2
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, pubsub.NewBlockEvent{Height: 1}, entries[0].Event)
+	require.EqualValues(t, 1, entries[1].Height)
+	require.Equal(t, validator.OperatorAddr, entries[1].Event.(stake.ValidatorUpdateEvent).Validator.OperatorAddr)
+}
+
+// The handlers of gaia keep working on the metered state access once the resource metering is activated.
+func TestGaiaAppResourceMetering(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ResourceMetering, 1)
+	defer sdk.UpgradeMgr.Reset()
+	defer fees.UnsetAllCalculators()
+	defer fees.Pool.Clear()
+
+	fees.UnsetAllCalculators()
+	gapp := NewGaiaApp(log.NewNopLogger(), db.NewMemDB(), nil)
+
+	priv := ed25519.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	acc := &auth.BaseAccount{Address: addr, Coins: sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 100), sdk.NewCoin(gov.DefaultDepositDenom, 3000e8)}}
+	genesisState := GenesisState{
+		Accounts:     []GenesisAccount{NewGenesisAccount(acc)},
+		StakeData:    stake.DefaultGenesisState(),
+		DistrData:    distr.DefaultGenesisState(),
+		SlashingData: slashing.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
+	}
+	stateBytes, err := codec.MarshalJSONIndent(gapp.cdc, genesisState)
+	require.NoError(t, err)
+	gapp.InitChain(abci.RequestInitChain{AppStateBytes: stateBytes})
+	gapp.Commit()
+
+	gapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	coins := sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 40)}
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 1000e8)}
+	msgs := []sdk.Msg{
+		bank.NewMsgSend([]bank.Input{bank.NewInput(addr, coins)}, []bank.Output{bank.NewOutput(addr, coins)}),
+		gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addr, deposit, time.Hour),
+		gov.NewMsgDeposit(addr, 1, deposit),
+	}
+	for i, msg := range msgs {
+		tx := mock.GenTx([]sdk.Msg{msg}, []int64{0}, []int64{int64(i)}, priv)
+		res := gapp.DeliverTx(abci.RequestDeliverTx{Tx: gapp.cdc.MustMarshalBinaryLengthPrefixed(tx)})
+		require.True(t, res.IsOK(), res.Log)
+		// the accounts are accessed through the account cache, only the stores of gov are charged
+		if _, ok := msg.(bank.MsgSend); !ok {
+			require.True(t, res.GasUsed > 0, res.Log)
+		}
+	}
+	gapp.EndBlock(abci.RequestEndBlock{})
+
+	ctx := gapp.DeliverState.Ctx
+	proposal := gapp.govKeeper.GetProposal(ctx, 1)
+	require.NotNil(t, proposal)
+	require.Equal(t, sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}, proposal.GetTotalDeposit())
+	require.Equal(t, int64(1000e8), gapp.accountKeeper.GetAccount(ctx, addr).GetCoins().AmountOf(gov.DefaultDepositDenom))
+}
@@ -20,6 +20,9 @@ import (
 	bankcmd "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
 	distrcmd "github.com/cosmos/cosmos-sdk/x/distribution/client/cli"
 	govcmd "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
+	ibccmd "github.com/cosmos/cosmos-sdk/x/ibc/client/cli"
+	lightclientcmd "github.com/cosmos/cosmos-sdk/x/lightclient/client/cli"
+	sidechaincmd "github.com/cosmos/cosmos-sdk/x/sidechain/client/cli"
 	slashingcmd "github.com/cosmos/cosmos-sdk/x/slashing/client/cli"
 	stakecmd "github.com/cosmos/cosmos-sdk/x/stake/client/cli"
 )
@@ -27,6 +30,7 @@ import (
 const (
 	storeAcc      = "acc"
 	storeGov      = "gov"
+	storeIbc      = "ibc"
 	storeSlashing = "slashing"
 	storeStake    = "stake"
 )
@@ -83,6 +87,7 @@ func main() {
 		govcmd.GetCmdQueryVote(storeGov, cdc),
 		govcmd.GetCmdQueryVotes(storeGov, cdc),
 	)...)
+	queryCmd.AddCommand(ibccmd.GetQueryCmd(storeIbc, cdc))
 
 	//Add query commands
 	txCmd := &cobra.Command{
@@ -111,8 +116,12 @@ func main() {
 			bankcmd.SendTxCmd(cdc),
 			govcmd.GetCmdSubmitProposal(cdc),
 			govcmd.GetCmdSubmitListProposal(cdc),
+			govcmd.GetCmdSubmitUpgradeProposal(cdc),
 			slashingcmd.GetCmdUnjail(cdc),
 			govcmd.GetCmdVote(cdc),
+			sidechaincmd.SubmitSideChainRegistrationProposalCmd(cdc),
+			lightclientcmd.GetCmdSubmitCreateClientProposal(cdc),
+			lightclientcmd.GetCmdSubmitSideChainHeaders(cdc),
 		)...)
 	rootCmd.AddCommand(
 		queryCmd,
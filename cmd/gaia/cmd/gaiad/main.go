@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"io"
+	"path/filepath"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 
@@ -11,13 +12,25 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/cli"
+	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
 	gaiaInit "github.com/cosmos/cosmos-sdk/cmd/gaia/init"
+	"github.com/cosmos/cosmos-sdk/pubsub"
 	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+const (
+	flagStakeSnapshotArchiveDays = "stake-snapshot-archive-days"
+	flagPubSubBridgeAddr         = "pubsub-bridge-addr"
+	flagPubSubBridgeTopics       = "pubsub-bridge-topics"
+	flagPubSubJournal            = "pubsub-journal"
+	flagPubSubJournalKeepHeights = "pubsub-journal-keep-heights"
 )
 
 func main() {
@@ -35,6 +48,17 @@ func main() {
 	rootCmd.AddCommand(gaiaInit.GenTxCmd(ctx, cdc))
 
 	server.AddCommands(ctx, cdc, rootCmd, exportAppStateAndTMValidators)
+	rootCmd.AddCommand(server.UpgradePlanCmd(cdc))
+	rootCmd.PersistentFlags().Int(flagStakeSnapshotArchiveDays, 0,
+		"keep the validator-set snapshots of the latest days for reward auditing, 0 disables the archive")
+	rootCmd.PersistentFlags().String(flagPubSubBridgeAddr, "",
+		"listen address of the WebSocket endpoint streaming the pubsub events, e.g. 127.0.0.1:26661, empty disables the bridge")
+	rootCmd.PersistentFlags().StringSlice(flagPubSubBridgeTopics, []string{string(stake.Topic), string(slashing.Topic)},
+		"topics of the pubsub events streamed by the bridge")
+	rootCmd.PersistentFlags().Bool(flagPubSubJournal, false,
+		"record the pubsub events on disk, so that the subscribers can replay them from a height")
+	rootCmd.PersistentFlags().Int64(flagPubSubJournalKeepHeights, 0,
+		"keep the pubsub events of the latest heights in the journal, 0 keeps all the events")
 
 	// prepare and add flags
 	executor := cli.PrepareBaseCmd(rootCmd, "GA", app.DefaultNodeHome)
@@ -46,9 +70,34 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
-	return app.NewGaiaApp(logger, db, traceStore,
+	gApp := app.NewGaiaApp(logger, db, traceStore,
 		baseapp.SetPruning(viper.GetString("pruning")),
 	)
+	if keepDays := viper.GetInt(flagStakeSnapshotArchiveDays); keepDays > 0 {
+		archiveDB := dbm.NewDB("stake_snapshots", dbm.LevelDBBackend, filepath.Join(viper.GetString(cli.HomeFlag), "data"))
+		gApp.EnableStakeSnapshotArchive(archiveDB, keepDays)
+	}
+	pubSubEnabled := false
+	if viper.GetBool(flagPubSubJournal) {
+		journalDB := dbm.NewDB("pubsub_journal", dbm.LevelDBBackend, filepath.Join(viper.GetString(cli.HomeFlag), "data"))
+		gApp.EnablePubSubJournal(journalDB, viper.GetInt64(flagPubSubJournalKeepHeights))
+		pubSubEnabled = true
+	}
+	if addr := viper.GetString(flagPubSubBridgeAddr); addr != "" {
+		cfg := pubsub.DefaultBridgeConfig()
+		cfg.Addr = addr
+		for _, topic := range viper.GetStringSlice(flagPubSubBridgeTopics) {
+			cfg.Topics = append(cfg.Topics, pubsub.Topic(topic))
+		}
+		gApp.EnablePubSubBridge(cfg)
+		pubSubEnabled = true
+	}
+	if pubSubEnabled {
+		if err := gApp.StartPubSub(); err != nil {
+			cmn.Exit(err.Error())
+		}
+	}
+	return gApp
 }
 
 func exportAppStateAndTMValidators(
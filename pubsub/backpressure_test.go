@@ -0,0 +1,189 @@
+package pubsub
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/stretchr/testify/require"
+)
+
+const slowTopic = Topic("slow")
+
+type seqEvent struct {
+	seq int
+}
+
+func (event seqEvent) GetTopic() Topic {
+	return slowTopic
+}
+
+// slowSubscriber blocks in the handler of its first event until it is released
+type slowSubscriber struct {
+	*Subscriber
+	entered chan struct{}
+	release chan struct{}
+
+	mtx     sync.Mutex
+	handled []int
+}
+
+func newSlowSubscriber(t *testing.T, server *Server, clientID ClientID, cfg SubscriberConfig) *slowSubscriber {
+	sub, err := server.NewSubscriberWithConfig(clientID, nil, cfg)
+	require.NoError(t, err)
+	slow := &slowSubscriber{Subscriber: sub, entered: make(chan struct{}), release: make(chan struct{})}
+	require.NoError(t, sub.Subscribe(slowTopic, func(event Event) {
+		slow.mtx.Lock()
+		first := len(slow.handled) == 0
+		slow.handled = append(slow.handled, event.(seqEvent).seq)
+		slow.mtx.Unlock()
+		if first {
+			close(slow.entered)
+			<-slow.release
+		}
+	}))
+	return slow
+}
+
+func (slow *slowSubscriber) waitEntered(t *testing.T) {
+	select {
+	case <-slow.entered:
+	case <-time.After(time.Second):
+		t.Fatal("the slow subscriber did not handle the first event")
+	}
+}
+
+func (slow *slowSubscriber) getHandled() []int {
+	slow.mtx.Lock()
+	defer slow.mtx.Unlock()
+	return append([]int{}, slow.handled...)
+}
+
+func (slow *slowSubscriber) requireHandled(t *testing.T, expected ...int) {
+	require.Eventually(t, func() bool {
+		return len(slow.getHandled()) == len(expected)
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, expected, slow.getHandled())
+}
+
+// recordingCounter is a counter whose values are kept per label values
+type recordingCounter struct {
+	mtx    *sync.Mutex
+	values map[string]float64
+	labels string
+}
+
+func newRecordingCounter() *recordingCounter {
+	return &recordingCounter{mtx: &sync.Mutex{}, values: make(map[string]float64)}
+}
+
+func (c *recordingCounter) With(labelValues ...string) metrics.Counter {
+	return &recordingCounter{mtx: c.mtx, values: c.values, labels: strings.Join(labelValues, ",")}
+}
+
+func (c *recordingCounter) Add(delta float64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.values[c.labels] += delta
+}
+
+func (c *recordingCounter) Value(labelValues ...string) float64 {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.values[strings.Join(labelValues, ",")]
+}
+
+func startServerWithMetrics(t *testing.T) (*Server, *recordingCounter, *recordingCounter) {
+	published, dropped := newRecordingCounter(), newRecordingCounter()
+	server := NewServer(nil)
+	server.SetMetrics(&Metrics{PublishedEvents: published, DroppedEvents: dropped, DeliveryLatency: discard.NewHistogram()})
+	require.NoError(t, server.Start())
+	return server, published, dropped
+}
+
+func publishSeq(server *Server, from, to int) {
+	for i := from; i <= to; i++ {
+		server.Publish(seqEvent{seq: i})
+	}
+}
+
+func TestOverflowDropOldest(t *testing.T) {
+	server, published, dropped := startServerWithMetrics(t)
+	defer server.Stop()
+
+	slow := newSlowSubscriber(t, server, "slow", SubscriberConfig{BufferSize: 2, Policy: OverflowDropOldest})
+	fast := newSlowSubscriber(t, server, "fast", DefaultSubscriberConfig())
+	close(fast.release)
+
+	publishSeq(server, 1, 1)
+	slow.waitEntered(t)
+	publishSeq(server, 2, 5)
+
+	// the publishing is not stalled by the slow subscriber
+	fast.requireHandled(t, 1, 2, 3, 4, 5)
+	close(slow.release)
+	slow.requireHandled(t, 1, 4, 5)
+	require.Equal(t, float64(5), published.Value("topic", string(slowTopic)))
+	require.Equal(t, float64(2), dropped.Value("topic", string(slowTopic)))
+}
+
+func TestOverflowDisconnect(t *testing.T) {
+	server, _, dropped := startServerWithMetrics(t)
+	defer server.Stop()
+
+	slow := newSlowSubscriber(t, server, "slow", SubscriberConfig{BufferSize: 1, Policy: OverflowDisconnect})
+	fast := newSlowSubscriber(t, server, "fast", DefaultSubscriberConfig())
+	close(fast.release)
+
+	publishSeq(server, 1, 1)
+	slow.waitEntered(t)
+	publishSeq(server, 2, 4)
+
+	select {
+	case <-slow.Disconnected():
+	case <-time.After(time.Second):
+		t.Fatal("the slow subscriber is not disconnected")
+	}
+	require.False(t, server.HasSubscribed("slow", slowTopic))
+	fast.requireHandled(t, 1, 2, 3, 4)
+	require.Equal(t, float64(1), dropped.Value("topic", string(slowTopic)))
+
+	close(slow.release)
+	require.NotContains(t, slow.getHandled(), 3)
+	require.NotContains(t, slow.getHandled(), 4)
+
+	// the client id can subscribe again
+	_, err := server.NewSubscriber("slow", nil)
+	require.NoError(t, err)
+}
+
+func TestOverflowBlock(t *testing.T) {
+	server, _, dropped := startServerWithMetrics(t)
+	defer server.Stop()
+
+	slow := newSlowSubscriber(t, server, "slow", SubscriberConfig{BufferSize: 1, Policy: OverflowBlock})
+	fast := newSlowSubscriber(t, server, "fast", DefaultSubscriberConfig())
+	close(fast.release)
+
+	publishSeq(server, 1, 1)
+	slow.waitEntered(t)
+	go publishSeq(server, 2, 4)
+
+	// the slow subscriber stalls the publishing for everyone
+	time.Sleep(100 * time.Millisecond)
+	require.True(t, len(fast.getHandled()) < 4)
+
+	close(slow.release)
+	fast.requireHandled(t, 1, 2, 3, 4)
+	slow.requireHandled(t, 1, 2, 3, 4)
+	require.Equal(t, float64(0), dropped.Value("topic", string(slowTopic)))
+}
+
+func TestUnknownOverflowPolicy(t *testing.T) {
+	server := NewServer(nil)
+	_, err := server.NewSubscriberWithConfig("client", nil, SubscriberConfig{Policy: OverflowPolicy(10)})
+	require.Equal(t, ErrUnknownOverflowPolicy, err)
+}
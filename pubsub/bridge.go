@@ -0,0 +1,385 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+const (
+	EncodingJSON  = "json"
+	EncodingAmino = "amino"
+
+	// BridgeClientID is the client id of the bridge on the pubsub server
+	BridgeClientID = ClientID("pubsub-bridge")
+
+	BridgeEventsPath = "/events"
+
+	bridgeWriteWait = 10 * time.Second
+)
+
+// BridgeConfig is the config of the out-of-process pubsub bridge
+type BridgeConfig struct {
+	// local address the WebSocket endpoint listens on
+	Addr string
+	// topics forwarded by the bridge, the new block events are always forwarded
+	Topics []Topic
+	// number of latest heights whose events are kept for the clients reconnecting with a cursor
+	RetainHeights int64
+	// number of events buffered for a client before it is disconnected as too slow
+	ClientBufferSize int
+}
+
+func DefaultBridgeConfig() BridgeConfig {
+	return BridgeConfig{
+		Addr:             "127.0.0.1:26661",
+		RetainHeights:    100,
+		ClientBufferSize: 1000,
+	}
+}
+
+// EventMessage is a frame streamed to the clients of the bridge. The event is encoded in the encoding requested
+// by the client, a JSON frame is a text message with the event inlined, an amino frame is a binary message.
+type EventMessage struct {
+	Height int64  `json:"height"`
+	Index  int    `json:"index"`
+	Topic  Topic  `json:"topic"`
+	Type   string `json:"type"`
+	Event  []byte `json:"event"`
+}
+
+type jsonEventMessage struct {
+	Height int64           `json:"height"`
+	Index  int             `json:"index"`
+	Topic  Topic           `json:"topic"`
+	Type   string          `json:"type"`
+	Event  json.RawMessage `json:"event"`
+}
+
+type bridgeEvent struct {
+	height     int64
+	topic      Topic
+	jsonFrame  []byte
+	aminoFrame []byte
+}
+
+type bridgeClient struct {
+	clientID ClientID
+	topics   map[Topic]bool
+	encoding string
+	out      chan *bridgeEvent
+	quit     chan struct{}
+	once     sync.Once
+}
+
+// accepts filters the events by the topics of the client, the new block events carry the cursor and are
+// always accepted
+func (c *bridgeClient) accepts(event *bridgeEvent) bool {
+	return len(c.topics) == 0 || event.topic == NewBlockTopic || c.topics[event.topic]
+}
+
+func (c *bridgeClient) frame(event *bridgeEvent) (int, []byte) {
+	if c.encoding == EncodingAmino {
+		return websocket.BinaryMessage, event.aminoFrame
+	}
+	return websocket.TextMessage, event.jsonFrame
+}
+
+func (c *bridgeClient) close() {
+	c.once.Do(func() { close(c.quit) })
+}
+
+// Bridge streams the pubsub events to out-of-process clients over a local WebSocket endpoint. Every event is
+// stamped with the height of the last NewBlockEvent and its index within the height, a client reconnecting
+// with the from_height cursor gets the retained events from that height before the live ones.
+type Bridge struct {
+	common.BaseService
+
+	server   *Server
+	cdc      *codec.Codec
+	cfg      BridgeConfig
+	listener net.Listener
+	upgrader websocket.Upgrader
+
+	mtx          sync.Mutex
+	height       int64
+	index        int
+	events       []*bridgeEvent
+	prunedHeight int64
+	clients      map[ClientID]*bridgeClient
+}
+
+func NewBridge(server *Server, cdc *codec.Codec, cfg BridgeConfig, logger log.Logger) *Bridge {
+	bridge := &Bridge{
+		server:  server,
+		cdc:     cdc,
+		cfg:     cfg,
+		clients: make(map[ClientID]*bridgeClient),
+	}
+	bridge.BaseService = *common.NewBaseService(logger, "pubsubBridge", bridge)
+	return bridge
+}
+
+func (b *Bridge) OnStart() error {
+	sub, err := b.server.NewSubscriber(BridgeClientID, b.Logger)
+	if err != nil {
+		return err
+	}
+	topics := append([]Topic{NewBlockTopic}, b.cfg.Topics...)
+	for _, topic := range topics {
+		if err := sub.Subscribe(topic, b.record); err != nil {
+			return err
+		}
+	}
+
+	listener, err := net.Listen("tcp", b.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	b.listener = listener
+	mux := http.NewServeMux()
+	mux.Handle(BridgeEventsPath, b)
+	go func() {
+		if err := http.Serve(listener, mux); err != nil && b.IsRunning() {
+			b.Logger.Error("pubsub bridge stopped serving", "err", err)
+		}
+	}()
+	return nil
+}
+
+func (b *Bridge) OnStop() {
+	if b.listener != nil {
+		b.listener.Close()
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	for _, client := range b.clients {
+		client.close()
+	}
+}
+
+// Addr returns the address the bridge listens on
+func (b *Bridge) Addr() net.Addr {
+	if b.listener == nil {
+		return nil
+	}
+	return b.listener.Addr()
+}
+
+func (b *Bridge) record(event Event) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if newBlock, ok := event.(NewBlockEvent); ok {
+		b.height = newBlock.Height
+		b.index = 0
+		b.prune()
+	}
+	be := b.encode(event)
+	b.index++
+	if b.cfg.RetainHeights > 0 {
+		b.events = append(b.events, be)
+	}
+	for _, client := range b.clients {
+		if !client.accepts(be) {
+			continue
+		}
+		select {
+		case client.out <- be:
+		default:
+			b.Logger.Error("pubsub bridge client is too slow, disconnect it", "clientID", client.clientID)
+			client.close()
+		}
+	}
+}
+
+func (b *Bridge) prune() {
+	i := 0
+	for ; i < len(b.events) && b.events[i].height <= b.height-b.cfg.RetainHeights; i++ {
+		b.prunedHeight = b.events[i].height
+	}
+	b.events = b.events[i:]
+}
+
+func (b *Bridge) encode(event Event) *bridgeEvent {
+	be := &bridgeEvent{height: b.height, topic: event.GetTopic()}
+	typeName := reflect.TypeOf(event).Name()
+
+	if payload, err := json.Marshal(event); err != nil {
+		b.Logger.Error("failed to encode event to json", "type", typeName, "err", err)
+	} else {
+		be.jsonFrame, _ = json.Marshal(jsonEventMessage{
+			Height: b.height, Index: b.index, Topic: be.topic, Type: typeName, Event: payload,
+		})
+	}
+	if payload, err := b.marshalAmino(event); err != nil {
+		b.Logger.Error("failed to encode event to amino", "type", typeName, "err", err)
+	} else {
+		be.aminoFrame, _ = b.cdc.MarshalBinaryBare(EventMessage{
+			Height: b.height, Index: b.index, Topic: be.topic, Type: typeName, Event: payload,
+		})
+	}
+	return be
+}
+
+func (b *Bridge) marshalAmino(event Event) (bz []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return b.cdc.MarshalBinaryBare(event)
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection streaming the events. The query parameters are
+// client_id (required), encoding (json or amino), topics (comma separated, all by default) and from_height.
+func (b *Bridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	client, fromHeight, err := b.parseClient(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	b.mtx.Lock()
+	if _, ok := b.clients[client.clientID]; ok {
+		b.mtx.Unlock()
+		http.Error(w, ErrDuplicateClientID.Error(), http.StatusConflict)
+		return
+	}
+	if fromHeight > 0 && fromHeight <= b.prunedHeight {
+		b.mtx.Unlock()
+		http.Error(w, fmt.Sprintf("events of height %d are pruned", fromHeight), http.StatusGone)
+		return
+	}
+	var replay []*bridgeEvent
+	if fromHeight > 0 {
+		for _, event := range b.events {
+			if event.height >= fromHeight && client.accepts(event) {
+				replay = append(replay, event)
+			}
+		}
+	}
+	b.clients[client.clientID] = client
+	b.mtx.Unlock()
+	defer b.removeClient(client)
+
+	conn, err := b.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		b.Logger.Error("failed to upgrade pubsub bridge connection", "clientID", client.clientID, "err", err)
+		return
+	}
+	defer conn.Close()
+	go b.writeEvents(conn, client, replay)
+
+	// the clients do not send anything, reading detects the closed connections
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			client.close()
+			return
+		}
+	}
+}
+
+func (b *Bridge) parseClient(r *http.Request) (*bridgeClient, int64, error) {
+	query := r.URL.Query()
+	client := &bridgeClient{
+		clientID: ClientID(query.Get("client_id")),
+		topics:   make(map[Topic]bool),
+		encoding: query.Get("encoding"),
+		out:      make(chan *bridgeEvent, b.cfg.ClientBufferSize),
+		quit:     make(chan struct{}),
+	}
+	if client.clientID == "" {
+		return nil, 0, fmt.Errorf("client_id is required")
+	}
+	switch client.encoding {
+	case "":
+		client.encoding = EncodingJSON
+	case EncodingJSON, EncodingAmino:
+	default:
+		return nil, 0, fmt.Errorf("unknown encoding %s", client.encoding)
+	}
+	if topics := query.Get("topics"); topics != "" {
+		for _, topic := range strings.Split(topics, ",") {
+			if !b.forwards(Topic(topic)) {
+				return nil, 0, fmt.Errorf("topic %s is not forwarded by the bridge", topic)
+			}
+			client.topics[Topic(topic)] = true
+		}
+	}
+	var fromHeight int64
+	if height := query.Get("from_height"); height != "" {
+		var err error
+		if fromHeight, err = strconv.ParseInt(height, 10, 64); err != nil {
+			return nil, 0, fmt.Errorf("invalid from_height %s", height)
+		}
+	}
+	return client, fromHeight, nil
+}
+
+func (b *Bridge) forwards(topic Topic) bool {
+	if topic == NewBlockTopic {
+		return true
+	}
+	for _, t := range b.cfg.Topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Bridge) writeEvents(conn *websocket.Conn, client *bridgeClient, replay []*bridgeEvent) {
+	defer conn.Close()
+	for _, event := range replay {
+		if !b.writeEvent(conn, client, event) {
+			return
+		}
+	}
+	for {
+		select {
+		case event := <-client.out:
+			if !b.writeEvent(conn, client, event) {
+				return
+			}
+		case <-client.quit:
+			return
+		}
+	}
+}
+
+func (b *Bridge) writeEvent(conn *websocket.Conn, client *bridgeClient, event *bridgeEvent) bool {
+	messageType, frame := client.frame(event)
+	if frame == nil {
+		// the event can not be encoded in the encoding of the client, it is logged when recorded
+		return true
+	}
+	conn.SetWriteDeadline(time.Now().Add(bridgeWriteWait))
+	if err := conn.WriteMessage(messageType, frame); err != nil {
+		b.Logger.Error("failed to write event to pubsub bridge client", "clientID", client.clientID, "err", err)
+		client.close()
+		return false
+	}
+	return true
+}
+
+func (b *Bridge) removeClient(client *bridgeClient) {
+	client.close()
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.clients[client.clientID] == client {
+		delete(b.clients, client.clientID)
+	}
+}
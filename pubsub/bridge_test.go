@@ -0,0 +1,147 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+const bridgeTestTopic = Topic("bridge-test")
+
+type bridgeTestEvent struct {
+	Seq int64
+}
+
+func (event bridgeTestEvent) GetTopic() Topic {
+	return bridgeTestTopic
+}
+
+func startBridge(t *testing.T) (*Server, *Bridge) {
+	server := NewServer(nil)
+	require.NoError(t, server.Start())
+	cfg := DefaultBridgeConfig()
+	cfg.Addr = "127.0.0.1:0"
+	cfg.Topics = []Topic{bridgeTestTopic, CrossTransferTopic}
+	cfg.RetainHeights = 2
+	bridge := NewBridge(server, codec.New(), cfg, nil)
+	require.NoError(t, bridge.Start())
+	return server, bridge
+}
+
+// publishBlock publishes the events of a block and waits for the bridge to record them
+func publishBlock(t *testing.T, server *Server, bridge *Bridge, height int64, events ...Event) {
+	server.Publish(NewBlockEvent{Height: height})
+	for _, event := range events {
+		server.Publish(event)
+	}
+	require.Eventually(t, func() bool {
+		bridge.mtx.Lock()
+		defer bridge.mtx.Unlock()
+		return bridge.height == height && bridge.index == len(events)+1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func dialBridge(bridge *Bridge, query string) (*websocket.Conn, *http.Response, error) {
+	return websocket.DefaultDialer.Dial(fmt.Sprintf("ws://%s%s?%s", bridge.Addr(), BridgeEventsPath, query), nil)
+}
+
+func readJSONMessage(t *testing.T, conn *websocket.Conn) jsonEventMessage {
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	messageType, bz, err := conn.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, websocket.TextMessage, messageType)
+	var msg jsonEventMessage
+	require.NoError(t, json.Unmarshal(bz, &msg))
+	return msg
+}
+
+func TestBridgeReplayFromHeight(t *testing.T) {
+	server, bridge := startBridge(t)
+	defer bridge.Stop()
+
+	publishBlock(t, server, bridge, 1, bridgeTestEvent{Seq: 1})
+	publishBlock(t, server, bridge, 2, CrossTransferEvent{TxHash: "tx"}, bridgeTestEvent{Seq: 2})
+
+	conn, _, err := dialBridge(bridge, "client_id=indexer&topics=bridge-test&from_height=2")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	msg := readJSONMessage(t, conn)
+	require.Equal(t, int64(2), msg.Height)
+	require.Equal(t, 0, msg.Index)
+	require.Equal(t, NewBlockTopic, msg.Topic)
+	require.Equal(t, "NewBlockEvent", msg.Type)
+
+	// the cross transfer event is filtered out
+	msg = readJSONMessage(t, conn)
+	require.Equal(t, int64(2), msg.Height)
+	require.Equal(t, 2, msg.Index)
+	require.Equal(t, "bridgeTestEvent", msg.Type)
+	var event bridgeTestEvent
+	require.NoError(t, json.Unmarshal(msg.Event, &event))
+	require.Equal(t, int64(2), event.Seq)
+
+	// live events follow the replayed ones
+	server.Publish(bridgeTestEvent{Seq: 3})
+	msg = readJSONMessage(t, conn)
+	require.Equal(t, int64(2), msg.Height)
+	require.Equal(t, 3, msg.Index)
+
+	// the client id is exclusive
+	_, resp, err := dialBridge(bridge, "client_id=indexer")
+	require.Error(t, err)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	_, resp, err = dialBridge(bridge, "client_id=other&topics=unknown")
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// only the latest heights are retained
+	publishBlock(t, server, bridge, 3)
+	publishBlock(t, server, bridge, 4)
+	_, resp, err = dialBridge(bridge, "client_id=other&from_height=2")
+	require.Error(t, err)
+	require.Equal(t, http.StatusGone, resp.StatusCode)
+}
+
+func TestBridgeAminoEncoding(t *testing.T) {
+	server, bridge := startBridge(t)
+	defer bridge.Stop()
+
+	conn, _, err := dialBridge(bridge, "client_id=indexer&encoding=amino")
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Eventually(t, func() bool {
+		bridge.mtx.Lock()
+		defer bridge.mtx.Unlock()
+		return len(bridge.clients) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	server.Publish(NewBlockEvent{Height: 5})
+	server.Publish(bridgeTestEvent{Seq: 7})
+
+	cdc := codec.New()
+	for i, topic := range []Topic{NewBlockTopic, bridgeTestTopic} {
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		messageType, bz, err := conn.ReadMessage()
+		require.NoError(t, err)
+		require.Equal(t, websocket.BinaryMessage, messageType)
+		var msg EventMessage
+		require.NoError(t, cdc.UnmarshalBinaryBare(bz, &msg))
+		require.Equal(t, int64(5), msg.Height)
+		require.Equal(t, i, msg.Index)
+		require.Equal(t, topic, msg.Topic)
+		if topic == bridgeTestTopic {
+			var event bridgeTestEvent
+			require.NoError(t, cdc.UnmarshalBinaryBare(msg.Event, &event))
+			require.Equal(t, int64(7), event.Seq)
+		}
+	}
+}
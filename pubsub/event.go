@@ -4,6 +4,7 @@ type Topic string
 
 const (
 	CrossTransferTopic = Topic("cross-transfer")
+	NewBlockTopic      = Topic("new-block")
 )
 
 type Event interface {
@@ -32,3 +33,12 @@ type CrossTransferEvent struct {
 func (event CrossTransferEvent) GetTopic() Topic {
 	return CrossTransferTopic
 }
+
+// NewBlockEvent is published before the events of a block, the events published after it belong to the block
+type NewBlockEvent struct {
+	Height int64
+}
+
+func (event NewBlockEvent) GetTopic() Topic {
+	return NewBlockTopic
+}
@@ -0,0 +1,84 @@
+package pubsub
+
+import (
+	"path"
+	"reflect"
+	"strings"
+)
+
+// Filter is a predicate on the events of a subscription, it is evaluated by the server before the event is
+// enqueued for the subscriber
+type Filter func(Event) bool
+
+// IsPattern reports whether the topic is a pattern matching other topics, the pattern syntax is the one of
+// path.Match, e.g. "*" matches all the topics and "cross-*" matches "cross-transfer"
+func IsPattern(topic Topic) bool {
+	return strings.ContainsAny(string(topic), "*?[\\")
+}
+
+// Match reports whether the topic matches the topic or pattern of a subscription
+func (topic Topic) Match(event Topic) bool {
+	if !IsPattern(topic) {
+		return topic == event
+	}
+	matched, err := path.Match(string(topic), string(event))
+	return err == nil && matched
+}
+
+func validatePattern(topic Topic) error {
+	if _, err := path.Match(string(topic), ""); err != nil {
+		return ErrInvalidPattern
+	}
+	return nil
+}
+
+// OfType matches the events of the same concrete types as the samples
+func OfType(samples ...Event) Filter {
+	types := make(map[reflect.Type]bool, len(samples))
+	for _, sample := range samples {
+		types[reflect.TypeOf(sample)] = true
+	}
+	return func(event Event) bool {
+		return types[reflect.TypeOf(event)]
+	}
+}
+
+// FieldEquals matches the events having an exported field of the name, including the fields promoted from
+// embedded structs, whose value equals to the value, e.g. FieldEquals("ChainId", "bsc")
+func FieldEquals(name string, value interface{}) Filter {
+	return func(event Event) bool {
+		v := reflect.Indirect(reflect.ValueOf(event))
+		if v.Kind() != reflect.Struct {
+			return false
+		}
+		field := v.FieldByName(name)
+		if !field.IsValid() || !field.CanInterface() {
+			return false
+		}
+		return reflect.DeepEqual(field.Interface(), value)
+	}
+}
+
+// And matches the events matched by all the filters
+func And(filters ...Filter) Filter {
+	return func(event Event) bool {
+		for _, filter := range filters {
+			if !filter(event) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or matches the events matched by any of the filters
+func Or(filters ...Filter) Filter {
+	return func(event Event) bool {
+		for _, filter := range filters {
+			if filter(event) {
+				return true
+			}
+		}
+		return false
+	}
+}
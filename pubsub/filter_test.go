@@ -0,0 +1,100 @@
+package pubsub
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type baseChainEvent struct {
+	ChainId string
+}
+
+type delegationTestEvent struct {
+	baseChainEvent
+	Amount int64
+}
+
+func (event delegationTestEvent) GetTopic() Topic {
+	return Topic("stake")
+}
+
+type undelegationTestEvent struct {
+	ChainId string
+	Amount  int64
+}
+
+func (event undelegationTestEvent) GetTopic() Topic {
+	return Topic("stake")
+}
+
+func TestTopicMatch(t *testing.T) {
+	require.True(t, Topic("stake").Match("stake"))
+	require.False(t, Topic("stake").Match("slashing"))
+	require.True(t, Topic("*").Match("slashing"))
+	require.True(t, Topic("cross-*").Match(CrossTransferTopic))
+	require.False(t, Topic("cross-*").Match(NewBlockTopic))
+	require.True(t, Topic("s[lt]*").Match("stake"))
+	require.False(t, IsPattern(CrossTransferTopic))
+	require.True(t, IsPattern("cross-*"))
+}
+
+func TestFilters(t *testing.T) {
+	bsc := delegationTestEvent{baseChainEvent: baseChainEvent{ChainId: "bsc"}, Amount: 1}
+	other := undelegationTestEvent{ChainId: "other", Amount: 2}
+
+	require.True(t, FieldEquals("ChainId", "bsc")(bsc))
+	require.False(t, FieldEquals("ChainId", "bsc")(other))
+	require.False(t, FieldEquals("ChainId", "bsc")(NewBlockEvent{}))
+	require.False(t, FieldEquals("ChainId", 1)(bsc))
+	require.True(t, OfType(undelegationTestEvent{})(other))
+	require.False(t, OfType(undelegationTestEvent{})(bsc))
+	require.True(t, And(OfType(delegationTestEvent{}), FieldEquals("Amount", int64(1)))(bsc))
+	require.False(t, And(OfType(delegationTestEvent{}), FieldEquals("Amount", int64(2)))(bsc))
+	require.True(t, Or(FieldEquals("ChainId", "bsc"), FieldEquals("ChainId", "other"))(other))
+}
+
+func TestSubscribeWithFilter(t *testing.T) {
+	server := NewServer(nil)
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	sub, err := server.NewSubscriber("indexer", nil)
+	require.NoError(t, err)
+	require.Equal(t, ErrInvalidPattern, sub.SubscribeWithFilter("[", nil, func(Event) {}))
+
+	var mtx sync.Mutex
+	var chainEvents, crossEvents []Event
+	require.NoError(t, sub.SubscribeWithFilter("*", FieldEquals("ChainId", "bsc"), func(event Event) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		chainEvents = append(chainEvents, event)
+	}))
+	require.NoError(t, sub.Subscribe("cross-*", func(event Event) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		crossEvents = append(crossEvents, event)
+	}))
+	require.True(t, server.HasSubscribed("indexer", "cross-*"))
+
+	events := []Event{
+		delegationTestEvent{baseChainEvent: baseChainEvent{ChainId: "bsc"}, Amount: 1},
+		undelegationTestEvent{ChainId: "other", Amount: 2},
+		CrossTransferEvent{ChainId: "bsc", Denom: "AXC"},
+		NewBlockEvent{Height: 1},
+	}
+	for _, event := range events {
+		server.Publish(event)
+	}
+
+	require.Eventually(t, func() bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return len(chainEvents) == 2 && len(crossEvents) == 1
+	}, time.Second, 10*time.Millisecond)
+	sub.Wait()
+	require.ElementsMatch(t, []Event{events[0], events[2]}, chainEvents)
+	require.Equal(t, []Event{events[2]}, crossEvents)
+}
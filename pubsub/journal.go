@@ -0,0 +1,168 @@
+package pubsub
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+const journalKeyLength = 16 // height and index
+
+// ErrJournalDisabled is returned when a client asks for a replay from a server without journal.
+var ErrJournalDisabled = errors.New("journal is disabled")
+
+// JournalEntry is an event recorded in the journal
+type JournalEntry struct {
+	Height int64
+	Index  int64
+	Event  Event
+}
+
+type journalRecord struct {
+	Type  string          `json:"type"`
+	Event json.RawMessage `json:"event"`
+}
+
+// Journal records the published events on disk keyed by the height of the last NewBlockEvent and the index
+// of the event within the height. A NewBlockEvent of a height which is already recorded means the block is
+// executed again after a crash, the events recorded from that height are discarded.
+type Journal struct {
+	db          dbm.DB
+	keepHeights int64
+	types       map[string]reflect.Type
+	names       map[reflect.Type]string
+
+	height int64
+	index  int64
+}
+
+// NewJournal creates a journal on the db which keeps the events of the latest keepHeights heights,
+// 0 means all the events are kept
+func NewJournal(db dbm.DB, keepHeights int64) *Journal {
+	journal := &Journal{
+		db:          db,
+		keepHeights: keepHeights,
+		types:       make(map[string]reflect.Type),
+		names:       make(map[reflect.Type]string),
+	}
+	iterator := db.ReverseIterator(nil, nil)
+	defer iterator.Close()
+	if iterator.Valid() {
+		journal.height, journal.index = decodeJournalKey(iterator.Key())
+		journal.index++
+	}
+	return journal
+}
+
+// RegisterEvent registers the concrete type of an event under a unique name, only the registered events
+// are recorded
+func (j *Journal) RegisterEvent(event Event, name string) {
+	t := reflect.TypeOf(event)
+	if _, ok := j.types[name]; ok {
+		panic(fmt.Sprintf("event name %s is already registered", name))
+	}
+	j.types[name] = t
+	j.names[t] = name
+}
+
+func encodeJournalKey(height, index int64) []byte {
+	key := make([]byte, journalKeyLength)
+	binary.BigEndian.PutUint64(key[:8], uint64(height))
+	binary.BigEndian.PutUint64(key[8:], uint64(index))
+	return key
+}
+
+func decodeJournalKey(key []byte) (int64, int64) {
+	return int64(binary.BigEndian.Uint64(key[:8])), int64(binary.BigEndian.Uint64(key[8:]))
+}
+
+func (j *Journal) record(event Event) error {
+	if newBlock, ok := event.(NewBlockEvent); ok {
+		j.discardFrom(newBlock.Height)
+		j.prune(newBlock.Height)
+		j.height = newBlock.Height
+		j.index = 0
+	}
+
+	name, ok := j.names[reflect.TypeOf(event)]
+	if !ok {
+		return fmt.Errorf("event %T is not registered", event)
+	}
+	bz, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(journalRecord{Type: name, Event: bz})
+	if err != nil {
+		return err
+	}
+
+	key := encodeJournalKey(j.height, j.index)
+	j.index++
+	if _, ok := event.(NewBlockEvent); ok {
+		// flushes the events of the previous block as well
+		j.db.SetSync(key, value)
+	} else {
+		j.db.Set(key, value)
+	}
+	return nil
+}
+
+func (j *Journal) discardFrom(height int64) {
+	j.deleteRange(encodeJournalKey(height, 0), nil)
+}
+
+func (j *Journal) prune(height int64) {
+	if j.keepHeights <= 0 || height <= j.keepHeights {
+		return
+	}
+	j.deleteRange(nil, encodeJournalKey(height-j.keepHeights+1, 0))
+}
+
+func (j *Journal) deleteRange(start, end []byte) {
+	iterator := j.db.Iterator(start, end)
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	if len(keys) == 0 {
+		return
+	}
+	batch := j.db.NewBatch()
+	defer batch.Close()
+	for _, key := range keys {
+		batch.Delete(key)
+	}
+	batch.Write()
+}
+
+// Iterate calls fn on the recorded events from the height in order until fn returns true
+func (j *Journal) Iterate(fromHeight int64, fn func(entry JournalEntry) (stop bool)) error {
+	iterator := j.db.Iterator(encodeJournalKey(fromHeight, 0), nil)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var record journalRecord
+		if err := json.Unmarshal(iterator.Value(), &record); err != nil {
+			return err
+		}
+		t, ok := j.types[record.Type]
+		if !ok {
+			return fmt.Errorf("event %s is not registered", record.Type)
+		}
+		ptr := reflect.New(t)
+		if err := json.Unmarshal(record.Event, ptr.Interface()); err != nil {
+			return err
+		}
+		height, index := decodeJournalKey(iterator.Key())
+		if fn(JournalEntry{Height: height, Index: index, Event: ptr.Elem().Interface().(Event)}) {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package pubsub
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+const journalTestTopic = Topic("journal-test")
+
+type journalTestEvent struct {
+	Seq int
+}
+
+func (event journalTestEvent) GetTopic() Topic {
+	return journalTestTopic
+}
+
+func newTestJournal(db dbm.DB, keepHeights int64) *Journal {
+	journal := NewJournal(db, keepHeights)
+	journal.RegisterEvent(NewBlockEvent{}, "pubsub/NewBlockEvent")
+	journal.RegisterEvent(journalTestEvent{}, "pubsub/journalTestEvent")
+	return journal
+}
+
+func startJournalServer(t *testing.T, journal *Journal) *Server {
+	server := NewServer(nil)
+	server.SetJournal(journal)
+	require.NoError(t, server.Start())
+	return server
+}
+
+func journalEntries(t *testing.T, journal *Journal, fromHeight int64) []JournalEntry {
+	var entries []JournalEntry
+	require.NoError(t, journal.Iterate(fromHeight, func(entry JournalEntry) bool {
+		entries = append(entries, entry)
+		return false
+	}))
+	return entries
+}
+
+func waitJournalEntries(t *testing.T, journal *Journal, count int) {
+	require.Eventually(t, func() bool {
+		return len(journalEntries(t, journal, 0)) == count
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestJournalRecord(t *testing.T) {
+	db := dbm.NewMemDB()
+	journal := newTestJournal(db, 0)
+	server := startJournalServer(t, journal)
+
+	server.Publish(NewBlockEvent{Height: 1})
+	server.Publish(journalTestEvent{Seq: 1})
+	server.Publish(journalTestEvent{Seq: 2})
+	server.Publish(NewBlockEvent{Height: 2})
+	server.Publish(journalTestEvent{Seq: 3})
+	waitJournalEntries(t, journal, 5)
+	server.Stop()
+
+	require.Equal(t, []JournalEntry{
+		{Height: 2, Index: 0, Event: NewBlockEvent{Height: 2}},
+		{Height: 2, Index: 1, Event: journalTestEvent{Seq: 3}},
+	}, journalEntries(t, journal, 2))
+
+	// the node crashed before the commit of height 2, the block is executed again after restart
+	journal = newTestJournal(db, 0)
+	require.Equal(t, int64(2), journal.height)
+	require.Equal(t, int64(2), journal.index)
+	server = startJournalServer(t, journal)
+	defer server.Stop()
+	server.Publish(NewBlockEvent{Height: 2})
+	server.Publish(journalTestEvent{Seq: 4})
+	waitJournalEntries(t, journal, 5)
+
+	entries := journalEntries(t, journal, 0)
+	require.Equal(t, JournalEntry{Height: 1, Index: 2, Event: journalTestEvent{Seq: 2}}, entries[2])
+	require.Equal(t, JournalEntry{Height: 2, Index: 1, Event: journalTestEvent{Seq: 4}}, entries[4])
+}
+
+func TestJournalPrune(t *testing.T) {
+	journal := newTestJournal(dbm.NewMemDB(), 2)
+	server := startJournalServer(t, journal)
+	defer server.Stop()
+
+	for height := int64(1); height <= 4; height++ {
+		server.Publish(NewBlockEvent{Height: height})
+		server.Publish(journalTestEvent{Seq: int(height)})
+	}
+	waitJournalEntries(t, journal, 4)
+	require.Equal(t, int64(3), journalEntries(t, journal, 0)[0].Height)
+}
+
+func TestReplayFromHeight(t *testing.T) {
+	journal := newTestJournal(dbm.NewMemDB(), 0)
+	server := startJournalServer(t, journal)
+	defer server.Stop()
+
+	for height := int64(1); height <= 3; height++ {
+		server.Publish(NewBlockEvent{Height: height})
+		server.Publish(journalTestEvent{Seq: int(height)})
+	}
+	waitJournalEntries(t, journal, 6)
+
+	sub, err := server.NewSubscriberWithConfig("replay", nil, SubscriberConfig{ReplayFromHeight: 2})
+	require.NoError(t, err)
+	var mtx sync.Mutex
+	var handled []int
+	require.NoError(t, sub.Subscribe(journalTestTopic, func(event Event) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		handled = append(handled, event.(journalTestEvent).Seq)
+	}))
+	server.Publish(journalTestEvent{Seq: 4})
+
+	require.Eventually(t, func() bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return len(handled) == 3
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, []int{2, 3, 4}, handled)
+
+	// the replay needs a journal
+	_, err = NewServer(nil).NewSubscriberWithConfig("replay", nil, SubscriberConfig{ReplayFromHeight: 2})
+	require.Equal(t, ErrJournalDisabled, err)
+}
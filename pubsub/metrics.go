@@ -0,0 +1,56 @@
+package pubsub
+
+import (
+	"sync"
+
+	metricsPkg "github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics contains Metrics exposed by this package.
+type Metrics struct {
+	PublishedEvents metricsPkg.Counter
+	DroppedEvents   metricsPkg.Counter
+	DeliveryLatency metricsPkg.Histogram
+}
+
+var (
+	prometheusMetrics     *Metrics
+	prometheusMetricsOnce sync.Once
+)
+
+// PrometheusMetrics returns Metrics build using Prometheus client library, the collectors are registered once
+// and shared by all the servers.
+func PrometheusMetrics() *Metrics {
+	prometheusMetricsOnce.Do(func() {
+		prometheusMetrics = &Metrics{
+			PublishedEvents: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Subsystem: "pubsub",
+				Name:      "published_events",
+				Help:      "The number of events published",
+			}, []string{"topic"}),
+			DroppedEvents: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Subsystem: "pubsub",
+				Name:      "dropped_events",
+				Help:      "The number of events dropped by the overflow policy of slow subscribers",
+			}, []string{"topic"}),
+			DeliveryLatency: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+				Subsystem: "pubsub",
+				Name:      "delivery_latency_seconds",
+				Help:      "The time between the publishing of an event and its handling by a subscriber",
+			}, []string{"topic"}),
+		}
+	})
+	return prometheusMetrics
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		PublishedEvents: discard.NewCounter(),
+		DroppedEvents:   discard.NewCounter(),
+		DeliveryLatency: discard.NewHistogram(),
+	}
+}
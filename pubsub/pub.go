@@ -3,6 +3,7 @@ package pubsub
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/libs/log"
@@ -22,6 +23,12 @@ var (
 	ErrSubscriptionNotFound = errors.New("subscription not found")
 
 	ErrNilHandler = errors.New("handler is nil")
+
+	// ErrInvalidPattern is returned when a client subscribes with a malformed topic pattern.
+	ErrInvalidPattern = errors.New("invalid topic pattern")
+
+	// ErrUnknownOverflowPolicy is returned when a client subscribes with an unknown overflow policy.
+	ErrUnknownOverflowPolicy = errors.New("unknown overflow policy")
 )
 
 type operation int
@@ -40,9 +47,27 @@ type cmd struct {
 	topic      Topic
 	subscriber *Subscriber
 	clientID   ClientID
+	filter     Filter
 
 	// publish
-	event Event
+	msg message
+}
+
+// message is an event with the time it is published
+type message struct {
+	event     Event
+	published time.Time
+	// the topic or pattern of the subscription the event is delivered for
+	subscription Topic
+}
+
+type subscription struct {
+	subscriber *Subscriber
+	filter     Filter
+}
+
+func (s subscription) accepts(event Event) bool {
+	return s.filter == nil || s.filter(event)
 }
 
 type Server struct {
@@ -50,25 +75,39 @@ type Server struct {
 
 	cmds chan cmd
 
-	subscribers   map[ClientID]map[Topic]bool        // clientID -> topic -> bool
-	subscriptions map[Topic]map[ClientID]*Subscriber // topic -> clientID -> subscriber
+	subscribers   map[ClientID]map[Topic]bool         // clientID -> topic -> bool
+	subscriptions map[Topic]map[ClientID]subscription // topic or pattern -> clientID -> subscription
 
 	// check if the subscriber has already been added before
 	// subscribing or unsubscribing
 	mtx sync.RWMutex
 	wg  sync.WaitGroup
+
+	metrics *Metrics
+	journal *Journal
 }
 
 func NewServer(logger log.Logger) *Server {
 	server := &Server{
 		cmds:          make(chan cmd),
 		subscribers:   make(map[ClientID]map[Topic]bool),
-		subscriptions: make(map[Topic]map[ClientID]*Subscriber),
+		subscriptions: make(map[Topic]map[ClientID]subscription),
+		metrics:       NopMetrics(),
 	}
 	server.BaseService = *common.NewBaseService(logger, "pubsubServer", server)
 	return server
 }
 
+// SetMetrics replaces the no-op metrics of the server, it should be called before the server is started
+func (server *Server) SetMetrics(metrics *Metrics) {
+	server.metrics = metrics
+}
+
+// SetJournal enables the recording of the published events, it should be called before the server is started
+func (server *Server) SetJournal(journal *Journal) {
+	server.journal = journal
+}
+
 func (server *Server) OnStart() error {
 	go server.loop()
 	return nil
@@ -101,24 +140,114 @@ func (server *Server) loop() {
 		case sub:
 			// initialize subscription for this client per topic if needed
 			if _, ok := server.subscriptions[cmd.topic]; !ok {
-				server.subscriptions[cmd.topic] = make(map[ClientID]*Subscriber)
+				server.subscriptions[cmd.topic] = make(map[ClientID]subscription)
+			}
+			s := subscription{subscriber: cmd.subscriber, filter: cmd.filter}
+			// replay the recorded events before the live ones
+			if cmd.subscriber.replayFromHeight > 0 {
+				server.replay(s, cmd.topic)
 			}
 			// create subscription
-			server.subscriptions[cmd.topic][cmd.clientID] = cmd.subscriber
+			server.subscriptions[cmd.topic][cmd.clientID] = s
 		case pub:
-			server.push(cmd.event)
+			server.push(cmd.msg)
 		}
 	}
 }
 
-func (server *Server) push(event Event) {
-	for _, sub := range server.subscriptions[event.GetTopic()] {
-		sub.wg.Add(1)
-		sub.out <- event
+func (server *Server) push(msg message) {
+	topic := msg.event.GetTopic()
+	server.metrics.PublishedEvents.With("topic", string(topic)).Add(1)
+	if server.journal != nil {
+		if err := server.journal.record(msg.event); err != nil {
+			server.Logger.Error("failed to record event in journal", "topic", topic, "err", err)
+		}
+	}
+	for subscribed, clientSubscriptions := range server.subscriptions {
+		if !subscribed.Match(topic) {
+			continue
+		}
+		msg.subscription = subscribed
+		for _, s := range clientSubscriptions {
+			if s.accepts(msg.event) {
+				server.deliver(s.subscriber, msg)
+			}
+		}
 	}
 	server.wg.Done()
 }
 
+func (server *Server) deliver(sub *Subscriber, msg message) {
+	select {
+	case <-sub.quit:
+		// the subscriber is stopped, its remaining subscriptions are not served
+		return
+	default:
+	}
+	sub.wg.Add(1)
+	switch sub.policy {
+	case OverflowDropOldest:
+		server.pushDropOldest(sub, msg)
+	case OverflowDisconnect:
+		server.pushOrDisconnect(sub, msg)
+	default:
+		select {
+		case sub.out <- msg:
+		case <-sub.quit:
+			sub.wg.Done()
+		}
+	}
+}
+
+// replay delivers the recorded events of the topic or pattern from the replay height of the subscriber
+func (server *Server) replay(s subscription, topic Topic) {
+	sub := s.subscriber
+	err := server.journal.Iterate(sub.replayFromHeight, func(entry JournalEntry) bool {
+		if topic.Match(entry.Event.GetTopic()) && s.accepts(entry.Event) {
+			server.deliver(sub, message{event: entry.Event, published: time.Now(), subscription: topic})
+		}
+		return false
+	})
+	if err != nil {
+		server.Logger.Error("failed to replay events from journal", "clientID", sub.clientID, "topic", topic, "err", err)
+	}
+}
+
+func (server *Server) pushDropOldest(sub *Subscriber, msg message) {
+	for {
+		select {
+		case sub.out <- msg:
+			return
+		default:
+		}
+		select {
+		case dropped := <-sub.out:
+			server.metrics.DroppedEvents.With("topic", string(dropped.event.GetTopic())).Add(1)
+			sub.wg.Done()
+		default:
+		}
+	}
+}
+
+func (server *Server) pushOrDisconnect(sub *Subscriber, msg message) {
+	select {
+	case sub.out <- msg:
+		return
+	default:
+	}
+	server.metrics.DroppedEvents.With("topic", string(msg.event.GetTopic())).Add(1)
+	sub.wg.Done()
+
+	server.removeClient(sub.clientID)
+	server.mtx.Lock()
+	delete(server.subscribers, sub.clientID)
+	server.mtx.Unlock()
+	sub.disconnect()
+	if sub.Logger != nil {
+		sub.Logger.Error("Subscriber is too slow, disconnected", "clientID", sub.clientID)
+	}
+}
+
 func (server *Server) removeClient(clientID ClientID) {
 	for topic, clientSubscriptions := range server.subscriptions {
 		if _, ok := clientSubscriptions[clientID]; ok {
@@ -161,7 +290,7 @@ func (server *Server) Publish(e Event) {
 
 	server.wg.Add(1)
 	select {
-	case server.cmds <- cmd{op: pub, event: e}:
+	case server.cmds <- cmd{op: pub, msg: message{event: e, published: time.Now()}}:
 		return
 	case <-server.Quit():
 		server.wg.Done()
@@ -3,23 +3,72 @@ package pubsub
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/tendermint/tendermint/libs/log"
 )
 
 type ClientID string
 
+// OverflowPolicy decides what happens to an event published to a subscriber whose buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the publishing of all events until the subscriber has room for the event
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest buffered events of the subscriber to make room for the event
+	OverflowDropOldest
+	// OverflowDisconnect drops the event and removes all the subscriptions of the subscriber
+	OverflowDisconnect
+)
+
+const DefaultBufferSize = 100
+
+// SubscriberConfig is the buffering config of a subscriber
+type SubscriberConfig struct {
+	BufferSize int
+	Policy     OverflowPolicy
+	// the recorded events of a topic from the height are delivered before the live ones, 0 means no replay
+	ReplayFromHeight int64
+}
+
+func DefaultSubscriberConfig() SubscriberConfig {
+	return SubscriberConfig{
+		BufferSize: DefaultBufferSize,
+		Policy:     OverflowBlock,
+	}
+}
+
 type Subscriber struct {
-	clientID ClientID
-	server   *Server
-	handlers map[Topic]Handler
-	out      chan Event
-	quit     chan struct{}
-	wg       sync.WaitGroup
-	Logger   log.Logger
+	clientID         ClientID
+	server           *Server
+	handlers         map[Topic]Handler
+	policy           OverflowPolicy
+	replayFromHeight int64
+	out              chan message
+	quit             chan struct{}
+	quitOnce         sync.Once
+	disconnected     chan struct{}
+	wg               sync.WaitGroup
+	Logger           log.Logger
 }
 
 func (server *Server) NewSubscriber(clientID ClientID, logger log.Logger) (*Subscriber, error) {
+	return server.NewSubscriberWithConfig(clientID, logger, DefaultSubscriberConfig())
+}
+
+// NewSubscriberWithConfig creates a subscriber with its own buffer size and overflow policy, so that a slow
+// subscriber does not have to stall the publishing for everyone
+func (server *Server) NewSubscriberWithConfig(clientID ClientID, logger log.Logger, cfg SubscriberConfig) (*Subscriber, error) {
+	if cfg.Policy < OverflowBlock || cfg.Policy > OverflowDisconnect {
+		return nil, ErrUnknownOverflowPolicy
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = DefaultBufferSize
+	}
+	if cfg.ReplayFromHeight > 0 && server.journal == nil {
+		return nil, ErrJournalDisabled
+	}
 	server.mtx.Lock()
 	defer server.mtx.Unlock()
 	_, ok := server.subscribers[clientID]
@@ -27,22 +76,27 @@ func (server *Server) NewSubscriber(clientID ClientID, logger log.Logger) (*Subs
 		return nil, ErrDuplicateClientID
 	}
 	sub := &Subscriber{
-		clientID: clientID,
-		server:   server,
-		handlers: make(map[Topic]Handler),
-		out:      make(chan Event, 100),
-		quit:     make(chan struct{}),
-		Logger:   logger,
+		clientID:         clientID,
+		server:           server,
+		handlers:         make(map[Topic]Handler),
+		policy:           cfg.Policy,
+		replayFromHeight: cfg.ReplayFromHeight,
+		out:              make(chan message, cfg.BufferSize),
+		quit:             make(chan struct{}),
+		disconnected:     make(chan struct{}),
+		Logger:           logger,
 	}
 	server.subscribers[clientID] = make(map[Topic]bool)
 
 	go func() {
 		for {
 			select {
-			case event := <-sub.out:
-				sub.eventHandle(event)
+			case msg := <-sub.out:
+				server.metrics.DeliveryLatency.With("topic", string(msg.event.GetTopic())).Observe(time.Since(msg.published).Seconds())
+				sub.eventHandle(msg.subscription, msg.event)
 				sub.wg.Done()
 			case <-sub.quit:
+				sub.drain()
 				if sub.Logger != nil {
 					sub.Logger.Info(fmt.Sprintf("Subscriber[%s] removed", sub.clientID))
 				}
@@ -53,22 +107,57 @@ func (server *Server) NewSubscriber(clientID ClientID, logger log.Logger) (*Subs
 	return sub, nil
 }
 
-func (s *Subscriber) eventHandle(event Event) {
+// drain releases the waiters of the buffered events which are never handled
+func (s *Subscriber) drain() {
+	for {
+		select {
+		case <-s.out:
+			s.wg.Done()
+		default:
+			return
+		}
+	}
+}
+
+func (s *Subscriber) stop() {
+	s.quitOnce.Do(func() { close(s.quit) })
+}
+
+func (s *Subscriber) disconnect() {
+	close(s.disconnected)
+	s.stop()
+}
+
+// Disconnected is closed when the subscriber is disconnected by the OverflowDisconnect policy
+func (s *Subscriber) Disconnected() <-chan struct{} {
+	return s.disconnected
+}
+
+func (s *Subscriber) eventHandle(subscription Topic, event Event) {
 	defer func() {
 		if err := recover(); err != nil && s.Logger != nil {
 			s.Logger.Error("event handle err: ", err)
 		}
 	}()
-	handler, ok := s.handlers[event.GetTopic()]
+	handler, ok := s.handlers[subscription]
 	if ok {
 		handler(event)
 	}
 }
 
 func (s *Subscriber) Subscribe(topic Topic, handler Handler) error {
+	return s.SubscribeWithFilter(topic, nil, handler)
+}
+
+// SubscribeWithFilter subscribes to a topic or a topic pattern, only the events matched by the filter are
+// delivered to the handler, a nil filter matches all the events
+func (s *Subscriber) SubscribeWithFilter(topic Topic, filter Filter, handler Handler) error {
 	if handler == nil {
 		return ErrNilHandler
 	}
+	if err := validatePattern(topic); err != nil {
+		return err
+	}
 	s.server.mtx.RLock()
 	subscribers, ok := s.server.subscribers[s.clientID]
 	if ok {
@@ -82,7 +171,7 @@ func (s *Subscriber) Subscribe(topic Topic, handler Handler) error {
 	s.handlers[topic] = handler
 
 	select {
-	case s.server.cmds <- cmd{op: sub, topic: topic, subscriber: s, clientID: s.clientID}:
+	case s.server.cmds <- cmd{op: sub, topic: topic, subscriber: s, clientID: s.clientID, filter: filter}:
 		s.server.mtx.Lock()
 		if _, ok := s.server.subscribers[s.clientID]; !ok {
 			s.server.subscribers[s.clientID] = make(map[Topic]bool)
@@ -109,7 +198,7 @@ func (s *Subscriber) Unsubscribe(topic Topic) error {
 	case s.server.cmds <- cmd{op: unsub, clientID: s.clientID, topic: topic}:
 		s.server.mtx.Lock()
 		delete(s.server.subscribers[s.clientID], topic)
-		close(s.quit)
+		s.stop()
 		s.server.mtx.Unlock()
 		return nil
 	case <-s.server.Quit():
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const flagUpgradeConfig = "config"
+
+// UpgradeConfigFile is the candidate config of the upgrade heights checked by the upgrade-plan command
+type UpgradeConfigFile struct {
+	HeightMap map[string]int64 `json:"height_map"` // upgrade name -> height
+}
+
+// UpgradePlanCmd lists the upgrades of the running node, and checks the candidate config of the upgrade heights
+// against the running binary before the node restarts with it
+func UpgradePlanCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade-plan",
+		Short: "List the upgrades of the running node and check a candidate upgrade config against it",
+		Long: `List the upgrades of the running node with the heights, the activation and the gates they carry.
+With --config, the upgrade heights in the json file, e.g. {"height_map": {"BEP9": 1000}}, are checked against the
+running binary: the upgrades must be known by it, the activated upgrades can not be moved, and the others must be
+scheduled after the current height.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", baseapp.UpgradeQueryRoute, baseapp.QueryUpgradePlan), nil)
+			if err != nil {
+				return err
+			}
+			var plan baseapp.UpgradePlan
+			if err := json.Unmarshal(res, &plan); err != nil {
+				return err
+			}
+
+			configFile := viper.GetString(flagUpgradeConfig)
+			if configFile == "" {
+				if viper.GetBool(client.FlagJson) {
+					fmt.Println(string(res))
+					return nil
+				}
+				printUpgradePlan(plan)
+				return nil
+			}
+
+			bz, err := ioutil.ReadFile(configFile)
+			if err != nil {
+				return err
+			}
+			var config UpgradeConfigFile
+			if err := json.Unmarshal(bz, &config); err != nil {
+				return fmt.Errorf("invalid upgrade config %s: %s", configFile, err.Error())
+			}
+
+			errs := sdk.CheckUpgradeHeights(plan.Upgrades, plan.Height, config.HeightMap)
+			if len(errs) != 0 {
+				for _, err := range errs {
+					fmt.Println(err.Error())
+				}
+				return fmt.Errorf("upgrade config %s is invalid for the running binary at height %d", configFile, plan.Height)
+			}
+			fmt.Printf("upgrade config %s is valid for the running binary at height %d\n", configFile, plan.Height)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagUpgradeConfig, "", "path of the candidate upgrade config to check")
+	cmd.Flags().Bool(client.FlagJson, false, "return output in json format")
+	return client.GetCommands(cmd)[0]
+}
+
+func printUpgradePlan(plan baseapp.UpgradePlan) {
+	fmt.Printf("height: %d\n", plan.Height)
+	for _, upgrade := range plan.Upgrades {
+		height := "unscheduled"
+		if upgrade.Height != 0 {
+			height = fmt.Sprintf("%d", upgrade.Height)
+		}
+		fmt.Printf("%s\theight: %s\tactivated: %t\tknown: %t\tbegin blockers: %d\tstore keys: [%s]\tmsg types: [%s]\n",
+			upgrade.Name, height, upgrade.Activated, upgrade.Known, upgrade.BeginBlockers,
+			strings.Join(upgrade.StoreKeys, ", "), strings.Join(upgrade.MsgTypes, ", "))
+	}
+}
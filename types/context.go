@@ -26,6 +26,8 @@ type Context struct {
 	sideChainKeyPrefix []byte
 	sideChainId        string
 	crossStake         bool
+	resourceMeter      ResourceMeter
+	resourceConfig     ResourceConfig
 }
 
 // create a new context
@@ -119,6 +121,11 @@ func (c Context) CrossStake() bool {
 	return c.crossStake
 }
 
+// ResourceMeter returns the meter the state access is charged to, it's nil if the context is not metered
+func (c Context) ResourceMeter() ResourceMeter {
+	return c.resourceMeter
+}
+
 //----------------------------------------
 // With* (setting a value)
 
@@ -230,6 +237,13 @@ func (c Context) WithCrossStake(crossStake bool) Context {
 	return c
 }
 
+// WithResourceMeter charges the access to the KVStores of the context to the meter with the units of the config
+func (c Context) WithResourceMeter(meter ResourceMeter, config ResourceConfig) Context {
+	c.resourceMeter = meter
+	c.resourceConfig = config
+	return c
+}
+
 // is context nil
 func (c Context) IsZero() bool {
 	return c.ctx == nil && c.ms == nil
@@ -262,7 +276,10 @@ func (c Context) Value(key interface{}) interface{} {
 func (c Context) KVStore(key StoreKey) KVStore {
 	kvStore := c.MultiStore().GetKVStore(key)
 	if c.sideChainKeyPrefix != nil {
-		return kvStore.Prefix(c.sideChainKeyPrefix)
+		kvStore = kvStore.Prefix(c.sideChainKeyPrefix)
+	}
+	if c.resourceMeter != nil {
+		return NewResourceKVStore(kvStore, c.resourceMeter, c.resourceConfig)
 	}
 	return kvStore
 }
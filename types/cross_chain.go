@@ -85,6 +85,22 @@ type CrossChainApplication interface {
 	ExecuteFailAckPackage(ctx Context, payload []byte) ExecuteResult
 }
 
+// CrossChainPackageInfo identifies a package received from a dest chain
+type CrossChainPackageInfo struct {
+	ChainID   ChainID
+	ChannelID ChannelID
+	Sequence  uint64
+}
+
+// CrossChainPackageObserver is implemented by the cross chain applications which keep track of the packages they
+// receive. The observer is called with the context of the claim, so what it writes is kept even if the execution
+// of the package fails and is discarded.
+type CrossChainPackageObserver interface {
+	OnPackageReceived(ctx Context, info CrossChainPackageInfo, packageType CrossChainPackageType, payload []byte)
+	OnPackageExecuted(ctx Context, info CrossChainPackageInfo, packageType CrossChainPackageType, payload []byte,
+		result ExecuteResult, crash bool)
+}
+
 type ExecuteResult struct {
 	Err     Error
 	Tags    Tags
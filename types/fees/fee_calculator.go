@@ -1,6 +1,8 @@
 package fees
 
 import (
+	"fmt"
+
 	"github.com/cosmos/cosmos-sdk/types"
 	param "github.com/cosmos/cosmos-sdk/x/paramHub/types"
 )
@@ -23,6 +25,23 @@ func GetCalculator(msgType string) FeeCalculator {
 	return calculators[msgType]
 }
 
+// CalculateMsgsFee returns the fee of the tx, which is the sum of the fees of its msgs
+func CalculateMsgsFee(msgs []types.Msg) (types.Fee, error) {
+	var fee types.Fee
+	for _, msg := range msgs {
+		calculator := GetCalculator(msg.Type())
+		if calculator == nil {
+			return types.Fee{}, fmt.Errorf("no fee calculator for msg type %s", msg.Type())
+		}
+		fee.AddFee(calculator(msg))
+	}
+	if fee.Tokens == nil {
+		// all the msgs are free
+		return types.NewFee(types.Coins{}, types.FeeFree), nil
+	}
+	return fee, nil
+}
+
 func UnsetAllCalculators() {
 	for key := range calculators {
 		delete(calculators, key)
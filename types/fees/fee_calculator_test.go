@@ -54,6 +54,26 @@ func TestRegisterAndGetCalculators(t *testing.T) {
 	require.Nil(t, GetCalculator(msg.Type()))
 }
 
+func TestCalculateMsgsFee(t *testing.T) {
+	defer UnsetAllCalculators()
+	_, addr := privAndAddr()
+	msgs := []types.Msg{types.NewTestMsg(addr), types.NewTestMsg(addr)}
+
+	_, err := CalculateMsgsFee(msgs)
+	require.Error(t, err)
+
+	RegisterCalculator(msgs[0].Type(), FreeFeeCalculator())
+	fee, err := CalculateMsgsFee(msgs)
+	require.NoError(t, err)
+	require.Equal(t, types.NewFee(types.Coins{}, types.FeeFree), fee)
+
+	RegisterCalculator(msgs[0].Type(), FixedFeeCalculator(10, types.FeeForProposer))
+	fee, err = CalculateMsgsFee(msgs)
+	require.NoError(t, err)
+	require.Equal(t, types.FeeForProposer, fee.Type)
+	require.Equal(t, types.Coins{types.NewCoin(types.NativeTokenSymbol, 20)}, fee.Tokens)
+}
+
 func privAndAddr() (crypto.PrivKey, types.AccAddress) {
 	priv := secp256k1.GenPrivKey()
 	addr := types.AccAddress(priv.PubKey().Address())
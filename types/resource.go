@@ -0,0 +1,239 @@
+package types
+
+import (
+	"io"
+	"math"
+)
+
+// ResourceConfig is the units charged for each kind of the state access
+type ResourceConfig struct {
+	HasCost          uint64
+	DeleteCost       uint64
+	ReadCostFlat     uint64
+	ReadCostPerByte  uint64
+	WriteCostFlat    uint64
+	WriteCostPerByte uint64
+	IterNextCostFlat uint64
+}
+
+// DefaultResourceConfig returns the default units of the state access. Iterating is charged on every step besides
+// the bytes read, so an unbounded iteration costs in proportion to the entries it walks through.
+func DefaultResourceConfig() ResourceConfig {
+	return ResourceConfig{
+		HasCost:          10,
+		DeleteCost:       10,
+		ReadCostFlat:     10,
+		ReadCostPerByte:  1,
+		WriteCostFlat:    10,
+		WriteCostPerByte: 10,
+		IterNextCostFlat: 30,
+	}
+}
+
+// ErrorOutOfResource is panicked by the ResourceMeter when the consumed units exceed the limit
+type ErrorOutOfResource struct {
+	Descriptor string
+}
+
+// ResourceMeter accounts the units consumed by the state access of a tx
+type ResourceMeter interface {
+	Consumed() uint64
+	Limit() uint64
+	IsExceeded() bool
+	Consume(units uint64, descriptor string)
+}
+
+type basicResourceMeter struct {
+	limit    uint64
+	consumed uint64
+}
+
+// NewResourceMeter returns a meter which panics with ErrorOutOfResource once the consumed units exceed the limit
+func NewResourceMeter(limit uint64) ResourceMeter {
+	return &basicResourceMeter{limit: limit}
+}
+
+func (m *basicResourceMeter) Consumed() uint64 {
+	return m.consumed
+}
+
+func (m *basicResourceMeter) Limit() uint64 {
+	return m.limit
+}
+
+func (m *basicResourceMeter) IsExceeded() bool {
+	return m.consumed > m.limit
+}
+
+func (m *basicResourceMeter) Consume(units uint64, descriptor string) {
+	if m.consumed > math.MaxUint64-units {
+		m.consumed = math.MaxUint64
+	} else {
+		m.consumed += units
+	}
+	if m.consumed > m.limit {
+		panic(ErrorOutOfResource{descriptor})
+	}
+}
+
+type infiniteResourceMeter struct {
+	consumed uint64
+}
+
+// NewInfiniteResourceMeter returns a meter which only accounts the consumed units
+func NewInfiniteResourceMeter() ResourceMeter {
+	return &infiniteResourceMeter{}
+}
+
+func (m *infiniteResourceMeter) Consumed() uint64 {
+	return m.consumed
+}
+
+func (m *infiniteResourceMeter) Limit() uint64 {
+	return 0
+}
+
+func (m *infiniteResourceMeter) IsExceeded() bool {
+	return false
+}
+
+func (m *infiniteResourceMeter) Consume(units uint64, descriptor string) {
+	if m.consumed > math.MaxUint64-units {
+		m.consumed = math.MaxUint64
+	} else {
+		m.consumed += units
+	}
+}
+
+//----------------------------------------
+// resourceKVStore
+
+// resourceKVStore charges the reads, the writes and the iteration of the parent store to the meter
+type resourceKVStore struct {
+	parent KVStore
+	meter  ResourceMeter
+	config ResourceConfig
+}
+
+var _ KVStore = resourceKVStore{}
+
+// NewResourceKVStore wraps the store to charge the state access to the meter
+func NewResourceKVStore(parent KVStore, meter ResourceMeter, config ResourceConfig) KVStore {
+	return resourceKVStore{parent: parent, meter: meter, config: config}
+}
+
+func (rs resourceKVStore) GetStoreType() StoreType {
+	return rs.parent.GetStoreType()
+}
+
+func (rs resourceKVStore) Get(key []byte) []byte {
+	rs.meter.Consume(rs.config.ReadCostFlat, "ReadFlat")
+	value := rs.parent.Get(key)
+	rs.meter.Consume(rs.config.ReadCostPerByte*uint64(len(key)+len(value)), "ReadPerByte")
+	return value
+}
+
+func (rs resourceKVStore) Has(key []byte) bool {
+	rs.meter.Consume(rs.config.HasCost, "Has")
+	return rs.parent.Has(key)
+}
+
+func (rs resourceKVStore) Set(key, value []byte) {
+	rs.meter.Consume(rs.config.WriteCostFlat, "WriteFlat")
+	rs.meter.Consume(rs.config.WriteCostPerByte*uint64(len(key)+len(value)), "WritePerByte")
+	rs.parent.Set(key, value)
+}
+
+func (rs resourceKVStore) Delete(key []byte) {
+	rs.meter.Consume(rs.config.DeleteCost, "Delete")
+	rs.parent.Delete(key)
+}
+
+func (rs resourceKVStore) Iterator(start, end []byte) Iterator {
+	return newResourceIterator(rs.parent.Iterator(start, end), rs.meter, rs.config)
+}
+
+func (rs resourceKVStore) ReverseIterator(start, end []byte) Iterator {
+	return newResourceIterator(rs.parent.ReverseIterator(start, end), rs.meter, rs.config)
+}
+
+// Prefix keeps charging the prefixed store to the meter
+func (rs resourceKVStore) Prefix(prefix []byte) KVStore {
+	return NewResourceKVStore(rs.parent.Prefix(prefix), rs.meter, rs.config)
+}
+
+// CacheWrap keeps charging the cache-wrapped store to the meter, the cached state changes are charged once they are
+// made, so writing them to the store is free
+func (rs resourceKVStore) CacheWrap() CacheWrap {
+	return newResourceCacheKVStore(rs.parent.CacheWrap(), rs.meter, rs.config)
+}
+
+// CacheWrapWithTrace keeps charging the cache-wrapped store to the meter
+func (rs resourceKVStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return newResourceCacheKVStore(rs.parent.CacheWrapWithTrace(w, tc), rs.meter, rs.config)
+}
+
+// resourceCacheKVStore charges the access to the cache of a resource metered store
+type resourceCacheKVStore struct {
+	resourceKVStore
+	cache CacheWrap
+}
+
+var _ CacheKVStore = resourceCacheKVStore{}
+
+func newResourceCacheKVStore(cache CacheWrap, meter ResourceMeter, config ResourceConfig) resourceCacheKVStore {
+	return resourceCacheKVStore{
+		resourceKVStore: resourceKVStore{parent: cache.(KVStore), meter: meter, config: config},
+		cache:           cache,
+	}
+}
+
+func (rcs resourceCacheKVStore) Write() {
+	rcs.cache.Write()
+}
+
+// resourceIterator charges every entry it walks through to the meter
+type resourceIterator struct {
+	parent Iterator
+	meter  ResourceMeter
+	config ResourceConfig
+}
+
+func newResourceIterator(parent Iterator, meter ResourceMeter, config ResourceConfig) Iterator {
+	it := &resourceIterator{parent: parent, meter: meter, config: config}
+	it.consumeSeek()
+	return it
+}
+
+func (ri *resourceIterator) Domain() (start []byte, end []byte) {
+	return ri.parent.Domain()
+}
+
+func (ri *resourceIterator) Valid() bool {
+	return ri.parent.Valid()
+}
+
+func (ri *resourceIterator) Next() {
+	ri.parent.Next()
+	ri.consumeSeek()
+}
+
+func (ri *resourceIterator) Key() []byte {
+	return ri.parent.Key()
+}
+
+func (ri *resourceIterator) Value() []byte {
+	return ri.parent.Value()
+}
+
+func (ri *resourceIterator) Close() {
+	ri.parent.Close()
+}
+
+// consumeSeek charges the entry the iterator moves to
+func (ri *resourceIterator) consumeSeek() {
+	if ri.parent.Valid() {
+		ri.meter.Consume(ri.config.IterNextCostFlat, "IterNextFlat")
+		ri.meter.Consume(ri.config.ReadCostPerByte*uint64(len(ri.parent.Key())+len(ri.parent.Value())), "ValuePerByte")
+	}
+}
@@ -0,0 +1,86 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestResourceMeter(t *testing.T) {
+	meter := types.NewResourceMeter(100)
+	meter.Consume(60, "first")
+	meter.Consume(40, "second")
+	require.Equal(t, uint64(100), meter.Consumed())
+	require.False(t, meter.IsExceeded())
+
+	require.PanicsWithValue(t, types.ErrorOutOfResource{Descriptor: "third"}, func() {
+		meter.Consume(1, "third")
+	})
+	require.True(t, meter.IsExceeded())
+
+	infinite := types.NewInfiniteResourceMeter()
+	infinite.Consume(^uint64(0), "first")
+	infinite.Consume(1, "second")
+	require.Equal(t, ^uint64(0), infinite.Consumed())
+	require.False(t, infinite.IsExceeded())
+}
+
+func TestResourceKVStore(t *testing.T) {
+	key := types.NewKVStoreKey(t.Name())
+	ctx := defaultContext(key)
+	config := types.DefaultResourceConfig()
+
+	// the context is not metered by default
+	require.Nil(t, ctx.ResourceMeter())
+
+	meter := types.NewInfiniteResourceMeter()
+	ctx = ctx.WithResourceMeter(meter, config)
+	store := ctx.KVStore(key)
+
+	k, v := []byte("key"), []byte("value")
+	store.Set(k, v)
+	writeCost := config.WriteCostFlat + config.WriteCostPerByte*uint64(len(k)+len(v))
+	require.Equal(t, writeCost, meter.Consumed())
+
+	require.Equal(t, v, store.Get(k))
+	readCost := config.ReadCostFlat + config.ReadCostPerByte*uint64(len(k)+len(v))
+	require.Equal(t, writeCost+readCost, meter.Consumed())
+
+	require.True(t, store.Has(k))
+	store.Delete(k)
+	require.Equal(t, writeCost+readCost+config.HasCost+config.DeleteCost, meter.Consumed())
+
+	// every entry walked through is charged, including the prefixed store
+	prefixStore := store.Prefix([]byte("prefix"))
+	for _, k := range []string{"a", "b", "c"} {
+		ctx.MultiStore().GetKVStore(key).Set([]byte("prefix"+k), v)
+	}
+	consumed := meter.Consumed()
+	iterator := prefixStore.Iterator(nil, nil)
+	count := 0
+	for ; iterator.Valid(); iterator.Next() {
+		count++
+	}
+	iterator.Close()
+	require.Equal(t, 3, count)
+	iterCost := config.IterNextCostFlat + config.ReadCostPerByte*uint64(1+len(v))
+	require.Equal(t, consumed+3*iterCost, meter.Consumed())
+
+	// the access to the cache-wrapped store is charged, and writing the cache is free
+	cache := store.CacheWrap().(types.CacheKVStore)
+	consumed = meter.Consumed()
+	cache.Set(k, v)
+	require.Equal(t, consumed+writeCost, meter.Consumed())
+	require.Nil(t, ctx.MultiStore().GetKVStore(key).Get(k))
+	cache.Write()
+	require.Equal(t, consumed+writeCost, meter.Consumed())
+	require.Equal(t, v, ctx.MultiStore().GetKVStore(key).Get(k))
+
+	// the limit is enforced on the state access
+	ctx = ctx.WithResourceMeter(types.NewResourceMeter(config.ReadCostFlat-1), config)
+	require.Panics(t, func() {
+		ctx.KVStore(key).Get(k)
+	})
+}
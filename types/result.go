@@ -21,6 +21,20 @@ type Result struct {
 	// Tags are used for transaction indexing and pubsub.
 	Tags   Tags
 	Events Events
+
+	// MsgResults are the results of the msgs in a multi-message tx, in the order of the msgs.
+	MsgResults []MsgResult
+
+	// ResourceUsed is the units consumed by the state access of the tx, it's 0 if the app is not metered.
+	ResourceUsed uint64
+}
+
+// MsgResult is the result of a msg in the tx
+type MsgResult struct {
+	Data   []byte
+	Log    string
+	Tags   Tags
+	Events Events
 }
 
 // TODO: In the future, more codes may be OK.
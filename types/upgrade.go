@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 )
 
 var UpgradeMgr = NewUpgradeManager(UpgradeConfig{})
@@ -21,22 +23,102 @@ const (
 	BEP159Phase2         = "BEP159Phase2" // phase 2 activation height of BEP159, enable create validator and active oracle relayer whitelist
 	BEP173               = "BEP173"       // https://github.com/bnb-chain/BEPs/pull/173
 	FixDoubleSignChainId = "FixDoubleSignChainId"
+	ProphecyKVStore      = "ProphecyKVStore" // store oracle prophecies in sorted KV entries instead of JSON maps
+	RelayerReward        = "RelayerReward"   // accrue relay fees to the oracle relayers which voted for the delivered packages
+	SideChainRegistry    = "SideChainRegistry"
+	IBCPackageTimeout    = "IBCPackageTimeout"
+	CrossStakeStatus     = "CrossStakeStatus"
+	CrossStakeRewardOps  = "CrossStakeRewardOps"
+	CrossStakeFailAck    = "CrossStakeFailAck"
+	RewardLedger         = "RewardLedger"
+	AutoCompound         = "AutoCompound"
+	GovUpgradePlan       = "GovUpgradePlan"   // schedule upgrades with the plans of the passed software upgrade proposals
+	MultiMsgTx           = "MultiMsgTx"       // allow the tx to contain multiple msgs which are executed atomically
+	ProofClaim           = "ProofClaim"       // deliver oracle packages with the receipt proofs of the side chain
+	ParliaEvidence       = "ParliaEvidence"   // verify the double sign evidence against the parlia validator set of its height
+	ResourceMetering     = "ResourceMetering" // meter the state access of the delivered txs and limit it per block
+	LightClient          = "LightClient"      // verify the headers of the side chains with the parlia light clients
 )
 
 var MainNetConfig = UpgradeConfig{
 	HeightMap: map[string]int64{},
 }
 
+// upgrades known by the binary, the node halts at the height of an upgrade scheduled by governance which is not known
+var knownUpgrades = map[string]bool{
+	FixSignBytesOverflow: true,
+	BEP9:                 true,
+	BEP12:                true,
+	BEP3:                 true,
+	BEP8:                 true,
+	LaunchAxcUpgrade:     true,
+	BEP82:                true,
+	FixFailAckPackage:    true,
+	BEP128:               true,
+	BEP153:               true,
+	BEP159:               true,
+	BEP159Phase2:         true,
+	BEP173:               true,
+	FixDoubleSignChainId: true,
+	ProphecyKVStore:      true,
+	RelayerReward:        true,
+	SideChainRegistry:    true,
+	IBCPackageTimeout:    true,
+	CrossStakeStatus:     true,
+	CrossStakeRewardOps:  true,
+	CrossStakeFailAck:    true,
+	RewardLedger:         true,
+	AutoCompound:         true,
+	GovUpgradePlan:       true,
+	MultiMsgTx:           true,
+	ProofClaim:           true,
+	ParliaEvidence:       true,
+	ResourceMetering:     true,
+	LightClient:          true,
+}
+
+func IsKnownUpgrade(name string) bool {
+	return knownUpgrades[name]
+}
+
+// RegisterUpgradeName registers an upgrade defined by the app as known by the binary, so governance can schedule it
+// and the store keys, msg types and begin blockers can be registered by its name before it is scheduled.
+// It must be called before the node starts.
+func RegisterUpgradeName(name string) {
+	knownUpgrades[name] = true
+}
+
+// The StoreKeyMap, MsgTypeMap and BeginBlockers are keyed by the height of the upgrade at the registration, while
+// the Named* fields are keyed by the name of the upgrade, so they follow the height of the upgrade even if it is
+// scheduled by governance after they are registered.
 type UpgradeConfig struct {
 	HeightMap     map[string]int64
 	StoreKeyMap   map[string]int64
 	MsgTypeMap    map[string]int64
 	BeginBlockers map[int64][]func(ctx Context)
+
+	NamedStoreKeyMap   map[string]string // store key name -> upgrade name
+	NamedMsgTypeMap    map[string]string // msg type -> upgrade name
+	NamedBeginBlockers []UpgradeBeginBlocker
+}
+
+type UpgradeBeginBlocker struct {
+	Name         string
+	BeginBlocker func(ctx Context)
 }
 
+// UpgradeManager is written by the ABCI BeginBlock and EndBlock and read by the queries concurrently, so the
+// fields should be accessed by the methods only
 type UpgradeManager struct {
+	mtx sync.RWMutex
+
 	Config UpgradeConfig
 	Height int64
+
+	// names of the upgrades the height keyed begin blockers, store keys and msg types are registered by
+	beginBlockerNames []string
+	storeKeyNames     map[string]string
+	msgTypeNames      map[string]string
 }
 
 func NewUpgradeManager(config UpgradeConfig) *UpgradeManager {
@@ -46,7 +128,13 @@ func NewUpgradeManager(config UpgradeConfig) *UpgradeManager {
 }
 
 func (mgr *UpgradeManager) Reset() {
-	*mgr = *NewUpgradeManager(UpgradeConfig{})
+	mgr.mtx.Lock()
+	defer mgr.mtx.Unlock()
+	mgr.Config = UpgradeConfig{}
+	mgr.Height = 0
+	mgr.beginBlockerNames = nil
+	mgr.storeKeyNames = nil
+	mgr.msgTypeNames = nil
 }
 
 func (mgr *UpgradeManager) AddConfig(config UpgradeConfig) {
@@ -56,24 +144,44 @@ func (mgr *UpgradeManager) AddConfig(config UpgradeConfig) {
 }
 
 func (mgr *UpgradeManager) SetHeight(height int64) {
+	mgr.mtx.Lock()
+	defer mgr.mtx.Unlock()
 	mgr.Height = height
 }
 
 func (mgr *UpgradeManager) GetHeight() int64 {
+	mgr.mtx.RLock()
+	defer mgr.mtx.RUnlock()
 	return mgr.Height
 }
 
 // run in every ABCI BeginBlock.
 func (mgr *UpgradeManager) BeginBlocker(ctx Context) {
-	if beginBlockers, ok := mgr.Config.BeginBlockers[mgr.GetHeight()]; ok {
-		for _, beginBlocker := range beginBlockers {
-			beginBlocker(ctx)
+	// the begin blockers read the manager, so they run without the lock
+	for _, beginBlocker := range mgr.getBeginBlockers() {
+		beginBlocker(ctx)
+	}
+}
+
+func (mgr *UpgradeManager) getBeginBlockers() []func(Context) {
+	mgr.mtx.RLock()
+	defer mgr.mtx.RUnlock()
+
+	var res []func(Context)
+	res = append(res, mgr.Config.BeginBlockers[mgr.Height]...)
+	for _, beginBlocker := range mgr.Config.NamedBeginBlockers {
+		if upgradeHeight := mgr.getUpgradeHeight(beginBlocker.Name); upgradeHeight != 0 && upgradeHeight == mgr.Height {
+			res = append(res, beginBlocker.BeginBlocker)
 		}
 	}
+	return res
 }
 
 func (mgr *UpgradeManager) RegisterBeginBlocker(name string, beginBlocker func(Context)) {
-	height := mgr.GetUpgradeHeight(name)
+	mgr.mtx.Lock()
+	defer mgr.mtx.Unlock()
+
+	height := mgr.getUpgradeHeight(name)
 	if height == 0 {
 		panic(fmt.Errorf("no UpgradeHeight found for %s", name))
 	}
@@ -88,9 +196,23 @@ func (mgr *UpgradeManager) RegisterBeginBlocker(name string, beginBlocker func(C
 	} else {
 		mgr.Config.BeginBlockers[height] = []func(Context){beginBlocker}
 	}
+	mgr.beginBlockerNames = append(mgr.beginBlockerNames, name)
+}
+
+// RegisterBeginBlockerByName registers the beginBlocker to run at the height of the known upgrade, the upgrade may
+// be scheduled later by the config or governance
+func (mgr *UpgradeManager) RegisterBeginBlockerByName(name string, beginBlocker func(Context)) {
+	mustBeKnownUpgrade(name)
+	mgr.mtx.Lock()
+	defer mgr.mtx.Unlock()
+	mgr.Config.NamedBeginBlockers = append(mgr.Config.NamedBeginBlockers,
+		UpgradeBeginBlocker{Name: name, BeginBlocker: beginBlocker})
 }
 
 func (mgr *UpgradeManager) AddUpgradeHeight(name string, height int64) {
+	mgr.mtx.Lock()
+	defer mgr.mtx.Unlock()
+
 	if mgr.Config.HeightMap == nil {
 		mgr.Config.HeightMap = map[string]int64{}
 	}
@@ -99,6 +221,12 @@ func (mgr *UpgradeManager) AddUpgradeHeight(name string, height int64) {
 }
 
 func (mgr *UpgradeManager) GetUpgradeHeight(name string) int64 {
+	mgr.mtx.RLock()
+	defer mgr.mtx.RUnlock()
+	return mgr.getUpgradeHeight(name)
+}
+
+func (mgr *UpgradeManager) getUpgradeHeight(name string) int64 {
 	if mgr.Config.HeightMap == nil {
 		return 0
 	}
@@ -106,7 +234,10 @@ func (mgr *UpgradeManager) GetUpgradeHeight(name string) int64 {
 }
 
 func (mgr *UpgradeManager) RegisterStoreKeys(upgradeName string, storeKeyNames ...string) {
-	height := mgr.GetUpgradeHeight(upgradeName)
+	mgr.mtx.Lock()
+	defer mgr.mtx.Unlock()
+
+	height := mgr.getUpgradeHeight(upgradeName)
 	if height == 0 {
 		panic(fmt.Errorf("no UpgradeHeight found for %s", upgradeName))
 	}
@@ -114,14 +245,37 @@ func (mgr *UpgradeManager) RegisterStoreKeys(upgradeName string, storeKeyNames .
 	if mgr.Config.StoreKeyMap == nil {
 		mgr.Config.StoreKeyMap = map[string]int64{}
 	}
+	if mgr.storeKeyNames == nil {
+		mgr.storeKeyNames = map[string]string{}
+	}
 
 	for _, storeKeyName := range storeKeyNames {
 		mgr.Config.StoreKeyMap[storeKeyName] = height
+		mgr.storeKeyNames[storeKeyName] = upgradeName
+	}
+}
+
+// RegisterStoreKeysByName registers the store keys to be committed from the height of the known upgrade, the
+// stores are not committed until the upgrade is scheduled and activated
+func (mgr *UpgradeManager) RegisterStoreKeysByName(upgradeName string, storeKeyNames ...string) {
+	mustBeKnownUpgrade(upgradeName)
+	mgr.mtx.Lock()
+	defer mgr.mtx.Unlock()
+
+	if mgr.Config.NamedStoreKeyMap == nil {
+		mgr.Config.NamedStoreKeyMap = map[string]string{}
+	}
+
+	for _, storeKeyName := range storeKeyNames {
+		mgr.Config.NamedStoreKeyMap[storeKeyName] = upgradeName
 	}
 }
 
 func (mgr *UpgradeManager) RegisterMsgTypes(upgradeName string, msgTypes ...string) {
-	height := mgr.GetUpgradeHeight(upgradeName)
+	mgr.mtx.Lock()
+	defer mgr.mtx.Unlock()
+
+	height := mgr.getUpgradeHeight(upgradeName)
 	if height == 0 {
 		panic(fmt.Errorf("no UpgradeHeight found for %s", upgradeName))
 	}
@@ -129,26 +283,184 @@ func (mgr *UpgradeManager) RegisterMsgTypes(upgradeName string, msgTypes ...stri
 	if mgr.Config.MsgTypeMap == nil {
 		mgr.Config.MsgTypeMap = map[string]int64{}
 	}
+	if mgr.msgTypeNames == nil {
+		mgr.msgTypeNames = map[string]string{}
+	}
 
 	for _, msgType := range msgTypes {
 		mgr.Config.MsgTypeMap[msgType] = height
+		mgr.msgTypeNames[msgType] = upgradeName
 	}
 }
 
-func (mgr *UpgradeManager) GetStoreKeyHeight(storeKeyName string) int64 {
-	if mgr.Config.StoreKeyMap == nil {
-		return 0
+// RegisterMsgTypesByName registers the msg types to be supported from the height of the known upgrade, the msgs
+// are not supported until the upgrade is scheduled and activated
+func (mgr *UpgradeManager) RegisterMsgTypesByName(upgradeName string, msgTypes ...string) {
+	mustBeKnownUpgrade(upgradeName)
+	mgr.mtx.Lock()
+	defer mgr.mtx.Unlock()
+
+	if mgr.Config.NamedMsgTypeMap == nil {
+		mgr.Config.NamedMsgTypeMap = map[string]string{}
+	}
+
+	for _, msgType := range msgTypes {
+		mgr.Config.NamedMsgTypeMap[msgType] = upgradeName
 	}
+}
+
+func mustBeKnownUpgrade(name string) {
+	if !IsKnownUpgrade(name) {
+		panic(fmt.Errorf("upgrade %s is not known, register it by RegisterUpgradeName first", name))
+	}
+}
+
+// GetStoreKeyHeight returns the height from which the store is committed, 0 if the store is not registered by an
+// upgrade or the upgrade is not scheduled yet
+func (mgr *UpgradeManager) GetStoreKeyHeight(storeKeyName string) int64 {
+	height, _ := mgr.getStoreKeyHeight(storeKeyName)
+	return height
+}
+
+// getStoreKeyHeight returns the height of the upgrade the store key is registered by, registered is false if the
+// store key is not registered by any upgrade
+func (mgr *UpgradeManager) getStoreKeyHeight(storeKeyName string) (height int64, registered bool) {
+	mgr.mtx.RLock()
+	defer mgr.mtx.RUnlock()
 
-	return mgr.Config.StoreKeyMap[storeKeyName]
+	if name, ok := mgr.Config.NamedStoreKeyMap[storeKeyName]; ok {
+		return mgr.getUpgradeHeight(name), true
+	}
+	height, registered = mgr.Config.StoreKeyMap[storeKeyName]
+	return height, registered
 }
 
+// GetMsgTypeHeight returns the height from which the msg type is supported, 0 if the msg type is not registered by
+// an upgrade or the upgrade is not scheduled yet
 func (mgr *UpgradeManager) GetMsgTypeHeight(msgType string) int64 {
-	if mgr.Config.MsgTypeMap == nil {
-		return 0
+	height, _ := mgr.getMsgTypeHeight(msgType)
+	return height
+}
+
+// getMsgTypeHeight returns the height of the upgrade the msg type is registered by, registered is false if the
+// msg type is not registered by any upgrade
+func (mgr *UpgradeManager) getMsgTypeHeight(msgType string) (height int64, registered bool) {
+	mgr.mtx.RLock()
+	defer mgr.mtx.RUnlock()
+
+	if name, ok := mgr.Config.NamedMsgTypeMap[msgType]; ok {
+		return mgr.getUpgradeHeight(name), true
+	}
+	height, registered = mgr.Config.MsgTypeMap[msgType]
+	return height, registered
+}
+
+// UpgradeInfo describes an upgrade known by the binary or registered into the UpgradeManager
+type UpgradeInfo struct {
+	Name          string   `json:"name"`
+	Height        int64    `json:"height"` // 0 if the upgrade is not scheduled
+	Activated     bool     `json:"activated"`
+	Known         bool     `json:"known"`
+	BeginBlockers int      `json:"begin_blockers"`
+	StoreKeys     []string `json:"store_keys"`
+	MsgTypes      []string `json:"msg_types"`
+}
+
+// GetUpgradeInfos returns the upgrades ordered by the height, the upgrades not scheduled are at the end
+func (mgr *UpgradeManager) GetUpgradeInfos() []UpgradeInfo {
+	mgr.mtx.RLock()
+	defer mgr.mtx.RUnlock()
+
+	infos := make(map[string]*UpgradeInfo)
+	getInfo := func(name string) *UpgradeInfo {
+		if info, ok := infos[name]; ok {
+			return info
+		}
+		height := mgr.getUpgradeHeight(name)
+		info := &UpgradeInfo{
+			Name:      name,
+			Height:    height,
+			Activated: height != 0 && mgr.Height >= height,
+			Known:     IsKnownUpgrade(name),
+			StoreKeys: []string{},
+			MsgTypes:  []string{},
+		}
+		infos[name] = info
+		return info
+	}
+
+	for name := range knownUpgrades {
+		getInfo(name)
+	}
+	for name := range mgr.Config.HeightMap {
+		getInfo(name)
+	}
+	for _, name := range mgr.beginBlockerNames {
+		getInfo(name).BeginBlockers++
+	}
+	for _, beginBlocker := range mgr.Config.NamedBeginBlockers {
+		getInfo(beginBlocker.Name).BeginBlockers++
+	}
+	for _, storeKeyMap := range []map[string]string{mgr.storeKeyNames, mgr.Config.NamedStoreKeyMap} {
+		for storeKeyName, name := range storeKeyMap {
+			info := getInfo(name)
+			info.StoreKeys = append(info.StoreKeys, storeKeyName)
+		}
+	}
+	for _, msgTypeMap := range []map[string]string{mgr.msgTypeNames, mgr.Config.NamedMsgTypeMap} {
+		for msgType, name := range msgTypeMap {
+			info := getInfo(name)
+			info.MsgTypes = append(info.MsgTypes, msgType)
+		}
 	}
 
-	return mgr.Config.MsgTypeMap[msgType]
+	res := make([]UpgradeInfo, 0, len(infos))
+	for _, info := range infos {
+		sort.Strings(info.StoreKeys)
+		sort.Strings(info.MsgTypes)
+		res = append(res, *info)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Height != res[j].Height {
+			return res[j].Height == 0 || (res[i].Height != 0 && res[i].Height < res[j].Height)
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
+// CheckUpgradeHeights checks the upgrade heights of a config against the upgrades of a binary at the height,
+// it returns the problems which prevent the node from restarting with the config
+func CheckUpgradeHeights(upgrades []UpgradeInfo, height int64, heightMap map[string]int64) []error {
+	infos := make(map[string]UpgradeInfo, len(upgrades))
+	for _, info := range upgrades {
+		infos[info.Name] = info
+	}
+
+	names := make([]string, 0, len(heightMap))
+	for name := range heightMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		configHeight := heightMap[name]
+		info, ok := infos[name]
+		switch {
+		case !ok || !info.Known:
+			errs = append(errs, fmt.Errorf("upgrade %s is not known by the binary", name))
+		case configHeight <= 0:
+			errs = append(errs, fmt.Errorf("height of upgrade %s should be positive, got %d", name, configHeight))
+		case info.Activated && configHeight != info.Height:
+			errs = append(errs, fmt.Errorf("upgrade %s has been activated at height %d, can not be moved to %d",
+				name, info.Height, configHeight))
+		case !info.Activated && configHeight <= height:
+			errs = append(errs, fmt.Errorf("height %d of upgrade %s should be greater than the current height %d",
+				configHeight, name, height))
+		}
+	}
+	return errs
 }
 
 func IsUpgradeHeight(name string) bool {
@@ -170,10 +482,14 @@ func IsUpgrade(name string) bool {
 }
 
 func ShouldCommitStore(storeKeyName string) bool {
-	storeKeyHeight := UpgradeMgr.GetStoreKeyHeight(storeKeyName)
-	if storeKeyHeight == 0 {
+	storeKeyHeight, registered := UpgradeMgr.getStoreKeyHeight(storeKeyName)
+	if !registered {
 		return true
 	}
+	// the upgrade is not scheduled yet
+	if storeKeyHeight == 0 {
+		return false
+	}
 
 	return UpgradeMgr.GetHeight() >= storeKeyHeight
 }
@@ -188,10 +504,14 @@ func ShouldSetStoreVersion(storeKeyName string) bool {
 }
 
 func IsMsgTypeSupported(msgType string) bool {
-	msgTypeHeight := UpgradeMgr.GetMsgTypeHeight(msgType)
-	if msgTypeHeight == 0 {
+	msgTypeHeight, registered := UpgradeMgr.getMsgTypeHeight(msgType)
+	if !registered {
 		return true
 	}
+	// the upgrade is not scheduled yet
+	if msgTypeHeight == 0 {
+		return false
+	}
 
 	return UpgradeMgr.GetHeight() >= msgTypeHeight
 }
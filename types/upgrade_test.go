@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -174,3 +175,98 @@ func TestMsgType(t *testing.T) {
 		require.Equal(t, tc.isSupported, IsMsgTypeSupported(MsgTypeTest))
 	}
 }
+
+func TestRegisterByName(t *testing.T) {
+	UpgradeMgr = NewUpgradeManager(UpgradeConfig{})
+	defer UpgradeMgr.Reset()
+
+	// the upgrade must be scheduled to register by the height, or known to register by the name
+	require.Panics(t, func() { UpgradeMgr.RegisterStoreKeys(UpgradeTest, StoreKeyNameTest) })
+	require.Panics(t, func() { UpgradeMgr.RegisterStoreKeysByName(UpgradeTest, StoreKeyNameTest) })
+	RegisterUpgradeName(UpgradeTest)
+	defer delete(knownUpgrades, UpgradeTest)
+
+	var ran int
+	UpgradeMgr.RegisterBeginBlockerByName(UpgradeTest, func(ctx Context) { ran++ })
+	UpgradeMgr.RegisterStoreKeysByName(UpgradeTest, StoreKeyNameTest)
+	UpgradeMgr.RegisterMsgTypesByName(UpgradeTest, MsgTypeTest)
+
+	// the stores and msgs of the upgrade not scheduled are not active
+	UpgradeMgr.SetHeight(100)
+	UpgradeMgr.BeginBlocker(Context{})
+	require.Equal(t, 0, ran)
+	require.False(t, ShouldCommitStore(StoreKeyNameTest))
+	require.False(t, IsMsgTypeSupported(MsgTypeTest))
+	require.Equal(t, int64(0), UpgradeMgr.GetStoreKeyHeight(StoreKeyNameTest))
+
+	// they follow the height of the upgrade scheduled after the registration
+	UpgradeMgr.AddUpgradeHeight(UpgradeTest, 200)
+	require.False(t, ShouldCommitStore(StoreKeyNameTest))
+	require.False(t, IsMsgTypeSupported(MsgTypeTest))
+	UpgradeMgr.SetHeight(200)
+	UpgradeMgr.BeginBlocker(Context{})
+	require.Equal(t, 1, ran)
+	require.True(t, ShouldCommitStore(StoreKeyNameTest))
+	require.True(t, ShouldSetStoreVersion(StoreKeyNameTest))
+	require.True(t, IsMsgTypeSupported(MsgTypeTest))
+	require.Equal(t, int64(200), UpgradeMgr.GetMsgTypeHeight(MsgTypeTest))
+}
+
+func TestUpgradeInfos(t *testing.T) {
+	UpgradeMgr = NewUpgradeManager(UpgradeConfig{})
+	defer UpgradeMgr.Reset()
+
+	UpgradeMgr.AddUpgradeHeight(BEP9, 100)
+	UpgradeMgr.AddUpgradeHeight(UpgradeTest, 50)
+	UpgradeMgr.RegisterBeginBlocker(BEP9, func(ctx Context) {})
+	UpgradeMgr.RegisterBeginBlocker(BEP9, func(ctx Context) {})
+	UpgradeMgr.RegisterStoreKeys(UpgradeTest, StoreKeyNameTest)
+	UpgradeMgr.RegisterMsgTypesByName(BEP12, MsgTypeTest)
+	UpgradeMgr.SetHeight(60)
+
+	infos := UpgradeMgr.GetUpgradeInfos()
+	require.Equal(t, len(knownUpgrades)+1, len(infos))
+	require.Equal(t, UpgradeInfo{Name: UpgradeTest, Height: 50, Activated: true, StoreKeys: []string{StoreKeyNameTest}, MsgTypes: []string{}}, infos[0])
+	require.Equal(t, UpgradeInfo{Name: BEP9, Height: 100, Known: true, BeginBlockers: 2, StoreKeys: []string{}, MsgTypes: []string{}}, infos[1])
+	// the upgrades not scheduled are ordered by the name
+	require.Equal(t, AutoCompound, infos[2].Name)
+	for _, info := range infos {
+		if info.Name == BEP12 {
+			require.Equal(t, UpgradeInfo{Name: BEP12, Known: true, StoreKeys: []string{}, MsgTypes: []string{MsgTypeTest}}, info)
+		}
+	}
+
+	errs := CheckUpgradeHeights(infos, 60, map[string]int64{
+		BEP9:        200, // reschedule the upgrade not activated
+		BEP12:       70,
+		BEP3:        60,   // not after the current height
+		BEP8:        0,    // not positive
+		UpgradeTest: 40,   // move the activated upgrade
+		"Unknown":   1000, // not known by the binary
+	})
+	require.Len(t, errs, 4)
+	require.Contains(t, errs[0].Error(), BEP3)
+	require.Contains(t, errs[1].Error(), BEP8)
+	require.Contains(t, errs[2].Error(), "Unknown")
+	require.Contains(t, errs[3].Error(), UpgradeTest)
+}
+
+func TestUpgradeManagerConcurrentAccess(t *testing.T) {
+	mgr := NewUpgradeManager(UpgradeConfig{})
+
+	// the upgrades are scheduled by the EndBlock while they are queried
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := int64(1); i <= 1000; i++ {
+			mgr.AddUpgradeHeight(fmt.Sprintf("upgrade%d", i), i)
+			mgr.SetHeight(i)
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		mgr.GetUpgradeInfos()
+		mgr.GetHeight()
+	}
+	<-done
+	require.Equal(t, int64(1000), mgr.GetUpgradeHeight("upgrade1000"))
+}
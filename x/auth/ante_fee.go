@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+)
+
+// txHashKey is the key of the tx hash in the context, it's the same as baseapp.TxHashKey, which can't be imported
+// here as baseapp imports auth.
+const txHashKey = "txHash"
+
+// NewFeeAnteHandler returns an AnteHandler that runs the anteHandler to check the signatures first, and then deducts
+// the fee of the msgs from the first signer. The fee is calculated by the calculators registered into fees for the
+// msg types, e.g. by the fee params of paramHub, and the tx is rejected as not supported if a calculator is missing.
+//
+// In DeliverTx, the fee is recorded into fees.Pool under the tx hash, including the free one, and the app is
+// expected to commit it with fees.Pool.CommitFee once the tx succeeds.
+func NewFeeAnteHandler(am AccountKeeper, anteHandler sdk.AnteHandler) sdk.AnteHandler {
+	return func(
+		ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode,
+	) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		newCtx, res, abort = anteHandler(ctx, tx, mode)
+		if abort {
+			return newCtx, res, abort
+		}
+		if newCtx.IsZero() {
+			newCtx = ctx
+		}
+
+		fee, err := fees.CalculateMsgsFee(tx.GetMsgs())
+		if err != nil {
+			return newCtx, sdk.ErrMsgNotSupported(err.Error()).Result(), true
+		}
+
+		if fee.Type != sdk.FeeFree && !fee.Tokens.IsZero() {
+			signers := GetSigners(newCtx)
+			if len(signers) == 0 {
+				return newCtx, sdk.ErrUnauthorized("no signer to pay the fee").Result(), true
+			}
+			payer, res := deductFees(newCtx, am, signers[0], fee)
+			if !res.IsOK() {
+				return newCtx, res, true
+			}
+
+			// the cached signers are updated with the payer
+			newSigners := make([]sdk.Account, len(signers))
+			copy(newSigners, signers)
+			newSigners[0] = payer
+			newCtx = WithSigners(newCtx, newSigners)
+		}
+
+		if mode == sdk.RunTxModeDeliver || mode == sdk.RunTxModeDeliverAfterPre {
+			txHash, _ := newCtx.Value(txHashKey).(string)
+			fees.Pool.AddFee(txHash, fee)
+		}
+
+		return newCtx, sdk.Result{}, false // continue...
+	}
+}
+
+// deductFees deducts the fee from the account and saves it
+func deductFees(ctx sdk.Context, am AccountKeeper, acc sdk.Account, fee sdk.Fee) (sdk.Account, sdk.Result) {
+	coins := acc.GetCoins()
+	newCoins := coins.Minus(fee.Tokens)
+	if !newCoins.IsNotNegative() {
+		return nil, sdk.ErrInsufficientFunds(fmt.Sprintf("%s < %s", coins, fee.Tokens)).Result()
+	}
+	if err := acc.SetCoins(newCoins); err != nil {
+		// Handle w/ #870
+		panic(err)
+	}
+	am.SetAccount(ctx, acc)
+	return acc, sdk.Result{}
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+)
+
+func TestFeeAnteHandler(t *testing.T) {
+	defer fees.UnsetAllCalculators()
+	defer fees.Pool.Clear()
+
+	// setup
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	anteHandler := NewFeeAnteHandler(mapper, NewAnteHandler(mapper))
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	ctx = ctx.WithBlockHeight(1)
+
+	// the first signer pays the fee
+	priv1, addr1 := privAndAddr()
+	priv2, addr2 := privAndAddr()
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 100)})
+	mapper.SetAccount(ctx, acc1)
+	acc2 := mapper.NewAccountWithAddress(ctx, addr2)
+	acc2.SetCoins(sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 100)})
+	mapper.SetAccount(ctx, acc2)
+
+	msgs := []sdk.Msg{newTestMsg(addr1, addr2)}
+	privs, accNums := []crypto.PrivKey{priv1, priv2}, []int64{0, 1}
+	seq := int64(0)
+	newTx := func() sdk.Tx {
+		tx := newTestTx(ctx, msgs, privs, accNums, []int64{seq, seq})
+		seq++
+		return tx
+	}
+
+	// the tx is rejected without the fee calculator
+	checkInvalidTx(t, anteHandler, ctx, newTx(), sdk.RunTxModeDeliver, sdk.CodeMsgNotSupported)
+
+	fees.RegisterCalculator(msgs[0].Type(), fees.FixedFeeCalculator(30, sdk.FeeForProposer))
+	newCtx, res, abort := anteHandler(ctx.WithValue(txHashKey, "tx1"), newTx(), sdk.RunTxModeDeliver)
+	require.False(t, abort, res.Log)
+	require.Equal(t, int64(70), mapper.GetAccount(ctx, addr1).GetCoins().AmountOf(sdk.NativeTokenSymbol))
+	require.Equal(t, int64(100), mapper.GetAccount(ctx, addr2).GetCoins().AmountOf(sdk.NativeTokenSymbol))
+	require.Equal(t, int64(70), GetSigners(newCtx)[0].GetCoins().AmountOf(sdk.NativeTokenSymbol))
+	require.Equal(t, sdk.NewFee(sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 30)}, sdk.FeeForProposer), *fees.Pool.GetFee("tx1"))
+
+	// the fee is deducted in CheckTx, but it's only recorded in DeliverTx
+	_, res, abort = anteHandler(ctx.WithValue(txHashKey, "tx2"), newTx(), sdk.RunTxModeCheck)
+	require.False(t, abort, res.Log)
+	require.Equal(t, int64(40), mapper.GetAccount(ctx, addr1).GetCoins().AmountOf(sdk.NativeTokenSymbol))
+	require.Nil(t, fees.Pool.GetFee("tx2"))
+
+	// the payer can not afford the fee
+	fees.RegisterCalculator(msgs[0].Type(), fees.FixedFeeCalculator(50, sdk.FeeForAll))
+	checkInvalidTx(t, anteHandler, ctx, newTx(), sdk.RunTxModeDeliver, sdk.CodeInsufficientFunds)
+	require.Equal(t, int64(40), mapper.GetAccount(ctx, addr1).GetCoins().AmountOf(sdk.NativeTokenSymbol))
+
+	// the free fee is recorded without the deduction
+	fees.RegisterCalculator(msgs[0].Type(), fees.FreeFeeCalculator())
+	_, res, abort = anteHandler(ctx.WithValue(txHashKey, "tx3"), newTx(), sdk.RunTxModeDeliver)
+	require.False(t, abort, res.Log)
+	require.Equal(t, int64(40), mapper.GetAccount(ctx, addr1).GetCoins().AmountOf(sdk.NativeTokenSymbol))
+	require.Equal(t, sdk.FeeFree, fees.Pool.GetFee("tx3").Type)
+}
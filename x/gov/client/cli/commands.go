@@ -23,6 +23,7 @@ func AddCommands(cmd *cobra.Command, cdc *codec.Codec) {
 			GetCmdSubmitProposal(cdc),
 			GetCmdSubmitListProposal(cdc),
 			GetCmdSubmitDelistProposal(cdc),
+			GetCmdSubmitUpgradeProposal(cdc),
 			GetCmdVote(cdc),
 		)...,
 	)
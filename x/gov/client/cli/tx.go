@@ -41,6 +41,9 @@ const (
 	flagInitPrice         = "init-price"
 	flagExpireTime        = "expire-time"
 	flagSideChainId       = "side-chain-id"
+	flagUpgradeName       = "upgrade-name"
+	flagUpgradeHeight     = "upgrade-height"
+	flagUpgradeInfo       = "upgrade-info"
 )
 
 type proposal struct {
@@ -824,3 +827,84 @@ func GetCmdSubmitDelistProposal(cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// GetCmdSubmitUpgradeProposal implements submitting a software upgrade proposal transaction command.
+func GetCmdSubmitUpgradeProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit-upgrade-proposal",
+		Short: "Submit a software upgrade proposal with the upgrade plan along with an initial deposit",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			title := viper.GetString(flagTitle)
+			initialDeposit := viper.GetString(flagDeposit)
+			votingPeriodInSeconds := viper.GetInt64(flagVotingPeriod)
+
+			if title == "" {
+				return errors.New("Title should not be empty")
+			}
+
+			if len(title) > gov.MaxTitleLength {
+				return fmt.Errorf("Proposal title is longer than max length of %d", gov.MaxTitleLength)
+			}
+
+			plan := gov.SoftwareUpgradePlan{
+				Name:   viper.GetString(flagUpgradeName),
+				Height: viper.GetInt64(flagUpgradeHeight),
+				Info:   viper.GetString(flagUpgradeInfo),
+			}
+			if err := plan.ValidateBasic(); err != nil {
+				return err
+			}
+
+			if votingPeriodInSeconds <= 0 {
+				return errors.New("voting period should be positive")
+			}
+
+			votingPeriod := time.Duration(votingPeriodInSeconds) * time.Second
+			if votingPeriod > gov.MaxVotingPeriod {
+				return fmt.Errorf("voting period should be less than %d seconds", gov.MaxVotingPeriod/time.Second)
+			}
+
+			fromAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoins(initialDeposit)
+			if err != nil {
+				return err
+			}
+
+			planBz, err := json.Marshal(plan)
+			if err != nil {
+				return err
+			}
+			msg := gov.NewMsgSubmitProposal(title, string(planBz), gov.ProposalTypeSoftwareUpgrade, fromAddr, amount, votingPeriod)
+
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+
+			cliCtx.PrintResponse = true
+			return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagTitle, "", "title of proposal")
+	cmd.Flags().Int64(flagVotingPeriod, 7*24*60*60, "voting period in seconds")
+	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
+	cmd.Flags().String(flagUpgradeName, "", "name of the upgrade, which must be known by the binary from the upgrade height")
+	cmd.Flags().Int64(flagUpgradeHeight, 0, "height from which the upgrade is activated")
+	cmd.Flags().String(flagUpgradeInfo, "", "info about the upgrade, e.g. the release of the binary")
+
+	return cmd
+}
@@ -38,6 +38,10 @@ func NormalizeProposalType(proposalType string) string {
 		return "CSCParamsChange"
 	case "ManageChanPermission", "manage_chan_permission":
 		return "ManageChanPermission"
+	case "RegisterSideChain", "register_side_chain":
+		return "RegisterSideChain"
+	case "CreateLightClient", "create_light_client":
+		return "CreateLightClient"
 	}
 	return ""
 }
@@ -33,6 +33,11 @@ func NewHandler(keeper Keeper) sdk.Handler {
 }
 
 func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
+	if msg.ProposalType == ProposalTypeSoftwareUpgrade && sdk.IsUpgrade(sdk.GovUpgradePlan) {
+		if _, err := keeper.checkUpgradePlan(ctx, msg.Description); err != nil {
+			return ErrInvalidProposal(keeper.codespace, err.Error()).Result()
+		}
+	}
 
 	proposal := keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType, msg.VotingPeriod)
 
@@ -202,6 +207,11 @@ func settleProposals(ctx sdk.Context, keeper Keeper, chainId string) (resEvents
 			// refund deposits
 			keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
 			refundProposals = append(refundProposals, SimpleProposal{activeProposal.GetProposalID(), chainId})
+
+			if activeProposal.GetProposalType() == ProposalTypeSoftwareUpgrade && chainId == NativeChainID &&
+				sdk.IsUpgrade(sdk.GovUpgradePlan) {
+				keeper.applyUpgradePlan(ctx, activeProposal)
+			}
 		} else {
 			activeProposal.SetStatus(StatusRejected)
 			action = events.EventTypeProposalRejected
@@ -13,6 +13,7 @@ var (
 	KeyNextProposalID        = []byte("newProposalID")
 	KeyActiveProposalQueue   = []byte("activeProposalQueue")
 	KeyInactiveProposalQueue = []byte("inactiveProposalQueue")
+	KeyUpgradePlansSubspace  = []byte("upgradePlans:")
 )
 
 // Key for getting a specific proposal from the store
@@ -30,6 +31,11 @@ func KeyVote(proposalID int64, voterAddr sdk.AccAddress) []byte {
 	return []byte(fmt.Sprintf("votes:%d:%d", proposalID, voterAddr))
 }
 
+// Key for getting the plan of a passed software upgrade proposal from the store
+func KeyUpgradePlan(name string) []byte {
+	return []byte(fmt.Sprintf("upgradePlans:%s", name))
+}
+
 // Key for getting all deposits on a proposal from the store
 func KeyDepositsSubspace(proposalID int64) []byte {
 	return []byte(fmt.Sprintf("deposits:%d:", proposalID))
@@ -131,6 +131,8 @@ const (
 	ProposalTypeRemoveValidator      ProposalKind = 0x07
 	ProposalTypeDelistTradingPair    ProposalKind = 0x08
 	ProposalTypeManageChanPermission ProposalKind = 0x09
+	ProposalTypeRegisterSideChain    ProposalKind = 0x0A
+	ProposalTypeCreateLightClient    ProposalKind = 0x0B
 )
 
 // String to proposalType byte.  Returns ff if invalid.
@@ -158,6 +160,10 @@ func ProposalTypeFromString(str string) (ProposalKind, error) {
 		return ProposalTypeCSCParamsChange, nil
 	case "ManageChanPermission":
 		return ProposalTypeManageChanPermission, nil
+	case "RegisterSideChain":
+		return ProposalTypeRegisterSideChain, nil
+	case "CreateLightClient":
+		return ProposalTypeCreateLightClient, nil
 	default:
 		return ProposalKind(0xff), errors.Errorf("'%s' is not a valid proposal type", str)
 	}
@@ -173,7 +179,9 @@ func validProposalType(pt ProposalKind) bool {
 		pt == ProposalTypeCreateValidator ||
 		pt == ProposalTypeRemoveValidator ||
 		pt == ProposalTypeDelistTradingPair ||
-		pt == ProposalTypeManageChanPermission {
+		pt == ProposalTypeManageChanPermission ||
+		pt == ProposalTypeRegisterSideChain ||
+		pt == ProposalTypeCreateLightClient {
 		return true
 	}
 	return false
@@ -236,6 +244,10 @@ func (pt ProposalKind) String() string {
 		return "CSCParamsChange"
 	case ProposalTypeManageChanPermission:
 		return "ManageChanPermission"
+	case ProposalTypeRegisterSideChain:
+		return "RegisterSideChain"
+	case ProposalTypeCreateLightClient:
+		return "CreateLightClient"
 	default:
 		return ""
 	}
@@ -0,0 +1,130 @@
+package gov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+//-----------------------------------------------------------
+// SoftwareUpgradePlan
+
+// SoftwareUpgradePlan is carried in json as the description of the software upgrade proposal. The upgrade is
+// scheduled into sdk.UpgradeMgr once the proposal passes.
+type SoftwareUpgradePlan struct {
+	Name   string `json:"name"`   // name of the upgrade, which must be known by the binary from the height
+	Height int64  `json:"height"` // height from which the upgrade is activated
+	Info   string `json:"info"`   // info about the upgrade, e.g. the release of the binary
+}
+
+func (plan SoftwareUpgradePlan) ValidateBasic() error {
+	if len(plan.Name) == 0 {
+		return errors.New("upgrade name should not be empty")
+	}
+	if plan.Height <= 0 {
+		return fmt.Errorf("upgrade height should be positive, got %d", plan.Height)
+	}
+	return nil
+}
+
+func (plan SoftwareUpgradePlan) String() string {
+	return fmt.Sprintf("SoftwareUpgradePlan{%s, %d, %s}", plan.Name, plan.Height, plan.Info)
+}
+
+// checkUpgradePlan checks the plan in the description of the software upgrade proposal submitted at the block
+func (keeper Keeper) checkUpgradePlan(ctx sdk.Context, description string) (SoftwareUpgradePlan, error) {
+	var plan SoftwareUpgradePlan
+	if err := json.Unmarshal([]byte(description), &plan); err != nil {
+		return plan, fmt.Errorf("unmarshal software upgrade plan error, err=%s", err.Error())
+	}
+	if err := plan.ValidateBasic(); err != nil {
+		return plan, err
+	}
+	if plan.Height <= ctx.BlockHeight() {
+		return plan, fmt.Errorf("upgrade height %d should be greater than the current height %d", plan.Height, ctx.BlockHeight())
+	}
+	// the upgrades scheduled by the passed proposals can't be rescheduled. Only the persisted plans are checked, as
+	// the upgrade heights in the config differ among the nodes.
+	if scheduled, found := keeper.GetUpgradePlan(ctx, plan.Name); found {
+		return plan, fmt.Errorf("upgrade %s has been scheduled at height %d", plan.Name, scheduled.Height)
+	}
+	return plan, nil
+}
+
+func (keeper Keeper) GetUpgradePlan(ctx sdk.Context, name string) (plan SoftwareUpgradePlan, found bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeyUpgradePlan(name))
+	if bz == nil {
+		return plan, false
+	}
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &plan)
+	return plan, true
+}
+
+func (keeper Keeper) SetUpgradePlan(ctx sdk.Context, plan SoftwareUpgradePlan) {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Set(KeyUpgradePlan(plan.Name), keeper.cdc.MustMarshalBinaryLengthPrefixed(plan))
+}
+
+// GetUpgradePlans returns the plans of all the passed software upgrade proposals
+func (keeper Keeper) GetUpgradePlans(ctx sdk.Context) (plans []SoftwareUpgradePlan) {
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, KeyUpgradePlansSubspace)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var plan SoftwareUpgradePlan
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &plan)
+		plans = append(plans, plan)
+	}
+	return plans
+}
+
+// LoadUpgradePlans schedules the persisted upgrade plans into sdk.UpgradeMgr, it must be called when the node starts.
+// The node halts if an upgrade which is not known by the binary has been activated.
+func (keeper Keeper) LoadUpgradePlans(ctx sdk.Context) {
+	logger := ctx.Logger().With("module", "x/gov")
+	for _, plan := range keeper.GetUpgradePlans(ctx) {
+		if !sdk.IsKnownUpgrade(plan.Name) && plan.Height <= ctx.BlockHeight() {
+			panic(upgradeNeededMsg(plan))
+		}
+		scheduleUpgrade(logger, plan)
+	}
+}
+
+// applyUpgradePlan persists and schedules the plan of the passed software upgrade proposal
+func (keeper Keeper) applyUpgradePlan(ctx sdk.Context, proposal Proposal) {
+	logger := ctx.Logger().With("module", "x/gov")
+	plan, err := keeper.checkUpgradePlan(ctx, proposal.GetDescription())
+	if err != nil {
+		logger.Error("skip the software upgrade proposal", "proposalId", proposal.GetProposalID(), "err", err.Error())
+		return
+	}
+	keeper.SetUpgradePlan(ctx, plan)
+	scheduleUpgrade(logger, plan)
+}
+
+// scheduleUpgrade adds the height of the plan into sdk.UpgradeMgr, the node halts at the height if the upgrade is
+// not known by the binary
+func scheduleUpgrade(logger log.Logger, plan SoftwareUpgradePlan) {
+	// the plan is agreed by the chain, so it overrides the height in the config of the node
+	if height := sdk.UpgradeMgr.GetUpgradeHeight(plan.Name); height != 0 && height != plan.Height {
+		logger.Error("upgrade height in the config is overridden by the plan", "configHeight", height, "plan", plan.String())
+	}
+	sdk.UpgradeMgr.AddUpgradeHeight(plan.Name, plan.Height)
+	if !sdk.IsKnownUpgrade(plan.Name) {
+		logger.Error("upgrade is not known by the binary, the node will halt at the height", "plan", plan.String())
+		sdk.UpgradeMgr.RegisterBeginBlocker(plan.Name, func(ctx sdk.Context) {
+			panic(upgradeNeededMsg(plan))
+		})
+		return
+	}
+	logger.Info("upgrade scheduled", "plan", plan.String())
+}
+
+func upgradeNeededMsg(plan SoftwareUpgradePlan) string {
+	return fmt.Sprintf("UPGRADE %q NEEDED at height %d: %s", plan.Name, plan.Height, plan.Info)
+}
@@ -0,0 +1,89 @@
+package gov_test
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestSoftwareUpgradeProposal(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GovUpgradePlan, 1)
+	defer sdk.UpgradeMgr.Reset()
+
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	_, feeAccount := mock.GeneratePrivKeyAddressPairs(1)
+	validator0 := stake.NewValidatorWithFeeAddr(feeAccount[0], sdk.ValAddress(addrs[0]), pubKeys[0], stake.Description{})
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	sdk.UpgradeMgr.SetHeight(10)
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()}).WithBlockHeight(10)
+
+	// create and delegate validator
+	stakeKeeper.SetValidator(ctx, validator0)
+	stakeKeeper.SetValidatorByConsAddr(ctx, validator0)
+	stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator0, true)
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	govHandler := gov.NewHandler(keeper)
+	votingPeriod := 1000 * time.Second
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}
+
+	// the description must be a valid plan after the current height
+	for _, description := range []string{"upgrade", `{"name":"","height":100}`, `{"name":"BEP9","height":10}`} {
+		res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", description, gov.ProposalTypeSoftwareUpgrade, addrs[0], deposit, votingPeriod))
+		require.Equal(t, sdk.ToABCICode(gov.DefaultCodespace, gov.CodeInvalidProposal), res.Code, res.Log)
+	}
+
+	unknownPlan := gov.SoftwareUpgradePlan{Name: "UnknownUpgrade", Height: 100, Info: "v1.0.0"}
+	plans := []gov.SoftwareUpgradePlan{{Name: sdk.MultiMsgTx, Height: 100, Info: "v0.9.0"}, unknownPlan}
+	for _, plan := range plans {
+		bz, err := json.Marshal(plan)
+		require.NoError(t, err)
+		res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", string(bz), gov.ProposalTypeSoftwareUpgrade, addrs[0], deposit, votingPeriod))
+		require.True(t, res.IsOK(), res.Log)
+
+		proposalID, _ := strconv.Atoi(string(res.Data))
+		res = govHandler(ctx, gov.NewMsgVote(addrs[0], int64(proposalID), gov.OptionYes))
+		require.True(t, res.IsOK(), res.Log)
+	}
+
+	// pass voting period
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(votingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+	gov.EndBlocker(ctx, keeper)
+
+	require.ElementsMatch(t, plans, keeper.GetUpgradePlans(ctx))
+	require.Equal(t, int64(100), sdk.UpgradeMgr.GetUpgradeHeight(sdk.MultiMsgTx))
+	require.Equal(t, int64(100), sdk.UpgradeMgr.GetUpgradeHeight(unknownPlan.Name))
+
+	// the upgrades scheduled by the passed proposals can't be rescheduled
+	bz, err := json.Marshal(gov.SoftwareUpgradePlan{Name: sdk.MultiMsgTx, Height: 200})
+	require.NoError(t, err)
+	res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", string(bz), gov.ProposalTypeSoftwareUpgrade, addrs[0], deposit, votingPeriod))
+	require.Equal(t, sdk.ToABCICode(gov.DefaultCodespace, gov.CodeInvalidProposal), res.Code, res.Log)
+
+	// the node halts at the height of the unknown upgrade
+	sdk.UpgradeMgr.SetHeight(100)
+	require.Panics(t, func() {
+		sdk.UpgradeMgr.BeginBlocker(ctx.WithBlockHeight(100))
+	})
+
+	// the plans are scheduled again when the node starts, and the node halts if the unknown upgrade is activated
+	sdk.UpgradeMgr.Reset()
+	keeper.LoadUpgradePlans(ctx.WithBlockHeight(99))
+	require.Equal(t, int64(100), sdk.UpgradeMgr.GetUpgradeHeight(sdk.MultiMsgTx))
+	require.Panics(t, func() {
+		keeper.LoadUpgradePlans(ctx.WithBlockHeight(100))
+	})
+}
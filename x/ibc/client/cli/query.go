@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+)
+
+const (
+	flagSideChainId = "side-chain-id"
+	flagChannelId   = "channel-id"
+	flagSequence    = "sequence"
+)
+
+// GetQueryCmd returns the ibc query commands
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	ibcQueryCmd := &cobra.Command{
+		Use:   "ibc",
+		Short: "Querying commands for the ibc module",
+	}
+	ibcQueryCmd.AddCommand(client.GetCommands(
+		GetCmdQueryOutstandingPackages(queryRoute, cdc),
+		GetCmdQueryPackage(queryRoute, cdc),
+	)...)
+	return ibcQueryCmd
+}
+
+// GetCmdQueryOutstandingPackages implements the query outstanding packages command.
+func GetCmdQueryOutstandingPackages(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "outstanding",
+		Short: "Query the syn packages of a channel awaiting an ack",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			channelId, err := sdk.ParseChannelID(viper.GetString(flagChannelId))
+			if err != nil {
+				return err
+			}
+			params := ibc.QueryOutstandingPackagesParams{
+				SideChainId: viper.GetString(flagSideChainId),
+				ChannelId:   channelId,
+			}
+			return queryAndPrint(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, ibc.QueryOutstandingPackages), params)
+		},
+	}
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	cmd.Flags().String(flagChannelId, "", "the id of channel")
+	return cmd
+}
+
+// GetCmdQueryPackage implements the query package command, the proof of the package is verified against
+// the app hash of the certified header unless the node is trusted.
+func GetCmdQueryPackage(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "package",
+		Short: "Query an outgoing package with its merkle proof",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			channelId, err := sdk.ParseChannelID(viper.GetString(flagChannelId))
+			if err != nil {
+				return err
+			}
+			params := ibc.QueryPackageParams{
+				SideChainId: viper.GetString(flagSideChainId),
+				ChannelId:   channelId,
+				Sequence:    uint64(viper.GetInt64(flagSequence)),
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, ibc.QueryPackage), bz)
+			if err != nil {
+				return err
+			}
+
+			var pack ibc.PackageWithProof
+			if err := cdc.UnmarshalJSON(res, &pack); err != nil {
+				return err
+			}
+			if !cliCtx.TrustNode {
+				// the AppHash for height H is in header H+1
+				commit, err := cliCtx.Verify(pack.Height + 1)
+				if err != nil {
+					return err
+				}
+				if err := pack.Verify(commit.Header.AppHash); err != nil {
+					return fmt.Errorf("failed to verify the proof of package: %v", err)
+				}
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	cmd.Flags().String(flagChannelId, "", "the id of channel")
+	cmd.Flags().Int64(flagSequence, 0, "the sequence of package")
+	return cmd
+}
+
+func queryAndPrint(cdc *codec.Codec, path string, params interface{}) error {
+	cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return err
+	}
+
+	res, err := cliCtx.QueryWithData(path, bz)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(res))
+	return nil
+}
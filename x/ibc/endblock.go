@@ -5,6 +5,11 @@ import (
 )
 
 func EndBlocker(ctx sdk.Context, keeper Keeper) {
+	// the fail ack of a timed out package may write new packages
+	if sdk.IsUpgrade(sdk.IBCPackageTimeout) {
+		ctx.EventManager().EmitEvents(keeper.TimeoutPackages(ctx))
+	}
+
 	if len(keeper.packageCollector.collectedPackages) == 0 {
 		return
 	}
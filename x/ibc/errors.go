@@ -12,6 +12,8 @@ const (
 	CodeFeeParamMismatch      sdk.CodeType = 102
 	CodeInvalidChainId        sdk.CodeType = 103
 	CodeWritePackageForbidden sdk.CodeType = 104
+	CodeInvalidChannelId      sdk.CodeType = 105
+	CodePackageNotFound       sdk.CodeType = 106
 )
 
 func ErrDuplicatedSequence(codespace sdk.CodespaceType, msg string) sdk.Error {
@@ -29,3 +31,11 @@ func ErrInvalidChainId(codespace sdk.CodespaceType, msg string) sdk.Error {
 func ErrWritePackageForbidden(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeWritePackageForbidden, msg)
 }
+
+func ErrInvalidChannelId(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidChannelId, msg)
+}
+
+func ErrPackageNotFound(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodePackageNotFound, msg)
+}
@@ -21,8 +21,13 @@ func createTestInput(t *testing.T, isCheckTx bool) (sdk.Context, Keeper) {
 	keySideChain := sdk.NewKVStoreKey("sc")
 	db := dbm.NewMemDB()
 	ms := store.NewCommitMultiStore(db)
-	ms.MountStoreWithDB(keyIBC, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keySideChain, sdk.StoreTypeIAVL, db)
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
+	// every IAVL store has its own db, the trees would overwrite the nodes of each other otherwise
+	ms.MountStoreWithDB(keyIBC, sdk.StoreTypeIAVL, dbm.NewPrefixDB(db, []byte(keyIBC.Name())))
+	ms.MountStoreWithDB(keySideChain, sdk.StoreTypeIAVL, dbm.NewPrefixDB(db, []byte(keySideChain.Name())))
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, dbm.NewPrefixDB(db, []byte(keyParams.Name())))
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
 	err := ms.LoadLatestVersion()
 	require.Nil(t, err)
 
@@ -30,8 +35,6 @@ func createTestInput(t *testing.T, isCheckTx bool) (sdk.Context, Keeper) {
 	if isCheckTx {
 		mode = sdk.RunTxModeCheck
 	}
-	keyParams := sdk.NewKVStoreKey("params")
-	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 
 	cdc := createTestCodec()
 	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
@@ -12,12 +12,15 @@ const (
 	destChainIDLength     = 2
 	channelIDLength       = 1
 	sequenceLength        = 8
+	timeLength            = 8
 	totalPackageKeyLength = prefixLength + srcChainIdLength + destChainIDLength + channelIDLength + sequenceLength
 )
 
 var (
-	PrefixForIbcPackageKey = []byte{0x00}
-	PrefixForSequenceKey   = []byte{0x01}
+	PrefixForIbcPackageKey         = []byte{0x00}
+	PrefixForSequenceKey           = []byte{0x01}
+	PrefixForOutstandingPackageKey = []byte{0x02} // syn packages awaiting an ack, by dest chain, channel and sequence
+	PrefixForPackageTimeoutKey     = []byte{0x03} // syn packages awaiting an ack, by dest chain and send time
 )
 
 func buildIBCPackageKey(srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
@@ -41,4 +44,47 @@ func buildIBCPackageKeyPrefix(srcChainID, destChainID sdk.ChainID, channelID sdk
 	copy(key[prefixLength+srcChainIdLength+destChainIDLength:], []byte{byte(channelID)})
 
 	return key
-}
\ No newline at end of file
+}
+func buildOutstandingPackageKey(destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
+	key := make([]byte, prefixLength+destChainIDLength+channelIDLength+sequenceLength)
+
+	copy(key[:prefixLength], PrefixForOutstandingPackageKey)
+	binary.BigEndian.PutUint16(key[prefixLength:prefixLength+destChainIDLength], uint16(destChainID))
+	copy(key[prefixLength+destChainIDLength:], []byte{byte(channelID)})
+	binary.BigEndian.PutUint64(key[prefixLength+destChainIDLength+channelIDLength:], sequence)
+
+	return key
+}
+
+func buildOutstandingPackagesPrefixKey(destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	return buildOutstandingPackageKey(destChainID, channelID, 0)[:prefixLength+destChainIDLength+channelIDLength]
+}
+
+func buildPackageTimeoutPrefixKey(destChainID sdk.ChainID) []byte {
+	key := make([]byte, prefixLength+destChainIDLength)
+
+	copy(key[:prefixLength], PrefixForPackageTimeoutKey)
+	binary.BigEndian.PutUint16(key[prefixLength:], uint16(destChainID))
+
+	return key
+}
+
+// buildPackageTimeoutTimeKey returns the prefix of the packages sent to the dest chain at the time
+func buildPackageTimeoutTimeKey(destChainID sdk.ChainID, sendTime int64) []byte {
+	key := make([]byte, prefixLength+destChainIDLength+timeLength)
+
+	copy(key, buildPackageTimeoutPrefixKey(destChainID))
+	binary.BigEndian.PutUint64(key[prefixLength+destChainIDLength:], uint64(sendTime))
+
+	return key
+}
+
+func buildPackageTimeoutKey(destChainID sdk.ChainID, sendTime int64, channelID sdk.ChannelID, sequence uint64) []byte {
+	key := make([]byte, prefixLength+destChainIDLength+timeLength+channelIDLength+sequenceLength)
+
+	copy(key, buildPackageTimeoutTimeKey(destChainID, sendTime))
+	copy(key[prefixLength+destChainIDLength+timeLength:], []byte{byte(channelID)})
+	binary.BigEndian.PutUint64(key[prefixLength+destChainIDLength+timeLength+channelIDLength:], sequence)
+
+	return key
+}
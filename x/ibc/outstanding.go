@@ -0,0 +1,235 @@
+package ibc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+const outstandingPackageValueLength = 8 + timeLength + 1 // send height, send time and timed out flag
+
+// OutstandingPackage is a syn package which is not acknowledged by the dest chain yet. The dest chain
+// acknowledges the syn packages of a channel in order, so the ack of a channel always resolves the package
+// with the lowest sequence.
+type OutstandingPackage struct {
+	DestChainID sdk.ChainID   `json:"dest_chain_id"`
+	ChannelID   sdk.ChannelID `json:"channel_id"`
+	Sequence    uint64        `json:"sequence"`
+	SendHeight  int64         `json:"send_height"`
+	SendTime    time.Time     `json:"send_time"`
+	// the package is failed by timeout, its ack is still expected to keep the order of the channel
+	TimedOut bool `json:"timed_out"`
+}
+
+func encodeOutstandingPackage(pack OutstandingPackage) []byte {
+	bz := make([]byte, outstandingPackageValueLength)
+	binary.BigEndian.PutUint64(bz[:8], uint64(pack.SendHeight))
+	binary.BigEndian.PutUint64(bz[8:8+timeLength], uint64(pack.SendTime.UnixNano()))
+	if pack.TimedOut {
+		bz[8+timeLength] = 1
+	}
+	return bz
+}
+
+func decodeOutstandingPackage(key, bz []byte) OutstandingPackage {
+	return OutstandingPackage{
+		DestChainID: sdk.ChainID(binary.BigEndian.Uint16(key[prefixLength : prefixLength+destChainIDLength])),
+		ChannelID:   sdk.ChannelID(key[prefixLength+destChainIDLength]),
+		Sequence:    binary.BigEndian.Uint64(key[prefixLength+destChainIDLength+channelIDLength:]),
+		SendHeight:  int64(binary.BigEndian.Uint64(bz[:8])),
+		SendTime:    time.Unix(0, int64(binary.BigEndian.Uint64(bz[8:8+timeLength]))).UTC(),
+		TimedOut:    bz[8+timeLength] == 1,
+	}
+}
+
+func (k *Keeper) addOutstandingPackage(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) {
+	pack := OutstandingPackage{
+		DestChainID: destChainID,
+		ChannelID:   channelID,
+		Sequence:    sequence,
+		SendHeight:  ctx.BlockHeight(),
+		SendTime:    ctx.BlockHeader().Time,
+	}
+	kvStore := ctx.KVStore(k.storeKey)
+	kvStore.Set(buildOutstandingPackageKey(destChainID, channelID, sequence), encodeOutstandingPackage(pack))
+	kvStore.Set(buildPackageTimeoutKey(destChainID, pack.SendTime.UnixNano(), channelID, sequence), []byte{1})
+}
+
+// backfillOutstandingPackages indexes the syn packages sent before the upgrade which are still kept, i.e. not
+// cleaned up as confirmed by the dest chain, so that the acks of the channel keep resolving the packages in
+// order. They are indexed with the upgrade block and never time out, as they were sent without a timeout.
+func (k *Keeper) backfillOutstandingPackages(ctx sdk.Context) {
+	kvStore := ctx.KVStore(k.storeKey)
+	sideChainIds, _ := k.sideKeeper.GetAllSideChainPrefixes(ctx)
+	for _, sideChainId := range sideChainIds {
+		destChainID, err := k.sideKeeper.GetDestChainID(sideChainId)
+		if err != nil {
+			continue
+		}
+		prefix := buildIBCPackageKeyPrefix(k.sideKeeper.GetSrcChainID(), destChainID, 0)[:prefixLength+srcChainIdLength+destChainIDLength]
+		iterator := sdk.KVStorePrefixIterator(kvStore, prefix)
+		for ; iterator.Valid(); iterator.Next() {
+			key := iterator.Key()
+			if len(key) != totalPackageKeyLength {
+				continue
+			}
+			packageType, _, err := sTypes.DecodePackageHeader(iterator.Value())
+			if err != nil || packageType != sdk.SynCrossChainPackageType {
+				continue
+			}
+			pack := OutstandingPackage{
+				DestChainID: destChainID,
+				ChannelID:   sdk.ChannelID(key[prefixLength+srcChainIdLength+destChainIDLength]),
+				Sequence:    binary.BigEndian.Uint64(key[totalPackageKeyLength-sequenceLength:]),
+				SendHeight:  ctx.BlockHeight(),
+				SendTime:    ctx.BlockHeader().Time,
+			}
+			kvStore.Set(buildOutstandingPackageKey(pack.DestChainID, pack.ChannelID, pack.Sequence), encodeOutstandingPackage(pack))
+		}
+		iterator.Close()
+	}
+}
+
+// GetOutstandingPackages returns the syn packages of a channel awaiting an ack, sorted by sequence
+func (k *Keeper) GetOutstandingPackages(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) []OutstandingPackage {
+	kvStore := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(kvStore, buildOutstandingPackagesPrefixKey(destChainID, channelID))
+	defer iterator.Close()
+
+	packages := make([]OutstandingPackage, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		packages = append(packages, decodeOutstandingPackage(iterator.Key(), iterator.Value()))
+	}
+	return packages
+}
+
+// ResolveOutstandingPackage removes the package acknowledged by an ack or fail ack package received on the
+// channel. A timed out package is returned as well, its application has already executed the fail ack.
+func (k *Keeper) ResolveOutstandingPackage(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) (OutstandingPackage, bool) {
+	kvStore := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(kvStore, buildOutstandingPackagesPrefixKey(destChainID, channelID))
+	if !iterator.Valid() {
+		iterator.Close()
+		return OutstandingPackage{}, false
+	}
+	pack := decodeOutstandingPackage(iterator.Key(), iterator.Value())
+	iterator.Close()
+
+	kvStore.Delete(buildOutstandingPackageKey(destChainID, channelID, pack.Sequence))
+	if !pack.TimedOut {
+		kvStore.Delete(buildPackageTimeoutKey(destChainID, pack.SendTime.UnixNano(), channelID, pack.Sequence))
+	}
+	return pack, true
+}
+
+// GetPackageTimeout returns the duration after which a syn package sent to the dest chain without ack is
+// failed, 0 means the packages never time out
+func (k *Keeper) GetPackageTimeout(ctx sdk.Context, destChainName string) time.Duration {
+	storePrefix := k.sideKeeper.GetSideChainStorePrefix(ctx, destChainName)
+	if storePrefix == nil {
+		return 0
+	}
+	var timeout int64
+	k.paramSpace.GetIfExists(ctx.WithSideChainKeyPrefix(storePrefix), ParamPackageTimeout, &timeout)
+	return time.Duration(timeout) * time.Second
+}
+
+// setDefaultPackageTimeouts sets the default package timeout of the side chains which do not have one yet
+func (k *Keeper) setDefaultPackageTimeouts(ctx sdk.Context) {
+	_, storePrefixes := k.sideKeeper.GetAllSideChainPrefixes(ctx)
+	for _, storePrefix := range storePrefixes {
+		sideCtx := ctx.WithSideChainKeyPrefix(storePrefix)
+		if !k.paramSpace.Has(sideCtx, ParamPackageTimeout) {
+			timeout := DefaultPackageTimeout
+			k.paramSpace.Set(sideCtx, ParamPackageTimeout, &timeout)
+		}
+	}
+}
+
+// TimeoutPackages fails the syn packages which are not acknowledged within the timeout of their dest chain,
+// the fail ack of the originating application is executed as if the dest chain had sent it
+func (k *Keeper) TimeoutPackages(ctx sdk.Context) sdk.Events {
+	events := sdk.Events{}
+	sideChainIds, _ := k.sideKeeper.GetAllSideChainPrefixes(ctx)
+	for _, sideChainId := range sideChainIds {
+		destChainID, err := k.sideKeeper.GetDestChainID(sideChainId)
+		if err != nil {
+			continue
+		}
+		timeout := k.GetPackageTimeout(ctx, sideChainId)
+		if timeout <= 0 {
+			continue
+		}
+		for _, pack := range k.getTimedOutPackages(ctx, destChainID, ctx.BlockHeader().Time.Add(-timeout)) {
+			events = append(events, k.timeoutPackage(ctx, pack))
+		}
+	}
+	return events
+}
+
+// getTimedOutPackages returns the outstanding packages sent to the dest chain no later than the deadline
+func (k *Keeper) getTimedOutPackages(ctx sdk.Context, destChainID sdk.ChainID, deadline time.Time) []OutstandingPackage {
+	kvStore := ctx.KVStore(k.storeKey)
+	iterator := kvStore.Iterator(buildPackageTimeoutPrefixKey(destChainID), buildPackageTimeoutTimeKey(destChainID, deadline.UnixNano()+1))
+	defer iterator.Close()
+
+	packages := make([]OutstandingPackage, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		channelID := sdk.ChannelID(key[prefixLength+destChainIDLength+timeLength])
+		sequence := binary.BigEndian.Uint64(key[prefixLength+destChainIDLength+timeLength+channelIDLength:])
+		outstandingKey := buildOutstandingPackageKey(destChainID, channelID, sequence)
+		packages = append(packages, decodeOutstandingPackage(outstandingKey, kvStore.Get(outstandingKey)))
+	}
+	return packages
+}
+
+func (k *Keeper) timeoutPackage(ctx sdk.Context, pack OutstandingPackage) sdk.Event {
+	kvStore := ctx.KVStore(k.storeKey)
+	kvStore.Delete(buildPackageTimeoutKey(pack.DestChainID, pack.SendTime.UnixNano(), pack.ChannelID, pack.Sequence))
+	pack.TimedOut = true
+	kvStore.Set(buildOutstandingPackageKey(pack.DestChainID, pack.ChannelID, pack.Sequence), encodeOutstandingPackage(pack))
+
+	result := k.executeTimeoutFailAck(ctx, pack)
+	if !result.IsOk() {
+		ctx.Logger().With("module", "ibc").Error("failed to execute fail ack of timed out package",
+			"destChainID", pack.DestChainID, "channelID", pack.ChannelID, "sequence", pack.Sequence, "err", result.Err)
+	}
+	code := sdk.CodeOK
+	if result.Err != nil {
+		code = result.Err.Code()
+	}
+	return sdk.NewEvent(ibcPackageTimeoutEventType,
+		sdk.NewAttribute(ibcPackageInfoAttributeKey, buildIBCPackageAttributeValue(pack.DestChainID, pack.ChannelID, pack.Sequence)),
+		sdk.NewAttribute(ibcPackageTimeoutResultAttribute, fmt.Sprintf("%d", code)),
+	)
+}
+
+func (k *Keeper) executeTimeoutFailAck(ctx sdk.Context, pack OutstandingPackage) (result sdk.ExecuteResult) {
+	payload, _ := k.GetIBCPackageById(ctx, pack.DestChainID, pack.ChannelID, pack.Sequence)
+	if len(payload) < sTypes.PackageHeaderLength {
+		return sdk.ExecuteResult{Err: sdk.ErrInternal("the payload of the timed out package is missing")}
+	}
+	app := k.sideKeeper.GetCrossChainApp(ctx, pack.ChannelID)
+	if app == nil {
+		return sdk.ExecuteResult{Err: ErrInvalidChannelId(DefaultCodespace, fmt.Sprintf("channel %d is not registered", pack.ChannelID))}
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	defer func() {
+		if r := recover(); r != nil {
+			ctx.Logger().With("module", "ibc").Error("execute fail ack of timed out package panic",
+				"err_log", fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack())))
+			result = sdk.ExecuteResult{Err: sdk.ErrInternal(fmt.Sprintf("execute fail ack failed: %v", r))}
+		}
+	}()
+	result = app.ExecuteFailAckPackage(cacheCtx, payload[sTypes.PackageHeaderLength:])
+	if result.IsOk() {
+		write()
+	}
+	return result
+}
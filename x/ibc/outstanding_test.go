@@ -0,0 +1,179 @@
+package ibc
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type failAckRecorder struct {
+	failAcks [][]byte
+}
+
+func (app *failAckRecorder) ExecuteSynPackage(ctx sdk.Context, payload []byte, relayerFee int64) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (app *failAckRecorder) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (app *failAckRecorder) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	app.failAcks = append(app.failAcks, payload)
+	return sdk.ExecuteResult{}
+}
+
+func TestOutstandingPackages(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCPackageTimeout, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer sdk.UpgradeMgr.Reset()
+
+	destChainName := "axc"
+	destChainID := sdk.ChainID(0x000f)
+	channelID := sdk.ChannelID(0x01)
+	sendTime := time.Unix(1600000000, 0).UTC()
+
+	ctx, keeper := createTestInput(t, false)
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "foochainid", Height: 1, Time: sendTime}).WithBlockHeight(1).
+		WithAccountCache(&sdk.DummyAccountCache{})
+	app := &failAckRecorder{}
+	keeper.sideKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	keeper.sideKeeper.SetSideChainIdAndStorePrefix(ctx, destChainName, []byte{0x99})
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel("transfer", channelID, app))
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	keeper.SetParams(ctx.WithSideChainKeyPrefix([]byte{0x99}), Params{RelayerFee: 1, PackageTimeout: 10})
+	require.Equal(t, 10*time.Second, keeper.GetPackageTimeout(ctx, destChainName))
+
+	for i := byte(0); i < 3; i++ {
+		_, err := keeper.CreateRawIBCPackageByIdWithFee(ctx, destChainID, channelID, sdk.SynCrossChainPackageType, []byte{i}, *big.NewInt(1))
+		require.NoError(t, err)
+	}
+	// only syn packages await an ack
+	_, err := keeper.CreateRawIBCPackageByIdWithFee(ctx, destChainID, channelID, sdk.AckCrossChainPackageType, []byte{0xff}, *big.NewInt(0))
+	require.NoError(t, err)
+
+	packages := keeper.GetOutstandingPackages(ctx, destChainID, channelID)
+	require.Len(t, packages, 3)
+	require.Equal(t, OutstandingPackage{
+		DestChainID: destChainID, ChannelID: channelID, Sequence: 0, SendHeight: 1, SendTime: sendTime,
+	}, packages[0])
+
+	pack, found := keeper.ResolveOutstandingPackage(ctx, destChainID, channelID)
+	require.True(t, found)
+	require.Equal(t, uint64(0), pack.Sequence)
+	require.False(t, pack.TimedOut)
+
+	require.Empty(t, keeper.TimeoutPackages(ctx.WithBlockHeader(abci.Header{Time: sendTime.Add(9 * time.Second)})))
+	require.Empty(t, app.failAcks)
+
+	events := keeper.TimeoutPackages(ctx.WithBlockHeader(abci.Header{Time: sendTime.Add(10 * time.Second)}))
+	require.Len(t, events, 2)
+	require.Equal(t, ibcPackageTimeoutEventType, events[0].Type)
+	require.Equal(t, buildIBCPackageAttributeValue(destChainID, channelID, 1), string(events[0].Attributes[0].Value))
+	require.Equal(t, [][]byte{{1}, {2}}, app.failAcks)
+	for _, pack := range keeper.GetOutstandingPackages(ctx, destChainID, channelID) {
+		require.True(t, pack.TimedOut)
+	}
+
+	// the packages time out only once, their late acks still resolve them in order
+	require.Empty(t, keeper.TimeoutPackages(ctx.WithBlockHeader(abci.Header{Time: sendTime.Add(time.Hour)})))
+	pack, found = keeper.ResolveOutstandingPackage(ctx, destChainID, channelID)
+	require.True(t, found)
+	require.Equal(t, uint64(1), pack.Sequence)
+	require.True(t, pack.TimedOut)
+	pack, found = keeper.ResolveOutstandingPackage(ctx, destChainID, channelID)
+	require.True(t, found)
+	require.Equal(t, uint64(2), pack.Sequence)
+	_, found = keeper.ResolveOutstandingPackage(ctx, destChainID, channelID)
+	require.False(t, found)
+	require.Len(t, app.failAcks, 2)
+}
+
+func TestSetDefaultPackageTimeouts(t *testing.T) {
+	ctx, keeper := createTestInput(t, false)
+	keeper.sideKeeper.SetSideChainIdAndStorePrefix(ctx, "axc", []byte{0x99})
+	keeper.sideKeeper.SetSideChainIdAndStorePrefix(ctx, "other", []byte{0x98})
+	sideCtx := ctx.WithSideChainKeyPrefix([]byte{0x99})
+	otherCtx := ctx.WithSideChainKeyPrefix([]byte{0x98})
+
+	// the params of a live side chain only have the relayer fee before the upgrade
+	relayerFee := DefaultRelayerFeeParam
+	keeper.paramSpace.Set(sideCtx, ParamRelayerFee, &relayerFee)
+	keeper.paramSpace.Set(otherCtx, ParamRelayerFee, &relayerFee)
+	timeout := int64(10)
+	keeper.paramSpace.Set(otherCtx, ParamPackageTimeout, &timeout)
+	var params Params
+	require.Panics(t, func() { keeper.paramSpace.GetParamSet(sideCtx, &params) })
+
+	keeper.setDefaultPackageTimeouts(ctx)
+	keeper.paramSpace.GetParamSet(sideCtx, &params)
+	require.Equal(t, Params{RelayerFee: relayerFee, PackageTimeout: DefaultPackageTimeout}, params)
+
+	// the timeout already set is kept
+	require.Equal(t, 10*time.Second, keeper.GetPackageTimeout(ctx, "other"))
+}
+
+func TestBackfillOutstandingPackages(t *testing.T) {
+	destChainName := "axc"
+	destChainID := sdk.ChainID(0x000f)
+	channelID := sdk.ChannelID(0x01)
+	upgradeTime := time.Unix(1600000000, 0).UTC()
+
+	ctx, keeper := createTestInput(t, false)
+	ctx = ctx.WithAccountCache(&sdk.DummyAccountCache{})
+	app := &failAckRecorder{}
+	keeper.sideKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	keeper.sideKeeper.SetSideChainIdAndStorePrefix(ctx, destChainName, []byte{0x99})
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel("transfer", channelID, app))
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	keeper.SetParams(ctx.WithSideChainKeyPrefix([]byte{0x99}), Params{RelayerFee: 1, PackageTimeout: 10})
+
+	// the packages are sent before the upgrade, the first one is confirmed and cleaned up
+	for i := byte(0); i < 3; i++ {
+		_, err := keeper.CreateRawIBCPackageByIdWithFee(ctx, destChainID, channelID, sdk.SynCrossChainPackageType, []byte{i}, *big.NewInt(1))
+		require.NoError(t, err)
+	}
+	_, err := keeper.CreateRawIBCPackageByIdWithFee(ctx, destChainID, channelID, sdk.AckCrossChainPackageType, []byte{0xff}, *big.NewInt(0))
+	require.NoError(t, err)
+	keeper.CleanupIBCPackage(ctx, destChainName, "transfer", 0)
+	require.Empty(t, keeper.GetOutstandingPackages(ctx, destChainID, channelID))
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCPackageTimeout, 5)
+	sdk.UpgradeMgr.SetHeight(5)
+	defer sdk.UpgradeMgr.Reset()
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "foochainid", Height: 5, Time: upgradeTime}).WithBlockHeight(5)
+	keeper.backfillOutstandingPackages(ctx)
+
+	packages := keeper.GetOutstandingPackages(ctx, destChainID, channelID)
+	require.Len(t, packages, 2)
+	require.Equal(t, OutstandingPackage{
+		DestChainID: destChainID, ChannelID: channelID, Sequence: 1, SendHeight: 5, SendTime: upgradeTime,
+	}, packages[0])
+	require.Equal(t, uint64(2), packages[1].Sequence)
+
+	// a package sent after the upgrade times out, while the ones sent before do not
+	_, err = keeper.CreateRawIBCPackageByIdWithFee(ctx, destChainID, channelID, sdk.SynCrossChainPackageType, []byte{4}, *big.NewInt(1))
+	require.NoError(t, err)
+	events := keeper.TimeoutPackages(ctx.WithBlockHeader(abci.Header{Time: upgradeTime.Add(time.Hour)}))
+	require.Len(t, events, 1)
+	require.Equal(t, [][]byte{{4}}, app.failAcks)
+
+	// the acks of the packages sent before the upgrade resolve them rather than the timed out one
+	for _, sequence := range []uint64{1, 2} {
+		pack, found := keeper.ResolveOutstandingPackage(ctx, destChainID, channelID)
+		require.True(t, found)
+		require.Equal(t, sequence, pack.Sequence)
+		require.False(t, pack.TimedOut)
+	}
+	pack, found := keeper.ResolveOutstandingPackage(ctx, destChainID, channelID)
+	require.True(t, found)
+	require.Equal(t, uint64(4), pack.Sequence)
+	require.True(t, pack.TimedOut)
+}
@@ -8,21 +8,26 @@ import (
 
 const (
 	DefaultRelayerFeeParam int64 = 1e6 // decimal is 8
+	DefaultPackageTimeout  int64 = 0   // the syn packages never time out by default
 	// Default parameter namespace
 	DefaultParamspace = "ibc"
 )
 
 var (
-	ParamRelayerFee = []byte("relayerFee")
+	ParamRelayerFee     = []byte("relayerFee")
+	ParamPackageTimeout = []byte("packageTimeout")
 )
 
 type Params struct {
 	RelayerFee int64 `json:"relayer_fee"`
+	// seconds after which a syn package without ack is failed, 0 disables the timeout
+	PackageTimeout int64 `json:"package_timeout"`
 }
 
 func (p *Params) KeyValuePairs() params.KeyValuePairs {
 	return params.KeyValuePairs{
 		{ParamRelayerFee, &p.RelayerFee},
+		{ParamPackageTimeout, &p.PackageTimeout},
 	}
 }
 
@@ -30,6 +35,9 @@ func (p *Params) UpdateCheck() error {
 	if p.RelayerFee <= 0 {
 		return fmt.Errorf("the syn_package_fee should be greater than 0")
 	}
+	if p.PackageTimeout < 0 {
+		return fmt.Errorf("the package_timeout should not be negative")
+	}
 	return nil
 }
 
@@ -0,0 +1,13 @@
+package ibc
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func RegisterUpgradeBeginBlocker(keeper Keeper) {
+	// the param set of the side chains is read as a whole, so the new param should exist once the upgrade is activated
+	sdk.UpgradeMgr.RegisterBeginBlockerByName(sdk.IBCPackageTimeout, func(ctx sdk.Context) {
+		keeper.setDefaultPackageTimeouts(ctx)
+		keeper.backfillOutstandingPackages(ctx)
+	})
+}
@@ -0,0 +1,79 @@
+package ibc
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+// PackageWithProof is an outgoing package with the proof of its key in the ibc store against the app hash
+// of the height, which is committed in the header of the next block
+type PackageWithProof struct {
+	DestChainID sdk.ChainID               `json:"dest_chain_id"`
+	ChannelID   sdk.ChannelID             `json:"channel_id"`
+	Sequence    uint64                    `json:"sequence"`
+	Height      int64                     `json:"height"`
+	StoreName   string                    `json:"store_name"`
+	Key         []byte                    `json:"key"`
+	Package     []byte                    `json:"package"`
+	PackageType sdk.CrossChainPackageType `json:"package_type"`
+	RelayerFee  string                    `json:"relayer_fee"`
+	Payload     []byte                    `json:"payload"`
+	Proof       *merkle.Proof             `json:"proof"`
+}
+
+// Verify checks the proof of the package against the app hash committed in the header of the next block
+func (p PackageWithProof) Verify(appHash []byte) error {
+	if p.Proof == nil {
+		return fmt.Errorf("missing proof of package")
+	}
+	kp := merkle.KeyPath{}
+	kp = kp.AppendKey([]byte(p.StoreName), merkle.KeyEncodingURL)
+	kp = kp.AppendKey(p.Key, merkle.KeyEncodingURL)
+	return store.DefaultProofRuntime().VerifyValue(p.Proof, appHash, kp.String(), p.Package)
+}
+
+// GetIBCPackageWithProof queries an outgoing package with its proof from the committed multi store, the latest
+// committed height is used if the height is 0
+func (k *Keeper) GetIBCPackageWithProof(queryable sdk.Queryable, destChainID sdk.ChainID, channelID sdk.ChannelID,
+	sequence uint64, height int64) (PackageWithProof, sdk.Error) {
+
+	key := buildIBCPackageKey(k.sideKeeper.GetSrcChainID(), destChainID, channelID, sequence)
+	storeName := k.storeKey.Name()
+	resp := queryable.Query(abci.RequestQuery{
+		Path:   fmt.Sprintf("/%s/key", storeName),
+		Data:   key,
+		Height: height,
+		Prove:  true,
+	})
+	if !resp.IsOK() {
+		return PackageWithProof{}, sdk.ErrInternal(resp.Log)
+	}
+	if resp.Value == nil {
+		return PackageWithProof{}, ErrPackageNotFound(DefaultCodespace,
+			fmt.Sprintf("package %d of channel %d is not found at height %d %s", sequence, channelID, resp.Height, resp.Log))
+	}
+
+	packageType, relayerFee, err := sTypes.DecodePackageHeader(resp.Value)
+	if err != nil {
+		return PackageWithProof{}, sdk.ErrInternal(err.Error())
+	}
+	return PackageWithProof{
+		DestChainID: destChainID,
+		ChannelID:   channelID,
+		Sequence:    sequence,
+		Height:      resp.Height,
+		StoreName:   storeName,
+		Key:         key,
+		Package:     resp.Value,
+		PackageType: packageType,
+		RelayerFee:  relayerFee.String(),
+		Payload:     resp.Value[sTypes.PackageHeaderLength:],
+		Proof:       resp.Proof,
+	}, nil
+}
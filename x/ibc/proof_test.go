@@ -0,0 +1,56 @@
+package ibc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestGetIBCPackageWithProof(t *testing.T) {
+	destChainName := "axc"
+	destChainID := sdk.ChainID(0x000f)
+	channelID := sdk.ChannelID(0x01)
+
+	ctx, keeper := createTestInput(t, false)
+	keeper.sideKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel("transfer", channelID, nil))
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+
+	_, sdkErr := keeper.CreateRawIBCPackageByIdWithFee(ctx, destChainID, channelID, sdk.SynCrossChainPackageType, []byte{0x01, 0x02}, *big.NewInt(100))
+	require.Nil(t, sdkErr)
+
+	cms := ctx.MultiStore().(sdk.CommitMultiStore)
+	commitID := cms.Commit()
+
+	cdc := createTestCodec()
+	querier := NewQuerier(keeper, cdc, cms.(sdk.Queryable))
+	queryPackage := func(sequence uint64) ([]byte, sdk.Error) {
+		bz := cdc.MustMarshalJSON(QueryPackageParams{SideChainId: destChainName, ChannelId: channelID, Sequence: sequence})
+		return querier(ctx, []string{QueryPackage}, abci.RequestQuery{Data: bz, Height: commitID.Version})
+	}
+
+	res, sdkErr := queryPackage(0)
+	require.Nil(t, sdkErr)
+	var pack PackageWithProof
+	require.NoError(t, cdc.UnmarshalJSON(res, &pack))
+	require.Equal(t, commitID.Version, pack.Height)
+	require.Equal(t, sdk.SynCrossChainPackageType, pack.PackageType)
+	require.Equal(t, "100", pack.RelayerFee)
+	require.Equal(t, []byte{0x01, 0x02}, pack.Payload)
+	require.NoError(t, pack.Verify(commitID.Hash))
+
+	// a tampered package does not match the proof
+	tampered := pack
+	tampered.Package = append([]byte{}, pack.Package...)
+	tampered.Package[len(tampered.Package)-1] = 0xff
+	require.Error(t, tampered.Verify(commitID.Hash))
+
+	_, sdkErr = queryPackage(1)
+	require.NotNil(t, sdkErr)
+	require.Equal(t, CodePackageNotFound, sdkErr.Code())
+}
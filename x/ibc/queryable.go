@@ -0,0 +1,69 @@
+package ibc
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	QueryOutstandingPackages = "outstandingPackages"
+	QueryPackage             = "package"
+)
+
+// QueryOutstandingPackagesParams is the params of the outstanding packages query
+type QueryOutstandingPackagesParams struct {
+	SideChainId string
+	ChannelId   sdk.ChannelID
+}
+
+// QueryPackageParams is the params of the package query, the height of the request is the height at which
+// the package is proved
+type QueryPackageParams struct {
+	SideChainId string
+	ChannelId   sdk.ChannelID
+	Sequence    uint64
+}
+
+// NewQuerier creates a querier for ibc REST endpoints, the packages are proved against the committed multi store
+func NewQuerier(keeper Keeper, cdc *codec.Codec, queryable sdk.Queryable) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QueryOutstandingPackages:
+			var params QueryOutstandingPackagesParams
+			if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrInternal("can not unmarshal request")
+			}
+			destChainID, err := keeper.sideKeeper.GetDestChainID(params.SideChainId)
+			if err != nil {
+				return nil, ErrInvalidChainId(DefaultCodespace, err.Error())
+			}
+			return marshalQueryResult(cdc, keeper.GetOutstandingPackages(ctx, destChainID, params.ChannelId))
+		case QueryPackage:
+			var params QueryPackageParams
+			if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrInternal("can not unmarshal request")
+			}
+			destChainID, err := keeper.sideKeeper.GetDestChainID(params.SideChainId)
+			if err != nil {
+				return nil, ErrInvalidChainId(DefaultCodespace, err.Error())
+			}
+			pack, sdkErr := keeper.GetIBCPackageWithProof(queryable, destChainID, params.ChannelId, params.Sequence, req.Height)
+			if sdkErr != nil {
+				return nil, sdkErr
+			}
+			return marshalQueryResult(cdc, pack)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown ibc query endpoint")
+		}
+	}
+}
+
+func marshalQueryResult(cdc *codec.Codec, result interface{}) ([]byte, sdk.Error) {
+	res, err := cdc.MarshalJSON(result)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/axc"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/lightclient"
+)
+
+const (
+	flagChainId           = "chain-id-of-side-chain"
+	flagEpochLength       = "epoch-length"
+	flagTrustedHeaderFile = "trusted-header-file"
+	flagTitle             = "title"
+	flagDeposit           = "deposit"
+	flagVotingPeriod      = "voting-period"
+)
+
+// GetCmdSubmitCreateClientProposal implements the command to submit a proposal creating the light client of a
+// side chain from a trusted epoch header
+func GetCmdSubmitCreateClientProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit-create-client-proposal",
+		Short: "Submit a proposal to create the light client of a side chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+			title := viper.GetString(flagTitle)
+			initialDeposit := viper.GetString(flagDeposit)
+			votingPeriodInSeconds := viper.GetInt64(flagVotingPeriod)
+
+			filePath := viper.GetString(flagTrustedHeaderFile)
+			if filePath == "" {
+				return fmt.Errorf("%s is required", flagTrustedHeaderFile)
+			}
+			headerBytes, err := os.ReadFile(filePath)
+			if err != nil {
+				return err
+			}
+			var trusted axc.Header
+			if err := json.Unmarshal(headerBytes, &trusted); err != nil {
+				return err
+			}
+
+			creation := lightclient.ClientCreation{
+				SideChainId:   viper.GetString(flagSideChainId),
+				ChainId:       viper.GetInt64(flagChainId),
+				EpochLength:   viper.GetInt64(flagEpochLength),
+				TrustedHeader: trusted,
+			}
+			if err := creation.Check(); err != nil {
+				return err
+			}
+			fromAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoins(initialDeposit)
+			if err != nil {
+				return err
+			}
+			creationBz, err := cdc.MarshalJSON(creation)
+			if err != nil {
+				return err
+			}
+
+			if votingPeriodInSeconds <= 0 {
+				return errors.New("voting period should be positive")
+			}
+
+			votingPeriod := time.Duration(votingPeriodInSeconds) * time.Second
+			if votingPeriod > gov.MaxVotingPeriod {
+				return fmt.Errorf("voting period should less than %d seconds", gov.MaxVotingPeriod/time.Second)
+			}
+
+			msg := gov.NewMsgSubmitProposal(title, string(creationBz), gov.ProposalTypeCreateLightClient, fromAddr, amount, votingPeriod)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+			return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagSideChainId, "", "the id of the side chain")
+	cmd.Flags().Int64(flagChainId, 0, "the chain id of the side chain, used to compute the seal hash")
+	cmd.Flags().Int64(flagEpochLength, 0, "number of blocks between two validator set rotations of the side chain")
+	cmd.Flags().String(flagTrustedHeaderFile, "", "File of the trusted epoch header in json format")
+	cmd.Flags().String(flagTitle, "", "title of proposal")
+	cmd.Flags().Int64(flagVotingPeriod, 7*24*60*60, "voting period in seconds")
+	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
+	return cmd
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/axc"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/lightclient"
+)
+
+const (
+	flagHeadersFile = "headers-file"
+	flagSideChainId = "side-chain-id"
+)
+
+func AddCommands(root *cobra.Command, cdc *codec.Codec) {
+	lightClientCmd := &cobra.Command{
+		Use:   "lightclient",
+		Short: "side chain light client",
+	}
+
+	lightClientCmd.AddCommand(
+		client.PostCommands(
+			GetCmdSubmitSideChainHeaders(cdc),
+			GetCmdSubmitCreateClientProposal(cdc),
+		)...)
+
+	root.AddCommand(lightClientCmd)
+}
+
+// GetCmdSubmitSideChainHeaders implements the submit side chain headers command handler.
+func GetCmdSubmitSideChainHeaders(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit-headers",
+		Short: "submit contiguous headers of a side chain to its light client, the first one may fork from the canonical chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			sideChainId := viper.GetString(flagSideChainId)
+			if len(sideChainId) == 0 {
+				return fmt.Errorf("%s is required", flagSideChainId)
+			}
+
+			filePath := viper.GetString(flagHeadersFile)
+			if filePath == "" {
+				return fmt.Errorf("%s is required", flagHeadersFile)
+			}
+			headersBytes, err := os.ReadFile(filePath)
+			if err != nil {
+				return err
+			}
+
+			headers := make([]axc.Header, 0)
+			if err := json.Unmarshal(headersBytes, &headers); err != nil {
+				return err
+			}
+
+			msg := lightclient.NewMsgSubmitSideChainHeaders(from, sideChainId, headers)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagHeadersFile, "", "File of headers in json format, e.g. [{\"difficulty\":\"0x2\",\"extraData\":\"0xd98301...},{\"difficulty\":\"0x2\",\"extraData\":\"0xd64372...}]")
+	cmd.Flags().String(flagSideChainId, "", "chain-id of the side chain the headers belong to")
+	return cmd
+}
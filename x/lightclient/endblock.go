@@ -0,0 +1,53 @@
+package lightclient
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+)
+
+// EndBlocker creates the light clients of the passed CreateLightClient proposals
+func EndBlocker(ctx sdk.Context, k Keeper) {
+	if !sdk.IsUpgrade(sdk.LightClient) || k.govKeeper == nil {
+		return
+	}
+	creations := k.getLastClientCreations(ctx)
+	// should in reverse order
+	for j := len(creations) - 1; j >= 0; j-- {
+		creation := creations[j]
+		if err := k.InitClient(ctx, creation.SideChainId, creation.ChainId, creation.EpochLength, creation.TrustedHeader); err != nil {
+			ctx.Logger().With("module", "lightclient").Error("failed to create light client",
+				"sideChainId", creation.SideChainId, "err", err.Error())
+		}
+	}
+}
+
+func (k Keeper) getLastClientCreations(ctx sdk.Context) []ClientCreation {
+	creations := make([]ClientCreation, 0)
+	// It can still find the valid proposal if the block chain stop for SafeToleratePeriod time
+	backPeriod := sidechain.SafeToleratePeriod + gov.MaxVotingPeriod
+	k.govKeeper.Iterate(ctx, nil, nil, gov.StatusNil, 0, true, func(proposal gov.Proposal) bool {
+		if proposal.GetProposalType() == gov.ProposalTypeCreateLightClient {
+			if ctx.BlockHeader().Time.Sub(proposal.GetVotingStartTime()) > backPeriod {
+				return true
+			}
+			if proposal.GetStatus() != gov.StatusPassed {
+				return false
+			}
+
+			proposal.SetStatus(gov.StatusExecuted)
+			k.govKeeper.SetProposal(ctx, proposal)
+
+			var creation ClientCreation
+			err := k.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &creation)
+			if err != nil {
+				ctx.Logger().With("module", "lightclient").Error("Get broken data when unmarshal ClientCreation msg, will skip.",
+					"proposalId", proposal.GetProposalID(), "err", err)
+				return false
+			}
+			creations = append(creations, creation)
+		}
+		return false
+	})
+	return creations
+}
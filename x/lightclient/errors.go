@@ -0,0 +1,60 @@
+package lightclient
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultCodespace sdk.CodespaceType = 32
+
+	CodeInvalidInput        sdk.CodeType = 101
+	CodeInvalidSideChainId  sdk.CodeType = 102
+	CodeClientNotFound      sdk.CodeType = 103
+	CodeClientExists        sdk.CodeType = 104
+	CodeInvalidHeader       sdk.CodeType = 105
+	CodeNonContiguousHeader sdk.CodeType = 106
+	CodeUnauthorizedSigner  sdk.CodeType = 107
+	CodeHeaderNotFound      sdk.CodeType = 108
+	CodeRecentlySigned      sdk.CodeType = 109
+	CodeWrongDifficulty     sdk.CodeType = 110
+)
+
+func ErrInvalidInput(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, msg)
+}
+
+func ErrInvalidSideChainId(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidSideChainId, msg)
+}
+
+func ErrClientNotFound(codespace sdk.CodespaceType, sideChainId string) sdk.Error {
+	return sdk.NewError(codespace, CodeClientNotFound, "light client of side chain "+sideChainId+" is not initialized")
+}
+
+func ErrClientExists(codespace sdk.CodespaceType, sideChainId string) sdk.Error {
+	return sdk.NewError(codespace, CodeClientExists, "light client of side chain "+sideChainId+" is already initialized")
+}
+
+func ErrInvalidHeader(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidHeader, msg)
+}
+
+func ErrNonContiguousHeader(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeNonContiguousHeader, msg)
+}
+
+func ErrUnauthorizedSigner(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeUnauthorizedSigner, msg)
+}
+
+func ErrHeaderNotFound(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeHeaderNotFound, msg)
+}
+
+func ErrRecentlySigned(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeRecentlySigned, msg)
+}
+
+func ErrWrongDifficulty(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeWrongDifficulty, msg)
+}
@@ -0,0 +1,48 @@
+package lightclient
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all lightclient state that must be provided at genesis
+type GenesisState struct {
+	Params  Params           `json:"params"`
+	Clients []ClientCreation `json:"clients"`
+}
+
+func NewGenesisState(params Params, clients []ClientCreation) GenesisState {
+	return GenesisState{
+		Params:  params,
+		Clients: clients,
+	}
+}
+
+// DefaultGenesisState - default GenesisState
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params: DefaultParams(),
+	}
+}
+
+// ValidateGenesis checks the params and the trusted headers
+func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.UpdateCheck(); err != nil {
+		return err
+	}
+	for _, client := range data.Clients {
+		if err := client.Check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InitGenesis sets the params and initializes the light clients
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	keeper.SetParams(ctx, data.Params)
+	for _, client := range data.Clients {
+		if err := keeper.InitClient(ctx, client.SideChainId, client.ChainId, client.EpochLength, client.TrustedHeader); err != nil {
+			panic(err)
+		}
+	}
+}
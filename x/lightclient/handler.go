@@ -0,0 +1,42 @@
+package lightclient
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgSubmitSideChainHeaders:
+			return handleMsgSubmitSideChainHeaders(ctx, msg, k)
+		default:
+			errMsg := fmt.Sprintf("Unrecognized lightclient msg type: %T", msg)
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+func handleMsgSubmitSideChainHeaders(ctx sdk.Context, msg MsgSubmitSideChainHeaders, k Keeper) sdk.Result {
+	if !sdk.IsUpgrade(sdk.LightClient) {
+		return ErrInvalidInput(k.codespace, "light client is not enabled").Result()
+	}
+	if int64(len(msg.Headers)) > k.MaxHeadersPerMsg(ctx) {
+		return ErrInvalidInput(k.codespace, fmt.Sprintf("at most %d headers can be submitted in one msg", k.MaxHeadersPerMsg(ctx))).Result()
+	}
+
+	state, err := k.SubmitHeaders(ctx, msg.SideChainId, msg.Headers)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		"sideChainId", []byte(msg.SideChainId),
+		"latestNumber", []byte(strconv.FormatInt(state.LatestNumber, 10)),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
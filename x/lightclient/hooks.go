@@ -0,0 +1,43 @@
+package lightclient
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/tendermint/go-amino"
+)
+
+type CreateClientHooks struct {
+	cdc *amino.Codec
+	k   Keeper
+}
+
+func NewCreateClientHooks(cdc *amino.Codec, keeper Keeper) CreateClientHooks {
+	return CreateClientHooks{cdc, keeper}
+}
+
+var _ gov.GovHooks = CreateClientHooks{}
+
+func (hooks CreateClientHooks) OnProposalSubmitted(ctx sdk.Context, proposal gov.Proposal) error {
+	if proposal.GetProposalType() != gov.ProposalTypeCreateLightClient {
+		panic(fmt.Sprintf("received wrong type of proposal %x", proposal.GetProposalType()))
+	}
+	if !sdk.IsUpgrade(sdk.LightClient) {
+		return fmt.Errorf("light client is not enabled")
+	}
+
+	var creation ClientCreation
+	err := hooks.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &creation)
+	if err != nil {
+		return fmt.Errorf("get broken data when unmarshal ClientCreation msg, err %v", err)
+	}
+	if err := creation.Check(); err != nil {
+		return err
+	}
+	// the client may still be created by another proposal before this one passes
+	if _, found := hooks.k.GetClientState(ctx, creation.SideChainId); found {
+		return fmt.Errorf("light client of side chain %s already exists", creation.SideChainId)
+	}
+	return nil
+}
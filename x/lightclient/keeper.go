@@ -0,0 +1,385 @@
+package lightclient
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/axc"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+)
+
+// difficulties of the headers sealed by the in-turn and the out-of-turn validators in parlia
+const (
+	diffInTurn = 2
+	diffNoTurn = 1
+)
+
+// Keeper of the light client store, it keeps the verified side chain headers of a rolling window, including
+// the headers of the forks, and follows the fork of the highest total difficulty as the canonical chain
+type Keeper struct {
+	storeKey   sdk.StoreKey
+	cdc        *codec.Codec
+	paramspace params.Subspace
+	codespace  sdk.CodespaceType
+
+	ScKeeper  sidechain.Keeper
+	govKeeper *gov.Keeper
+}
+
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramspace params.Subspace, codespace sdk.CodespaceType, scKeeper sidechain.Keeper) Keeper {
+	return Keeper{
+		storeKey:   key,
+		cdc:        cdc,
+		paramspace: paramspace.WithTypeTable(ParamTypeTable()),
+		codespace:  codespace,
+		ScKeeper:   scKeeper,
+	}
+}
+
+// SetGovKeeper enables creating light clients by gov proposals
+func (k *Keeper) SetGovKeeper(govKeeper *gov.Keeper) {
+	k.govKeeper = govKeeper
+}
+
+// Codespace returns the keeper's codespace.
+func (k Keeper) Codespace() sdk.CodespaceType {
+	return k.codespace
+}
+
+func (k Keeper) prepareCtx(ctx sdk.Context, sideChainId string) (sdk.Context, sdk.Error) {
+	sideCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId)
+	if err != nil {
+		return sdk.Context{}, ErrInvalidSideChainId(k.codespace, err.Error())
+	}
+	return sideCtx, nil
+}
+
+// InitClient sets the trusted state of the light client of a side chain. The trusted header must be an
+// epoch block, so that the validator set can be read from its extra-data.
+func (k Keeper) InitClient(ctx sdk.Context, sideChainId string, chainId, epochLength int64, trusted axc.Header) sdk.Error {
+	sideCtx, sdkErr := k.prepareCtx(ctx, sideChainId)
+	if sdkErr != nil {
+		return sdkErr
+	}
+	if _, found := k.getClientState(sideCtx); found {
+		return ErrClientExists(k.codespace, sideChainId)
+	}
+	if epochLength <= 0 {
+		return ErrInvalidInput(k.codespace, "epoch length should be greater than 0")
+	}
+	validators, err := trusted.GetValidators(epochLength)
+	if err != nil {
+		return ErrInvalidHeader(k.codespace, fmt.Sprintf("trusted header should be an epoch block, %s", err.Error()))
+	}
+	sortValidators(validators)
+	signer, err := trusted.ExtractSignerFromHeader(big.NewInt(chainId))
+	if err != nil {
+		return ErrInvalidHeader(k.codespace, fmt.Sprintf("failed to extract signer from trusted header, %s", err.Error()))
+	}
+
+	snapshot := Snapshot{
+		Number:          trusted.Number,
+		Hash:            trusted.Hash(),
+		TotalDifficulty: trusted.Difficulty,
+		Validators:      validators,
+		RecentSigners:   []axc.Address{signer},
+	}
+	state := ClientState{
+		ChainId:         chainId,
+		EpochLength:     epochLength,
+		LatestNumber:    snapshot.Number,
+		LatestHash:      snapshot.Hash,
+		TotalDifficulty: snapshot.TotalDifficulty,
+	}
+	k.setClientState(sideCtx, state)
+	k.setVerifiedHeader(sideCtx, trusted, snapshot)
+	k.setCanonicalHash(sideCtx, snapshot.Number, snapshot.Hash)
+	return nil
+}
+
+// SubmitHeaders verifies the headers and adds them to the header tree. The headers must be contiguous and the
+// first one must follow a verified header, which is not necessarily the canonical tip, so that the headers of
+// a fork can be submitted. The canonical chain is switched to the submitted headers once they reach a higher
+// total difficulty, the headers which are already verified are skipped.
+func (k Keeper) SubmitHeaders(ctx sdk.Context, sideChainId string, headers []axc.Header) (ClientState, sdk.Error) {
+	sideCtx, sdkErr := k.prepareCtx(ctx, sideChainId)
+	if sdkErr != nil {
+		return ClientState{}, sdkErr
+	}
+	state, found := k.getClientState(sideCtx)
+	if !found {
+		return ClientState{}, ErrClientNotFound(k.codespace, sideChainId)
+	}
+	if len(headers) == 0 {
+		return state, nil
+	}
+
+	parent, found := k.getSnapshot(sideCtx, headers[0].ParentHash)
+	if !found {
+		return ClientState{}, ErrNonContiguousHeader(k.codespace, fmt.Sprintf("parent %s of header %d is not verified or has been pruned",
+			headers[0].ParentHash.Hex(), headers[0].Number))
+	}
+	for i := range headers {
+		if snapshot, found := k.getSnapshot(sideCtx, headers[i].Hash()); found && snapshot.Number == parent.Number+1 &&
+			headers[i].ParentHash == parent.Hash {
+			parent = snapshot
+			continue
+		}
+		snapshot, sdkErr := k.verifyHeader(state, parent, &headers[i])
+		if sdkErr != nil {
+			return ClientState{}, sdkErr
+		}
+		k.setVerifiedHeader(sideCtx, headers[i], snapshot)
+		parent = snapshot
+	}
+
+	// as in parlia, the chain of the higher total difficulty wins, the current one is kept on a tie
+	if parent.TotalDifficulty > state.TotalDifficulty {
+		k.switchCanonical(sideCtx, state, parent)
+		state.LatestNumber = parent.Number
+		state.LatestHash = parent.Hash
+		state.TotalDifficulty = parent.TotalDifficulty
+		k.setClientState(sideCtx, state)
+		k.pruneHeaders(sideCtx, state.LatestNumber-k.HeaderWindow(ctx))
+	}
+	return state, nil
+}
+
+// verifyHeader checks the header follows its parent and is sealed by an authorized validator following the
+// rules of the parlia snapshot of the parent, and returns the snapshot after the header:
+//   - the signer must not have sealed any of the latest len(validators)/2 headers
+//   - the difficulty must tell whether the signer is the in-turn validator
+func (k Keeper) verifyHeader(state ClientState, parent Snapshot, header *axc.Header) (Snapshot, sdk.Error) {
+	if header.Number != parent.Number+1 {
+		return Snapshot{}, ErrNonContiguousHeader(k.codespace, fmt.Sprintf("expected header %d, got %d", parent.Number+1, header.Number))
+	}
+	if header.ParentHash.Cmp(parent.Hash) != 0 {
+		return Snapshot{}, ErrNonContiguousHeader(k.codespace, fmt.Sprintf("parent hash of header %d mismatch, expected %s, got %s",
+			header.Number, parent.Hash.Hex(), header.ParentHash.Hex()))
+	}
+	if err := header.VerifyExtra(state.EpochLength); err != nil {
+		return Snapshot{}, ErrInvalidHeader(k.codespace, err.Error())
+	}
+
+	signer, err := header.ExtractSignerFromHeader(state.chainID())
+	if err != nil {
+		return Snapshot{}, ErrInvalidHeader(k.codespace, fmt.Sprintf("failed to extract signer from header %d, %s", header.Number, err.Error()))
+	}
+	if !bytes.Equal(signer.Bytes(), header.Coinbase.Bytes()) {
+		return Snapshot{}, ErrUnauthorizedSigner(k.codespace, fmt.Sprintf("signer %s of header %d is not the coinbase %s", signer.Hex(), header.Number, header.Coinbase.Hex()))
+	}
+	validators := parent.validatorsAt(header.Number)
+	if err := axc.VerifySignerInValidatorSet(signer, validators); err != nil {
+		return Snapshot{}, ErrUnauthorizedSigner(k.codespace, fmt.Sprintf("signer %s of header %d is not in the validator set", signer.Hex(), header.Number))
+	}
+
+	recents := parent.RecentSigners
+	if limit := signerLimit(validators); len(recents) >= limit {
+		recents = recents[len(recents)-limit+1:]
+	}
+	for _, recent := range recents {
+		if recent == signer {
+			return Snapshot{}, ErrRecentlySigned(k.codespace, fmt.Sprintf("signer %s of header %d has sealed one of the recent headers", signer.Hex(), header.Number))
+		}
+	}
+
+	expectedDifficulty := int64(diffNoTurn)
+	if isInTurn(signer, validators, header.Number) {
+		expectedDifficulty = diffInTurn
+	}
+	if header.Difficulty != expectedDifficulty {
+		return Snapshot{}, ErrWrongDifficulty(k.codespace, fmt.Sprintf("difficulty of header %d should be %d, got %d", header.Number, expectedDifficulty, header.Difficulty))
+	}
+
+	snapshot := Snapshot{
+		Number:            header.Number,
+		Hash:              header.Hash(),
+		TotalDifficulty:   parent.TotalDifficulty + header.Difficulty,
+		Validators:        parent.Validators,
+		PendingValidators: parent.PendingValidators,
+		PendingFrom:       parent.PendingFrom,
+		RecentSigners:     append(append(make([]axc.Address, 0, len(recents)+1), recents...), signer),
+	}
+	if len(snapshot.PendingValidators) != 0 && header.Number >= snapshot.PendingFrom {
+		snapshot.Validators = snapshot.PendingValidators
+		snapshot.PendingValidators = nil
+		snapshot.PendingFrom = 0
+	}
+	if header.IsEpoch(state.EpochLength) {
+		pending, err := header.GetValidators(state.EpochLength)
+		if err != nil {
+			return Snapshot{}, ErrInvalidHeader(k.codespace, err.Error())
+		}
+		sortValidators(pending)
+		// as in parlia, the new validator set takes effect after half of the current validators have sealed a block
+		snapshot.PendingValidators = pending
+		snapshot.PendingFrom = header.Number + int64(len(snapshot.Validators)/2) + 1
+	}
+	return snapshot, nil
+}
+
+// switchCanonical points the canonical numbers to the chain of the new tip, back to the common ancestor with
+// the current canonical chain
+func (k Keeper) switchCanonical(ctx sdk.Context, state ClientState, tip Snapshot) {
+	for number := tip.Number + 1; number <= state.LatestNumber; number++ {
+		k.deleteCanonicalHash(ctx, number)
+	}
+	number, hash := tip.Number, tip.Hash
+	for {
+		if canonical, found := k.getCanonicalHash(ctx, number); found && canonical == hash {
+			return
+		}
+		k.setCanonicalHash(ctx, number, hash)
+		header, found := k.getHeaderByHash(ctx, hash)
+		if !found {
+			return
+		}
+		number, hash = number-1, header.ParentHash
+	}
+}
+
+// pruneHeaders deletes the verified headers up to the given number, of the canonical chain and the forks
+func (k Keeper) pruneHeaders(ctx sdk.Context, number int64) {
+	if number < 0 {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(HeaderNumberKeyPrefix, GetHeaderNumberPrefix(number+1))
+	keys := make([][]byte, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		hash := axc.BytesToHash(store.Get(key))
+		store.Delete(GetHeaderKey(hash))
+		store.Delete(GetSnapshotKey(hash))
+		store.Delete(key)
+	}
+	for ; number >= 0; number-- {
+		if _, found := k.getCanonicalHash(ctx, number); !found {
+			break
+		}
+		k.deleteCanonicalHash(ctx, number)
+	}
+}
+
+// GetClientState returns the trusted state of the light client of a side chain
+func (k Keeper) GetClientState(ctx sdk.Context, sideChainId string) (ClientState, bool) {
+	sideCtx, err := k.prepareCtx(ctx, sideChainId)
+	if err != nil {
+		return ClientState{}, false
+	}
+	return k.getClientState(sideCtx)
+}
+
+// GetLatestNumber returns the number of the canonical tip of a side chain
+func (k Keeper) GetLatestNumber(ctx sdk.Context, sideChainId string) (int64, bool) {
+	state, found := k.GetClientState(ctx, sideChainId)
+	if !found {
+		return 0, false
+	}
+	return state.LatestNumber, true
+}
+
+// GetHeader returns a verified header of the canonical chain of a side chain, only headers in the window are
+// available
+func (k Keeper) GetHeader(ctx sdk.Context, sideChainId string, number int64) (axc.Header, bool) {
+	sideCtx, err := k.prepareCtx(ctx, sideChainId)
+	if err != nil {
+		return axc.Header{}, false
+	}
+	hash, found := k.getCanonicalHash(sideCtx, number)
+	if !found {
+		return axc.Header{}, false
+	}
+	return k.getHeaderByHash(sideCtx, hash)
+}
+
+// GetValidatorsAt returns the validators allowed to seal the header of the given number on the canonical chain of
+// a side chain, which are known as long as the parent header is in the window
+func (k Keeper) GetValidatorsAt(ctx sdk.Context, sideChainId string, number int64) ([]axc.Address, bool) {
+	sideCtx, err := k.prepareCtx(ctx, sideChainId)
+	if err != nil {
+		return nil, false
+	}
+	hash, found := k.getCanonicalHash(sideCtx, number-1)
+	if !found {
+		return nil, false
+	}
+	snapshot, found := k.getSnapshot(sideCtx, hash)
+	if !found {
+		return nil, false
+	}
+	return snapshot.validatorsAt(number), true
+}
+
+func (k Keeper) getClientState(ctx sdk.Context) (ClientState, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(ClientStateKey)
+	if bz == nil {
+		return ClientState{}, false
+	}
+	var state ClientState
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &state)
+	return state, true
+}
+
+func (k Keeper) setClientState(ctx sdk.Context, state ClientState) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(ClientStateKey, k.cdc.MustMarshalBinaryLengthPrefixed(state))
+}
+
+func (k Keeper) getHeaderByHash(ctx sdk.Context, hash axc.Hash) (axc.Header, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetHeaderKey(hash))
+	if bz == nil {
+		return axc.Header{}, false
+	}
+	var header axc.Header
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &header)
+	return header, true
+}
+
+func (k Keeper) getSnapshot(ctx sdk.Context, hash axc.Hash) (Snapshot, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetSnapshotKey(hash))
+	if bz == nil {
+		return Snapshot{}, false
+	}
+	var snapshot Snapshot
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &snapshot)
+	return snapshot, true
+}
+
+func (k Keeper) setVerifiedHeader(ctx sdk.Context, header axc.Header, snapshot Snapshot) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetHeaderKey(snapshot.Hash), k.cdc.MustMarshalBinaryLengthPrefixed(header))
+	store.Set(GetSnapshotKey(snapshot.Hash), k.cdc.MustMarshalBinaryLengthPrefixed(snapshot))
+	store.Set(GetHeaderNumberKey(snapshot.Number, snapshot.Hash), snapshot.Hash.Bytes())
+}
+
+func (k Keeper) getCanonicalHash(ctx sdk.Context, number int64) (axc.Hash, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetCanonicalKey(number))
+	if bz == nil {
+		return axc.Hash{}, false
+	}
+	return axc.BytesToHash(bz), true
+}
+
+func (k Keeper) setCanonicalHash(ctx sdk.Context, number int64, hash axc.Hash) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetCanonicalKey(number), hash.Bytes())
+}
+
+func (k Keeper) deleteCanonicalHash(ctx sdk.Context, number int64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetCanonicalKey(number))
+}
@@ -0,0 +1,371 @@
+package lightclient
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/axc"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+)
+
+const (
+	testSideChainId = "axc"
+	testChainId     = 56
+	testEpoch       = 10
+)
+
+func createTestCodec() *codec.Codec {
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	return cdc
+}
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keyLightClient := sdk.NewKVStoreKey("lightclient")
+	keySideChain := sdk.NewKVStoreKey("sc")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyLightClient, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySideChain, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+
+	cdc := createTestCodec()
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid"}, sdk.RunTxModeDeliver, log.NewNopLogger())
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+
+	scKeeper := sidechain.NewKeeper(keySideChain, pk.Subspace(sidechain.DefaultParamspace), cdc)
+	scKeeper.SetSideChainIdAndStorePrefix(ctx, testSideChainId, []byte{0x99})
+
+	keeper := NewKeeper(cdc, keyLightClient, pk.Subspace(DefaultParamspace), DefaultCodespace, scKeeper)
+	InitGenesis(ctx, keeper, DefaultGenesisState())
+	return ctx, keeper
+}
+
+type testSigner struct {
+	key  *btcec.PrivateKey
+	addr axc.Address
+}
+
+// newTestSigners returns the signers sorted by address, so signers[number%n] is the in-turn one of a header
+func newTestSigners(t *testing.T, n int) []testSigner {
+	signers := make([]testSigner, n)
+	for i := range signers {
+		key, err := btcec.NewPrivateKey(btcec.S256())
+		require.NoError(t, err)
+		signers[i].key = key
+		copy(signers[i].addr[:], axc.Keccak256(key.PubKey().SerializeUncompressed()[1:])[12:])
+	}
+	sort.Slice(signers, func(i, j int) bool {
+		return bytes.Compare(signers[i].addr[:], signers[j].addr[:]) < 0
+	})
+	return signers
+}
+
+func validatorsOf(signers []testSigner) []axc.Address {
+	validators := make([]axc.Address, len(signers))
+	for i, s := range signers {
+		validators[i] = s.addr
+	}
+	return validators
+}
+
+// newTestHeader builds a header sealed by the in-turn signer, epoch blocks carry the given validators
+func newTestHeader(t *testing.T, parent *axc.Header, signer testSigner, validators []axc.Address) axc.Header {
+	header := axc.Header{
+		Coinbase:   signer.addr,
+		Difficulty: diffInTurn,
+		Number:     1,
+		Time:       1,
+	}
+	if parent != nil {
+		header.ParentHash = parent.Hash()
+		header.Number = parent.Number + 1
+		header.Time = parent.Time + 3
+	}
+
+	header.Extra = make([]byte, 32)
+	if header.IsEpoch(testEpoch) {
+		for _, val := range validators {
+			header.Extra = append(header.Extra, val.Bytes()...)
+		}
+	}
+	header.Extra = append(header.Extra, make([]byte, 65)...)
+	sealTestHeader(t, &header, signer)
+	return header
+}
+
+// newNoTurnHeader builds a header sealed by an out-of-turn signer
+func newNoTurnHeader(t *testing.T, parent *axc.Header, signer testSigner, validators []axc.Address) axc.Header {
+	header := newTestHeader(t, parent, signer, validators)
+	header.Difficulty = diffNoTurn
+	sealTestHeader(t, &header, signer)
+	return header
+}
+
+func sealTestHeader(t *testing.T, header *axc.Header, signer testSigner) {
+	hash := axc.SealHash(header, big.NewInt(testChainId))
+	sig, err := btcec.SignCompact(btcec.S256(), signer.key, hash.Bytes(), false)
+	require.NoError(t, err)
+	// convert [V || R || S] to [R || S || V]
+	copy(header.Extra[len(header.Extra)-65:], append(sig[1:], sig[0]-27))
+}
+
+func getTipSnapshot(t *testing.T, ctx sdk.Context, keeper Keeper) Snapshot {
+	sideCtx, err := keeper.prepareCtx(ctx, testSideChainId)
+	require.Nil(t, err)
+	state, found := keeper.getClientState(sideCtx)
+	require.True(t, found)
+	snapshot, found := keeper.getSnapshot(sideCtx, state.LatestHash)
+	require.True(t, found)
+	return snapshot
+}
+
+func TestSubmitHeaders(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	signers := newTestSigners(t, 3)
+	validators := validatorsOf(signers)
+
+	trusted := newTestHeader(t, &axc.Header{Number: testEpoch - 1}, signers[testEpoch%3], validators)
+
+	_, err := keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{trusted})
+	require.NotNil(t, err)
+	require.EqualValues(t, CodeClientNotFound, err.Code())
+
+	require.Nil(t, keeper.InitClient(ctx, testSideChainId, testChainId, testEpoch, trusted))
+	require.NotNil(t, keeper.InitClient(ctx, testSideChainId, testChainId, testEpoch, trusted))
+
+	headers := make([]axc.Header, 0)
+	parent := trusted
+	for i := 0; i < 5; i++ {
+		header := newTestHeader(t, &parent, signers[(parent.Number+1)%3], validators)
+		headers = append(headers, header)
+		parent = header
+	}
+	state, err := keeper.SubmitHeaders(ctx, testSideChainId, headers)
+	require.Nil(t, err)
+	require.EqualValues(t, testEpoch+5, state.LatestNumber)
+	require.Equal(t, parent.Hash(), state.LatestHash)
+
+	header, found := keeper.GetHeader(ctx, testSideChainId, testEpoch+3)
+	require.True(t, found)
+	require.Equal(t, headers[2].Hash(), header.Hash())
+
+	// the header does not link to a verified header
+	unknown := newTestHeader(t, &parent, signers[(parent.Number+1)%3], validators)
+	_, err = keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{newTestHeader(t, &unknown, signers[(parent.Number+2)%3], validators)})
+	require.NotNil(t, err)
+	require.EqualValues(t, CodeNonContiguousHeader, err.Code())
+
+	// the verified headers are skipped
+	state, err = keeper.SubmitHeaders(ctx, testSideChainId, headers[3:])
+	require.Nil(t, err)
+	require.Equal(t, parent.Hash(), state.LatestHash)
+
+	// the header is sealed by a signer out of the validator set
+	outsider := newTestSigners(t, 1)[0]
+	_, err = keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{newTestHeader(t, &parent, outsider, validators)})
+	require.NotNil(t, err)
+	require.EqualValues(t, CodeUnauthorizedSigner, err.Code())
+
+	// the coinbase is not the signer
+	forged := newTestHeader(t, &parent, signers[0], validators)
+	forged.Coinbase = signers[1].addr
+	_, err = keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{forged})
+	require.NotNil(t, err)
+	require.EqualValues(t, CodeUnauthorizedSigner, err.Code())
+
+	// the difficulty does not match whether the signer is in turn
+	outOfTurn := signers[(parent.Number+2)%3]
+	_, err = keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{newTestHeader(t, &parent, outOfTurn, validators)})
+	require.NotNil(t, err)
+	require.EqualValues(t, CodeWrongDifficulty, err.Code())
+	_, err = keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{newNoTurnHeader(t, &parent, signers[(parent.Number+1)%3], validators)})
+	require.NotNil(t, err)
+	require.EqualValues(t, CodeWrongDifficulty, err.Code())
+
+	_, err = keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{newNoTurnHeader(t, &parent, outOfTurn, validators)})
+	require.Nil(t, err)
+	require.Equal(t, []axc.Address{signers[parent.Number%3].addr, outOfTurn.addr}, getTipSnapshot(t, ctx, keeper).RecentSigners)
+}
+
+func TestRecentlySigned(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	signers := newTestSigners(t, 5)
+	validators := validatorsOf(signers)
+
+	trusted := newTestHeader(t, &axc.Header{Number: testEpoch - 1}, signers[testEpoch%5], validators)
+	require.Nil(t, keeper.InitClient(ctx, testSideChainId, testChainId, testEpoch, trusted))
+
+	// a single validator can not extend the chain alone, whether it is in turn or not
+	malicious := signers[(testEpoch+1)%5]
+	forged := newTestHeader(t, &trusted, malicious, validators)
+	_, err := keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{forged, newNoTurnHeader(t, &forged, malicious, validators)})
+	require.NotNil(t, err)
+	require.EqualValues(t, CodeRecentlySigned, err.Code())
+
+	// nor can it seal again until len(validators)/2 other validators have sealed
+	headers := []axc.Header{forged}
+	parent := forged
+	header := newTestHeader(t, &parent, signers[(parent.Number+1)%5], validators)
+	headers = append(headers, header)
+	parent = header
+	_, err = keeper.SubmitHeaders(ctx, testSideChainId, append(headers, newNoTurnHeader(t, &parent, malicious, validators)))
+	require.NotNil(t, err)
+	require.EqualValues(t, CodeRecentlySigned, err.Code())
+
+	header = newTestHeader(t, &parent, signers[(parent.Number+1)%5], validators)
+	headers = append(headers, header)
+	parent = header
+	headers = append(headers, newNoTurnHeader(t, &parent, malicious, validators))
+	state, err := keeper.SubmitHeaders(ctx, testSideChainId, headers)
+	require.Nil(t, err)
+	require.EqualValues(t, testEpoch+4, state.LatestNumber)
+	require.Equal(t, []axc.Address{signers[2].addr, signers[3].addr, malicious.addr}, getTipSnapshot(t, ctx, keeper).RecentSigners)
+}
+
+func TestValidatorSetRotation(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	keeper.SetParams(ctx, Params{HeaderWindow: 5, MaxHeadersPerMsg: DefaultMaxHeadersPerMsg})
+
+	oldSigners := newTestSigners(t, 3)
+	newSigners := newTestSigners(t, 2)
+	oldValidators := validatorsOf(oldSigners)
+	newValidators := validatorsOf(newSigners)
+
+	trusted := newTestHeader(t, &axc.Header{Number: testEpoch - 1}, oldSigners[testEpoch%3], oldValidators)
+	require.Nil(t, keeper.InitClient(ctx, testSideChainId, testChainId, testEpoch, trusted))
+
+	// headers before the next epoch are sealed by the old validators
+	parent := trusted
+	for parent.Number < 2*testEpoch-1 {
+		header := newTestHeader(t, &parent, oldSigners[(parent.Number+1)%3], oldValidators)
+		_, err := keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{header})
+		require.Nil(t, err)
+		parent = header
+	}
+
+	// the epoch block announces the new validators
+	epochHeader := newTestHeader(t, &parent, oldSigners[(2*testEpoch)%3], newValidators)
+	_, err := keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{epochHeader})
+	require.Nil(t, err)
+	snapshot := getTipSnapshot(t, ctx, keeper)
+	require.Equal(t, newValidators, snapshot.PendingValidators)
+	require.EqualValues(t, 2*testEpoch+2, snapshot.PendingFrom)
+	parent = epochHeader
+
+	// the new validators are not allowed to seal until the switch
+	_, err = keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{newTestHeader(t, &parent, newSigners[0], newValidators)})
+	require.NotNil(t, err)
+	require.EqualValues(t, CodeUnauthorizedSigner, err.Code())
+
+	header := newTestHeader(t, &parent, oldSigners[(parent.Number+1)%3], newValidators)
+	_, err = keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{header})
+	require.Nil(t, err)
+	parent = header
+
+	// after the switch, the old validators are not allowed to seal
+	_, err = keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{newTestHeader(t, &parent, oldSigners[2], newValidators)})
+	require.NotNil(t, err)
+	require.EqualValues(t, CodeUnauthorizedSigner, err.Code())
+
+	header = newTestHeader(t, &parent, newSigners[(parent.Number+1)%2], newValidators)
+	_, err = keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{header})
+	require.Nil(t, err)
+	snapshot = getTipSnapshot(t, ctx, keeper)
+	require.Equal(t, newValidators, snapshot.Validators)
+	require.Len(t, snapshot.PendingValidators, 0)
+
+	// the validators sealing each header follow the switch
+	for number, expected := range map[int64][]axc.Address{
+		2 * testEpoch:     oldValidators,
+		2*testEpoch + 1:   oldValidators,
+		2*testEpoch + 2:   newValidators,
+		header.Number + 1: newValidators,
+	} {
+		validators, found := keeper.GetValidatorsAt(ctx, testSideChainId, number)
+		require.True(t, found)
+		require.Equal(t, expected, validators, "validators of header %d", number)
+	}
+	_, found := keeper.GetValidatorsAt(ctx, testSideChainId, header.Number+2)
+	require.False(t, found)
+
+	// only the latest headers in the window are kept
+	_, found = keeper.GetHeader(ctx, testSideChainId, header.Number-5)
+	require.False(t, found)
+	_, found = keeper.GetHeader(ctx, testSideChainId, header.Number-4)
+	require.True(t, found)
+	_, found = keeper.GetValidatorsAt(ctx, testSideChainId, header.Number-4)
+	require.False(t, found)
+}
+
+func TestForkChoice(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	signers := newTestSigners(t, 5)
+	validators := validatorsOf(signers)
+
+	trusted := newTestHeader(t, &axc.Header{Number: testEpoch - 1}, signers[testEpoch%5], validators)
+	require.Nil(t, keeper.InitClient(ctx, testSideChainId, testChainId, testEpoch, trusted))
+
+	// the canonical chain is sealed by the in-turn validators
+	a11 := newTestHeader(t, &trusted, signers[1], validators)
+	a12 := newTestHeader(t, &a11, signers[2], validators)
+	state, err := keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{a11, a12})
+	require.Nil(t, err)
+	require.Equal(t, a12.Hash(), state.LatestHash)
+
+	// a fork of a lower or equal total difficulty is verified but not followed
+	b11 := newNoTurnHeader(t, &trusted, signers[3], validators)
+	b12 := newTestHeader(t, &b11, signers[2], validators)
+	b13 := newNoTurnHeader(t, &b12, signers[4], validators)
+	state, err = keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{b11, b12, b13})
+	require.Nil(t, err)
+	require.Equal(t, a12.Hash(), state.LatestHash)
+	header, found := keeper.GetHeader(ctx, testSideChainId, testEpoch+1)
+	require.True(t, found)
+	require.Equal(t, a11.Hash(), header.Hash())
+	_, found = keeper.GetHeader(ctx, testSideChainId, testEpoch+3)
+	require.False(t, found)
+
+	// the fork becomes canonical once it reaches a higher total difficulty
+	b14 := newNoTurnHeader(t, &b13, signers[0], validators)
+	state, err = keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{b14})
+	require.Nil(t, err)
+	require.Equal(t, b14.Hash(), state.LatestHash)
+	require.EqualValues(t, testEpoch+4, state.LatestNumber)
+	require.Equal(t, trusted.Difficulty+5, state.TotalDifficulty)
+	header, found = keeper.GetHeader(ctx, testSideChainId, testEpoch+1)
+	require.True(t, found)
+	require.Equal(t, b11.Hash(), header.Hash())
+
+	// and the chain switches back to a shorter fork of a higher total difficulty
+	a13 := newTestHeader(t, &a12, signers[3], validators)
+	state, err = keeper.SubmitHeaders(ctx, testSideChainId, []axc.Header{a13})
+	require.Nil(t, err)
+	require.Equal(t, a13.Hash(), state.LatestHash)
+	require.EqualValues(t, testEpoch+3, state.LatestNumber)
+	for _, h := range []axc.Header{a11, a12, a13} {
+		header, found = keeper.GetHeader(ctx, testSideChainId, h.Number)
+		require.True(t, found)
+		require.Equal(t, h.Hash(), header.Hash())
+	}
+	_, found = keeper.GetHeader(ctx, testSideChainId, testEpoch+4)
+	require.False(t, found)
+}
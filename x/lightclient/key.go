@@ -0,0 +1,44 @@
+package lightclient
+
+import (
+	"encoding/binary"
+
+	"github.com/cosmos/cosmos-sdk/axc"
+)
+
+var (
+	ClientStateKey        = []byte{0x01} // key for the trusted state of the light client
+	HeaderKeyPrefix       = []byte{0x02} // prefix for each key to a verified header, by hash
+	SnapshotKeyPrefix     = []byte{0x03} // prefix for each key to the snapshot after a verified header, by hash
+	CanonicalKeyPrefix    = []byte{0x04} // prefix for each key to the hash of a canonical header, by number
+	HeaderNumberKeyPrefix = []byte{0x05} // prefix for each key indexing the verified headers by number and hash
+
+	canonicalKeyLength = len(CanonicalKeyPrefix) + 8
+)
+
+func GetHeaderKey(hash axc.Hash) []byte {
+	return append(append([]byte{}, HeaderKeyPrefix...), hash.Bytes()...)
+}
+
+func GetSnapshotKey(hash axc.Hash) []byte {
+	return append(append([]byte{}, SnapshotKeyPrefix...), hash.Bytes()...)
+}
+
+func GetCanonicalKey(number int64) []byte {
+	key := make([]byte, canonicalKeyLength)
+	copy(key, CanonicalKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(CanonicalKeyPrefix):], uint64(number))
+	return key
+}
+
+// GetHeaderNumberKey returns the index key of a verified header, the headers of forks share the number
+func GetHeaderNumberKey(number int64, hash axc.Hash) []byte {
+	return append(GetHeaderNumberPrefix(number), hash.Bytes()...)
+}
+
+func GetHeaderNumberPrefix(number int64) []byte {
+	key := make([]byte, len(HeaderNumberKeyPrefix)+8)
+	copy(key, HeaderNumberKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(HeaderNumberKeyPrefix):], uint64(number))
+	return key
+}
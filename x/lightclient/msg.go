@@ -0,0 +1,68 @@
+package lightclient
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/axc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+const (
+	MsgRoute = "lightclient"
+
+	TypeMsgSubmitSideChainHeaders = "submit_side_headers"
+)
+
+// MsgSubmitSideChainHeaders - struct for relayers to submit new headers of a side chain
+var _ sdk.Msg = MsgSubmitSideChainHeaders{}
+
+type MsgSubmitSideChainHeaders struct {
+	Relayer     sdk.AccAddress `json:"relayer"`
+	SideChainId string         `json:"side_chain_id"`
+	Headers     []axc.Header   `json:"headers"`
+}
+
+func NewMsgSubmitSideChainHeaders(relayer sdk.AccAddress, sideChainId string, headers []axc.Header) MsgSubmitSideChainHeaders {
+	return MsgSubmitSideChainHeaders{
+		Relayer:     relayer,
+		SideChainId: sideChainId,
+		Headers:     headers,
+	}
+}
+
+// nolint
+func (msg MsgSubmitSideChainHeaders) Route() string { return MsgRoute }
+func (msg MsgSubmitSideChainHeaders) Type() string  { return TypeMsgSubmitSideChainHeaders }
+func (msg MsgSubmitSideChainHeaders) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Relayer}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgSubmitSideChainHeaders) GetSignBytes() []byte {
+	b := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgSubmitSideChainHeaders) ValidateBasic() sdk.Error {
+	if len(msg.Relayer) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected relayer address length is %d, actual length is %d", sdk.AddrLen, len(msg.Relayer)))
+	}
+	if len(msg.SideChainId) == 0 || len(msg.SideChainId) > types.MaxSideChainIdLength {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("side chain id must be included and max length is %d bytes", types.MaxSideChainIdLength))
+	}
+	if len(msg.Headers) == 0 {
+		return ErrInvalidInput(DefaultCodespace, "headers can not be empty")
+	}
+	for i := 1; i < len(msg.Headers); i++ {
+		if msg.Headers[i].Number != msg.Headers[i-1].Number+1 {
+			return ErrNonContiguousHeader(DefaultCodespace, "the numbers of headers are not contiguous")
+		}
+	}
+	return nil
+}
+
+func (msg MsgSubmitSideChainHeaders) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
@@ -0,0 +1,75 @@
+package lightclient
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+const (
+	// Default parameter namespace
+	DefaultParamspace = "lightclient"
+
+	DefaultHeaderWindow     int64 = 1024
+	DefaultMaxHeadersPerMsg int64 = 100
+)
+
+var (
+	KeyHeaderWindow     = []byte("HeaderWindow")
+	KeyMaxHeadersPerMsg = []byte("MaxHeadersPerMsg")
+)
+
+// ParamTypeTable for lightclient module
+func ParamTypeTable() params.TypeTable {
+	return params.NewTypeTable().RegisterParamSet(&Params{})
+}
+
+type Params struct {
+	HeaderWindow     int64 `json:"header_window"`       // number of latest verified headers kept in store
+	MaxHeadersPerMsg int64 `json:"max_headers_per_msg"` // max number of headers a relayer can submit in one msg
+}
+
+// Implements params.ParamStruct
+func (p *Params) KeyValuePairs() params.KeyValuePairs {
+	return params.KeyValuePairs{
+		{KeyHeaderWindow, &p.HeaderWindow},
+		{KeyMaxHeadersPerMsg, &p.MaxHeadersPerMsg},
+	}
+}
+
+func (p *Params) UpdateCheck() error {
+	if p.HeaderWindow <= 0 {
+		return fmt.Errorf("the header_window should be greater than 0")
+	}
+	if p.MaxHeadersPerMsg <= 0 {
+		return fmt.Errorf("the max_headers_per_msg should be greater than 0")
+	}
+	return nil
+}
+
+func DefaultParams() Params {
+	return Params{
+		HeaderWindow:     DefaultHeaderWindow,
+		MaxHeadersPerMsg: DefaultMaxHeadersPerMsg,
+	}
+}
+
+func (k Keeper) HeaderWindow(ctx sdk.Context) (window int64) {
+	k.paramspace.Get(ctx, KeyHeaderWindow, &window)
+	return
+}
+
+func (k Keeper) MaxHeadersPerMsg(ctx sdk.Context) (max int64) {
+	k.paramspace.Get(ctx, KeyMaxHeadersPerMsg, &max)
+	return
+}
+
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramspace.GetParamSet(ctx, &params)
+	return
+}
+
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramspace.SetParamSet(ctx, &params)
+}
@@ -0,0 +1,14 @@
+package lightclient
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func RegisterUpgradeBeginBlocker(keeper Keeper) {
+	// the params of the chains which start with the light client are set in genesis
+	sdk.UpgradeMgr.RegisterBeginBlockerByName(sdk.LightClient, func(ctx sdk.Context) {
+		if !keeper.paramspace.Has(ctx, KeyHeaderWindow) {
+			keeper.SetParams(ctx, DefaultParams())
+		}
+	})
+}
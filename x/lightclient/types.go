@@ -0,0 +1,90 @@
+package lightclient
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/axc"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+// ClientState is the trusted state of the light client of a side chain, it points to the tip of the canonical
+// chain, which is the verified chain of the highest total difficulty.
+type ClientState struct {
+	ChainId         int64    `json:"chain_id"`         // chain id of the side chain, used to compute the seal hash
+	EpochLength     int64    `json:"epoch_length"`     // number of blocks between two validator set rotations
+	LatestNumber    int64    `json:"latest_number"`    // number of the canonical tip
+	LatestHash      axc.Hash `json:"latest_hash"`      // hash of the canonical tip
+	TotalDifficulty int64    `json:"total_difficulty"` // total difficulty of the canonical tip since the trusted header
+}
+
+func (s ClientState) chainID() *big.Int {
+	return big.NewInt(s.ChainId)
+}
+
+// Snapshot is the parlia state after a verified header, the headers of a fork are verified against the
+// snapshot of their own parent.
+type Snapshot struct {
+	Number          int64    `json:"number"`
+	Hash            axc.Hash `json:"hash"`
+	TotalDifficulty int64    `json:"total_difficulty"`
+
+	Validators []axc.Address `json:"validators"` // validators allowed to seal the next header
+
+	// validator set announced by the latest epoch block, it takes effect from PendingFrom
+	PendingValidators []axc.Address `json:"pending_validators"`
+	PendingFrom       int64         `json:"pending_from"`
+
+	// signers of the latest headers, oldest first, a validator may not seal again while it is in the window
+	RecentSigners []axc.Address `json:"recent_signers"`
+}
+
+// validatorsAt returns the validators allowed to seal the header of the given number
+func (s Snapshot) validatorsAt(number int64) []axc.Address {
+	if len(s.PendingValidators) != 0 && number >= s.PendingFrom {
+		return s.PendingValidators
+	}
+	return s.Validators
+}
+
+// signerLimit returns the number of blocks a validator has to wait before sealing again, as in parlia
+func signerLimit(validators []axc.Address) int {
+	return len(validators)/2 + 1
+}
+
+// isInTurn returns whether the signer is the in-turn validator of the header of the given number, the
+// validators must be sorted
+func isInTurn(signer axc.Address, validators []axc.Address, number int64) bool {
+	return validators[number%int64(len(validators))] == signer
+}
+
+// sortValidators sorts the validators by address in ascending order, as the snapshot of parlia does
+func sortValidators(validators []axc.Address) {
+	sort.Slice(validators, func(i, j int) bool {
+		return bytes.Compare(validators[i][:], validators[j][:]) < 0
+	})
+}
+
+// ClientCreation - the trusted header a side chain light client starts from, it is set in genesis or by a
+// CreateLightClient proposal
+type ClientCreation struct {
+	SideChainId   string     `json:"side_chain_id"`
+	ChainId       int64      `json:"chain_id"`
+	EpochLength   int64      `json:"epoch_length"`
+	TrustedHeader axc.Header `json:"trusted_header"`
+}
+
+func (c ClientCreation) Check() error {
+	if len(c.SideChainId) == 0 || len(c.SideChainId) > types.MaxSideChainIdLength {
+		return fmt.Errorf("side chain id must be included and max length is %d bytes", types.MaxSideChainIdLength)
+	}
+	if c.EpochLength <= 0 {
+		return fmt.Errorf("epoch length should be greater than 0")
+	}
+	if _, err := c.TrustedHeader.GetValidators(c.EpochLength); err != nil {
+		return fmt.Errorf("invalid trusted header of side chain %s, %s", c.SideChainId, err.Error())
+	}
+	return nil
+}
@@ -34,9 +34,11 @@ var (
 	StatusTextToString = types.StatusTextToString
 	StringToStatusText = types.StringToStatusText
 
-	NewClaimMsg = types.NewClaimMsg
-	RouteOracle = types.RouteOracle
-	GetClaimId  = types.GetClaimId
+	NewClaimMsg                 = types.NewClaimMsg
+	NewClaimWithProofMsg        = types.NewClaimWithProofMsg
+	NewWithdrawRelayerRewardMsg = types.NewWithdrawRelayerRewardMsg
+	RouteOracle                 = types.RouteOracle
+	GetClaimId                  = types.GetClaimId
 )
 
 type (
@@ -47,5 +49,7 @@ type (
 	Status     = types.Status
 	StatusText = types.StatusText
 
-	ClaimMsg = types.ClaimMsg
+	ClaimMsg                 = types.ClaimMsg
+	ClaimWithProofMsg        = types.ClaimWithProofMsg
+	WithdrawRelayerRewardMsg = types.WithdrawRelayerRewardMsg
 )
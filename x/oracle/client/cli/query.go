@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle"
+)
+
+const (
+	flagSideChainId = "side-chain-id"
+	flagSequence    = "sequence"
+	flagChannelId   = "channel-id"
+	flagValidator   = "validator"
+	flagFromSeq     = "from-sequence"
+	flagToSeq       = "to-sequence"
+)
+
+// GetQueryCmd returns the oracle query commands
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	oracleQueryCmd := &cobra.Command{
+		Use:   "oracle",
+		Short: "Querying commands for the oracle module",
+	}
+	oracleQueryCmd.AddCommand(client.GetCommands(
+		GetCmdQueryProphecy(queryRoute, cdc),
+		GetCmdQuerySequence(queryRoute, cdc),
+		GetCmdQueryRelayers(queryRoute, cdc),
+		GetCmdQueryPendingProphecies(queryRoute, cdc),
+		GetCmdQueryRelayerRewards(queryRoute, cdc),
+	)...)
+	return oracleQueryCmd
+}
+
+// GetCmdQueryProphecy implements the query prophecy command.
+func GetCmdQueryProphecy(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prophecy",
+		Short: "Query the prophecy of a relay packages sequence",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := oracle.QueryProphecyParams{
+				SideChainId: viper.GetString(flagSideChainId),
+				Sequence:    viper.GetUint64(flagSequence),
+			}
+			return queryAndPrint(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, oracle.QueryProphecy), params)
+		},
+	}
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	cmd.Flags().Uint64(flagSequence, 0, "the sequence of the relay packages channel")
+	return cmd
+}
+
+// GetCmdQuerySequence implements the query receive sequence command.
+func GetCmdQuerySequence(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sequence",
+		Short: "Query the current receive sequence of a channel",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			channelId, err := sdk.ParseChannelID(viper.GetString(flagChannelId))
+			if err != nil {
+				return err
+			}
+			params := oracle.QuerySequenceParams{
+				SideChainId: viper.GetString(flagSideChainId),
+				ChannelId:   channelId,
+			}
+			return queryAndPrint(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, oracle.QuerySequence), params)
+		},
+	}
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	cmd.Flags().String(flagChannelId, "0", "the id of channel, 0 is the relay packages channel")
+	return cmd
+}
+
+// GetCmdQueryRelayers implements the query relayers command.
+func GetCmdQueryRelayers(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "relayers",
+		Short: "Query the oracle relayers and their claims on a relay packages sequence",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := oracle.QueryProphecyParams{
+				SideChainId: viper.GetString(flagSideChainId),
+				Sequence:    viper.GetUint64(flagSequence),
+			}
+			return queryAndPrint(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, oracle.QueryRelayers), params)
+		},
+	}
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	cmd.Flags().Uint64(flagSequence, 0, "the sequence of the relay packages channel")
+	return cmd
+}
+
+// GetCmdQueryPendingProphecies implements the query pending prophecies command.
+func GetCmdQueryPendingProphecies(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pending",
+		Short: "Query the pending prophecies of a side chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := oracle.QueryPendingPropheciesParams{
+				SideChainId:  viper.GetString(flagSideChainId),
+				FromSequence: viper.GetUint64(flagFromSeq),
+				ToSequence:   viper.GetUint64(flagToSeq),
+			}
+			return queryAndPrint(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, oracle.QueryPendingProphecies), params)
+		},
+	}
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	cmd.Flags().Uint64(flagFromSeq, 0, "the lowest sequence of the prophecies")
+	cmd.Flags().Uint64(flagToSeq, 0, "the highest sequence of the prophecies, 0 for no limit")
+	return cmd
+}
+
+// GetCmdQueryRelayerRewards implements the query relayer rewards command.
+func GetCmdQueryRelayerRewards(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rewards",
+		Short: "Query the relay fees accrued to the oracle relayers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := oracle.QueryRelayerRewardsParams{}
+			if validator := viper.GetString(flagValidator); validator != "" {
+				valAddr, err := sdk.ValAddressFromBech32(validator)
+				if err != nil {
+					return err
+				}
+				params.Validator = valAddr
+			}
+			return queryAndPrint(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, oracle.QueryRelayerRewards), params)
+		},
+	}
+	cmd.Flags().String(flagValidator, "", "the operator address of the relayer, all the relayers if empty")
+	return cmd
+}
+
+func queryAndPrint(cdc *codec.Codec, path string, params interface{}) error {
+	cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return err
+	}
+
+	res, err := cliCtx.QueryWithData(path, bz)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(res))
+	return nil
+}
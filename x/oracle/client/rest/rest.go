@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle"
+)
+
+// REST Variable names
+// nolint
+const (
+	RestSideChainId = "sideChainId"
+	RestSequence    = "sequence"
+	RestChannelId   = "channelId"
+	RestValidator   = "validator"
+	RestFromSeq     = "from_sequence"
+	RestToSeq       = "to_sequence"
+	storeName       = "oracle"
+)
+
+// RegisterRoutes - Central function to define routes that get registered by the main application
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
+	r.HandleFunc(fmt.Sprintf("/oracle/{%s}/prophecies", RestSideChainId), queryPendingPropheciesHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/oracle/{%s}/prophecies/{%s}", RestSideChainId, RestSequence), queryProphecyHandlerFn(cdc, cliCtx, oracle.QueryProphecy)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/oracle/{%s}/prophecies/{%s}/relayers", RestSideChainId, RestSequence), queryProphecyHandlerFn(cdc, cliCtx, oracle.QueryRelayers)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/oracle/{%s}/sequences/{%s}", RestSideChainId, RestChannelId), querySequenceHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc("/oracle/relayer_rewards", queryRelayerRewardsHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/oracle/relayer_rewards/{%s}", RestValidator), queryRelayerRewardsHandlerFn(cdc, cliCtx)).Methods("GET")
+}
+
+func queryProphecyHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext, query string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		sequence, ok := utils.ParseInt64OrReturnBadRequest(w, vars[RestSequence])
+		if !ok {
+			return
+		}
+		if sequence < 0 {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, "sequence should not be negative")
+			return
+		}
+
+		params := oracle.QueryProphecyParams{
+			SideChainId: vars[RestSideChainId],
+			Sequence:    uint64(sequence),
+		}
+		queryAndWrite(w, cdc, cliCtx, query, params)
+	}
+}
+
+func querySequenceHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		channelId, err := sdk.ParseChannelID(vars[RestChannelId])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		params := oracle.QuerySequenceParams{
+			SideChainId: vars[RestSideChainId],
+			ChannelId:   channelId,
+		}
+		queryAndWrite(w, cdc, cliCtx, oracle.QuerySequence, params)
+	}
+}
+
+func queryPendingPropheciesHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := oracle.QueryPendingPropheciesParams{
+			SideChainId: mux.Vars(r)[RestSideChainId],
+		}
+		if strFromSeq := r.URL.Query().Get(RestFromSeq); len(strFromSeq) != 0 {
+			fromSeq, ok := utils.ParseInt64OrReturnBadRequest(w, strFromSeq)
+			if !ok {
+				return
+			}
+			params.FromSequence = uint64(fromSeq)
+		}
+		if strToSeq := r.URL.Query().Get(RestToSeq); len(strToSeq) != 0 {
+			toSeq, ok := utils.ParseInt64OrReturnBadRequest(w, strToSeq)
+			if !ok {
+				return
+			}
+			params.ToSequence = uint64(toSeq)
+		}
+		queryAndWrite(w, cdc, cliCtx, oracle.QueryPendingProphecies, params)
+	}
+}
+
+func queryRelayerRewardsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := oracle.QueryRelayerRewardsParams{}
+		if validator, ok := mux.Vars(r)[RestValidator]; ok {
+			valAddr, err := sdk.ValAddressFromBech32(validator)
+			if err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			params.Validator = valAddr
+		}
+		queryAndWrite(w, cdc, cliCtx, oracle.QueryRelayerRewards, params)
+	}
+}
+
+func queryAndWrite(w http.ResponseWriter, cdc *codec.Codec, cliCtx context.CLIContext, query string, params interface{}) {
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", storeName, query), bz)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+}
@@ -0,0 +1,67 @@
+package oracle
+
+import (
+	"encoding/hex"
+
+	"github.com/cosmos/cosmos-sdk/axc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// EndBlocker prunes the expired prophecies and reevaluates the pending ones against the current
+// oracle relayers, it works on the prophecies in the deterministic store only.
+func EndBlocker(ctx sdk.Context, keeper Keeper) {
+	if !sdk.IsUpgrade(sdk.ProphecyKVStore) {
+		return
+	}
+	events := keeper.PruneExpiredProphecies(ctx)
+	recomputeEvents, finalized := keeper.RecomputePendingProphecies(ctx)
+	events = events.AppendEvents(recomputeEvents)
+	for _, prophecy := range finalized {
+		events = events.AppendEvents(executeFinalizedProphecy(ctx, keeper, prophecy))
+	}
+	ctx.EventManager().EmitEvents(events)
+}
+
+// executeFinalizedProphecy executes the packages of a prophecy which reaches consensus by the power change of the
+// oracle relayers. The prophecy is deleted even if the packages fail, so the relayers can claim the sequence again.
+func executeFinalizedProphecy(ctx sdk.Context, keeper Keeper, prophecy types.Prophecy) sdk.Events {
+	logger := ctx.Logger().With("module", "oracle")
+	defer keeper.DeleteProphecy(ctx, prophecy.ID)
+
+	chainId, _, sequence, err := types.ParseClaimId(prophecy.ID)
+	if err != nil {
+		logger.Error("invalid id of finalized prophecy", "id", prophecy.ID, "err", err.Error())
+		return nil
+	}
+	// the prophecy of a delivered sequence is stale
+	if keeper.ScKeeper.GetReceiveSequence(ctx, chainId, types.RelayPackagesChannelId) != sequence {
+		return nil
+	}
+
+	payload, err := hex.DecodeString(prophecy.Status.FinalClaim)
+	if err != nil {
+		logger.Error("decode payload of finalized prophecy error", "id", prophecy.ID, "err", err.Error())
+		return nil
+	}
+	packages := types.Packages{}
+	if err := rlp.DecodeBytes(payload, &packages); err != nil {
+		logger.Error("decode packages of finalized prophecy error", "id", prophecy.ID, "err", err.Error())
+		return nil
+	}
+
+	shares := keeper.GetRewardShares(ctx, prophecy)
+	if len(shares) == 0 {
+		return nil
+	}
+	// no relayer delivers the packages, the rounding remainder of the relay fee goes to the first voter
+	cacheCtx, write := ctx.CacheContext()
+	events, sdkErr := executePackages(cacheCtx, keeper, chainId, shares[0].Validator, shares, packages)
+	if sdkErr != nil {
+		logger.Error("execute finalized prophecy error", "id", prophecy.ID, "err", sdkErr.Error())
+		return nil
+	}
+	write()
+	keeper.ScKeeper.IncrReceiveSequence(ctx, chainId, types.RelayPackagesChannelId)
+	return events
+}
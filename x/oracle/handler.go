@@ -18,6 +18,10 @@ func NewHandler(keeper Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case types.ClaimMsg:
 			return handleClaimMsg(ctx, keeper, msg)
+		case types.ClaimWithProofMsg:
+			return handleClaimWithProofMsg(ctx, keeper, msg)
+		case types.WithdrawRelayerRewardMsg:
+			return handleWithdrawRelayerRewardMsg(ctx, keeper, msg)
 		default:
 			errMsg := "Unrecognized oracle msg type"
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -54,32 +58,91 @@ func handleClaimMsg(ctx sdk.Context, oracleKeeper Keeper, msg ClaimMsg) sdk.Resu
 		return types.ErrInvalidPayload("decode packages error").Result()
 	}
 
+	shares := oracleKeeper.GetRewardShares(ctx, prophecy)
+	events, sdkErr := executePackages(ctx, oracleKeeper, msg.ChainId, sdk.ValAddress(msg.ValidatorAddress), shares, packages)
+	if sdkErr != nil {
+		return sdkErr.Result()
+	}
+
+	// delete prophecy when execute claim success
+	oracleKeeper.DeleteProphecy(ctx, prophecy.ID)
+	oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, msg.ChainId, types.RelayPackagesChannelId)
+
+	return sdk.Result{
+		Events: events,
+	}
+}
+
+func handleClaimWithProofMsg(ctx sdk.Context, oracleKeeper Keeper, msg ClaimWithProofMsg) sdk.Result {
+	sequence := oracleKeeper.ScKeeper.GetReceiveSequence(ctx, msg.ChainId, types.RelayPackagesChannelId)
+	if sequence != msg.Sequence {
+		return types.ErrInvalidSequence(fmt.Sprintf("current sequence of channel %d is %d", types.RelayPackagesChannelId, sequence)).Result()
+	}
+
+	packages, sdkErr := oracleKeeper.ProcessClaimWithProof(ctx, msg)
+	if sdkErr != nil {
+		return sdkErr.Result()
+	}
+
+	// a proven claim is voted by its submitter alone
+	deliverer := sdk.ValAddress(msg.ValidatorAddress)
+	shares := []types.RewardShare{{Validator: deliverer, Power: 1}}
+	events, sdkErr := executePackages(ctx, oracleKeeper, msg.ChainId, deliverer, shares, packages)
+	if sdkErr != nil {
+		return sdkErr.Result()
+	}
+
+	// the packages are delivered, the pending prophecy of the same sequence is useless
+	oracleKeeper.DeleteProphecy(ctx, types.GetClaimId(msg.ChainId, types.RelayPackagesChannelId, msg.Sequence))
+	oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, msg.ChainId, types.RelayPackagesChannelId)
+
+	return sdk.Result{
+		Events: events,
+	}
+}
+
+func handleWithdrawRelayerRewardMsg(ctx sdk.Context, oracleKeeper Keeper, msg types.WithdrawRelayerRewardMsg) sdk.Result {
+	if !sdk.IsUpgrade(sdk.RelayerReward) {
+		return types.ErrRelayerRewardDisabled().Result()
+	}
+
+	validator := sdk.ValAddress(msg.ValidatorAddress)
+	amount, sdkErr := oracleKeeper.WithdrawRelayerReward(ctx, validator)
+	if sdkErr != nil {
+		return sdkErr.Result()
+	}
+
+	events := sdk.Events{sdk.NewEvent(types.EventTypeWithdrawRelayerReward,
+		sdk.NewAttribute(types.AttributeKeyValidator, validator.String()),
+		sdk.NewAttribute(types.AttributeKeyAmount, strconv.FormatInt(amount, 10)),
+	)}
+	return sdk.Result{
+		Events: events,
+	}
+}
+
+func executePackages(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, deliverer sdk.ValAddress,
+	shares []types.RewardShare, packages types.Packages) ([]sdk.Event, sdk.Error) {
 	events := make([]sdk.Event, 0, len(packages))
 	for _, pack := range packages {
-		event, sdkErr := handlePackage(ctx, oracleKeeper, msg.ChainId, &pack)
+		event, sdkErr := handlePackage(ctx, oracleKeeper, chainId, deliverer, shares, &pack)
 		if sdkErr != nil {
 			// only do log, but let reset package get chance to execute.
 			ctx.Logger().With("module", "oracle").Error(fmt.Sprintf("process package failed, channel=%d, sequence=%d, error=%v", pack.ChannelId, pack.Sequence, sdkErr))
-			return sdkErr.Result()
+			return nil, sdkErr
 		} else {
 			ctx.Logger().With("module", "oracle").Info(fmt.Sprintf("process package success, channel=%d, sequence=%d", pack.ChannelId, pack.Sequence))
 		}
 		events = append(events, event)
 
 		// increase channel sequence
-		oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, msg.ChainId, pack.ChannelId)
-	}
-
-	// delete prophecy when execute claim success
-	oracleKeeper.DeleteProphecy(ctx, prophecy.ID)
-	oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, msg.ChainId, types.RelayPackagesChannelId)
-
-	return sdk.Result{
-		Events: events,
+		oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, chainId, pack.ChannelId)
 	}
+	return events, nil
 }
 
-func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pack *types.Package) (sdk.Event, sdk.Error) {
+func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, deliverer sdk.ValAddress,
+	shares []types.RewardShare, pack *types.Package) (sdk.Event, sdk.Error) {
 	logger := ctx.Logger().With("module", "x/oracle")
 
 	crossChainApp := oracleKeeper.ScKeeper.GetCrossChainApp(ctx, pack.ChannelId)
@@ -106,37 +169,65 @@ func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pa
 		return sdk.Event{}, types.ErrFeeOverflow("relayFee overflow")
 	}
 
-	fee := sdk.Coins{sdk.Coin{Denom: sdk.NativeTokenSymbol, Amount: feeAmount}}
-	_, _, sdkErr := oracleKeeper.BkKeeper.SubtractCoins(ctx, sdk.PegAccount, fee)
-	if sdkErr != nil {
-		return sdk.Event{}, sdkErr
-	}
-
-	if ctx.IsDeliverTx() {
-		// add changed accounts
-		oracleKeeper.Pool.AddAddrs([]sdk.AccAddress{sdk.PegAccount})
-
-		// add fee
-		fees.Pool.AddAndCommitFee(
-			fmt.Sprintf("cross_communication:%d:%d:%v", pack.ChannelId, pack.Sequence, packageType),
-			sdk.Fee{
-				Tokens: fee,
-				Type:   sdk.FeeForProposer,
-			},
-		)
-	}
-
-	cacheCtx, write := ctx.CacheContext()
-	crash, result := executeClaim(cacheCtx, crossChainApp, pack.Payload, packageType, feeAmount)
-	if result.IsOk() {
-		write()
-	} else if ctx.IsDeliverTx() {
-		oracleKeeper.Metrics.ErrNumOfChannels.With("channel_id", fmt.Sprintf("%d", pack.ChannelId)).Add(1)
-		destChainName, err := oracleKeeper.ScKeeper.GetDestChainName(chainId)
-		if err != nil {
-			logger.Error("failed to find name of dest chain", "chainId", chainId)
-		} else {
-			oracleKeeper.PublishCrossAppFailEvent(ctx, sdk.PegAccount.String(), feeAmount, destChainName)
+	if sdk.IsUpgrade(sdk.RelayerReward) {
+		// the relay fee is accrued to the relayers which voted for the package
+		if sdkErr := oracleKeeper.AccrueRelayerRewards(ctx, shares, deliverer, feeAmount); sdkErr != nil {
+			return sdk.Event{}, sdkErr
+		}
+	} else {
+		fee := sdk.Coins{sdk.Coin{Denom: sdk.NativeTokenSymbol, Amount: feeAmount}}
+		_, _, sdkErr := oracleKeeper.BkKeeper.SubtractCoins(ctx, sdk.PegAccount, fee)
+		if sdkErr != nil {
+			return sdk.Event{}, sdkErr
+		}
+
+		if ctx.IsDeliverTx() {
+			// add changed accounts
+			oracleKeeper.Pool.AddAddrs([]sdk.AccAddress{sdk.PegAccount})
+
+			// add fee
+			fees.Pool.AddAndCommitFee(
+				fmt.Sprintf("cross_communication:%d:%d:%v", pack.ChannelId, pack.Sequence, packageType),
+				sdk.Fee{
+					Tokens: fee,
+					Type:   sdk.FeeForProposer,
+				},
+			)
+		}
+	}
+
+	// the application has executed the fail ack of a timed out package, its late ack is dropped
+	timedOut := false
+	if packageType != sdk.SynCrossChainPackageType && sdk.IsUpgrade(sdk.IBCPackageTimeout) {
+		outstanding, found := oracleKeeper.IbcKeeper.ResolveOutstandingPackage(ctx, chainId, pack.ChannelId)
+		timedOut = found && outstanding.TimedOut
+	}
+
+	var crash bool
+	var result sdk.ExecuteResult
+	if !timedOut {
+		info := sdk.CrossChainPackageInfo{ChainID: chainId, ChannelID: pack.ChannelId, Sequence: pack.Sequence}
+		observer, observed := crossChainApp.(sdk.CrossChainPackageObserver)
+		if observed {
+			observer.OnPackageReceived(ctx, info, packageType, pack.Payload[sTypes.PackageHeaderLength:])
+		}
+
+		cacheCtx, write := ctx.CacheContext()
+		crash, result = executeClaim(cacheCtx, crossChainApp, pack.Payload, packageType, feeAmount)
+		if observed {
+			observer.OnPackageExecuted(ctx, info, packageType, pack.Payload[sTypes.PackageHeaderLength:], result, crash)
+		}
+		if result.IsOk() {
+			write()
+		} else if ctx.IsDeliverTx() {
+			oracleKeeper.Metrics.ErrNumOfChannels.With("channel_id", fmt.Sprintf("%d", pack.ChannelId)).Add(1)
+			destChainName, err := oracleKeeper.ScKeeper.GetDestChainName(chainId)
+			if err != nil {
+				logger.Error("failed to find name of dest chain", "chainId", chainId)
+			} else {
+				oracleKeeper.PublishCrossAppFailEvent(ctx, sdk.PegAccount.String(), feeAmount, destChainName,
+					info, packageType, result)
+			}
 		}
 	}
 
@@ -189,6 +280,10 @@ func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pa
 		resultTags = append(resultTags, sdk.MakeTag(types.ClaimCrash, []byte{1}))
 	}
 
+	if timedOut {
+		resultTags = append(resultTags, sdk.MakeTag(types.ClaimTimedOut, []byte{1}))
+	}
+
 	// emit event if feeAmount is larger than 0
 	if feeAmount > 0 {
 		resultTags = append(resultTags, sdk.GetPegOutTag(sdk.NativeTokenSymbol, feeAmount))
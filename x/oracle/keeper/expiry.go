@@ -0,0 +1,171 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"strconv"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// GetProphecyExpiryBlocks returns the number of blocks a prophecy can stay pending, 0 means never expire
+func (k Keeper) GetProphecyExpiryBlocks(ctx sdk.Context) (expiryBlocks int64) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyProphecyExpiryBlocks, &expiryBlocks)
+	return
+}
+
+// SetProphecyExpiryBlocks sets the number of blocks a prophecy can stay pending
+func (k Keeper) SetProphecyExpiryBlocks(ctx sdk.Context, expiryBlocks int64) {
+	k.paramSpace.Set(ctx, types.ParamStoreKeyProphecyExpiryBlocks, &expiryBlocks)
+}
+
+// PruneExpiredProphecies deletes the prophecies which are created at least expiry blocks ago
+func (k Keeper) PruneExpiredProphecies(ctx sdk.Context) sdk.Events {
+	expiryBlocks := k.GetProphecyExpiryBlocks(ctx)
+	if expiryBlocks <= 0 || ctx.BlockHeight() < expiryBlocks {
+		return nil
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.ProphecyQueueKeyPrefix, types.GetProphecyQueueTimeKey(ctx.BlockHeight()-expiryBlocks+1))
+	expired := make([]string, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		expired = append(expired, string(iterator.Value()))
+	}
+	iterator.Close()
+
+	events := make(sdk.Events, 0, len(expired))
+	for _, id := range expired {
+		info, found := k.getProphecyInfo(ctx, id)
+		if !found {
+			continue
+		}
+		k.deleteProphecy(ctx, id)
+		events = append(events, sdk.NewEvent(types.EventTypeProphecyExpired,
+			sdk.NewAttribute(types.AttributeKeyProphecyId, id),
+			sdk.NewAttribute(types.AttributeKeyCreateHeight, strconv.FormatInt(info.CreateHeight, 10)),
+		))
+	}
+	return events
+}
+
+// RecomputePendingProphecies weighs the claims of the pending prophecies by the current power of the
+// oracle relayers. The prophecies which can never reach consensus are deleted. The prophecies which reach
+// consensus are finalized and returned, as no claim may come to execute them, the caller should execute
+// and delete them.
+//
+// The prophecies are only reweighed when the power of the oracle relayers changed since the last time,
+// and they are left as they are while there is no power at all.
+func (k Keeper) RecomputePendingProphecies(ctx sdk.Context) (sdk.Events, []types.Prophecy) {
+	relayersPower := k.stakeKeeper.GetOracleRelayersPower(ctx)
+	if !k.updateRelayersPowerHash(ctx, relayersPower) {
+		return nil, nil
+	}
+	totalPower := k.getTotalPower(ctx, relayersPower)
+	if totalPower <= 0 {
+		return nil, nil
+	}
+
+	events := make(sdk.Events, 0)
+	finalized := make([]types.Prophecy, 0)
+	for _, info := range k.getPendingProphecyInfos(ctx) {
+		changed := false
+		claimsPower := int64(0)
+		for _, claim := range k.GetValidatorClaims(ctx, info.ID) {
+			power := relayersPower[claim.Validator.String()]
+			if power != claim.Power {
+				k.addClaimTally(ctx, info.ID, claim.Claim, power-claim.Power)
+				claim.Power = power
+				k.setValidatorClaim(ctx, info.ID, claim)
+				changed = true
+			}
+			claimsPower += power
+		}
+		if changed {
+			info.ClaimsPower = claimsPower
+			k.setProphecyInfo(ctx, info)
+		}
+
+		highestClaim, highestClaimPower := k.findHighestTally(ctx, info.ID)
+		status := k.completeStatus(ctx, info.Status, highestClaim, highestClaimPower, info.ClaimsPower, totalPower)
+		switch status.Text {
+		case types.FailedStatusText:
+			k.deleteProphecy(ctx, info.ID)
+			events = append(events, sdk.NewEvent(types.EventTypeProphecyFailed,
+				sdk.NewAttribute(types.AttributeKeyProphecyId, info.ID),
+				sdk.NewAttribute(types.AttributeKeyCreateHeight, strconv.FormatInt(info.CreateHeight, 10)),
+			))
+		case types.SuccessStatusText:
+			info.Status = status
+			k.setProphecyInfo(ctx, info)
+			if prophecy, found := k.getProphecy(ctx, info.ID); found {
+				finalized = append(finalized, prophecy)
+			}
+		}
+	}
+	return events, finalized
+}
+
+// updateRelayersPowerHash records the hash of the power of the oracle relayers, and returns whether it
+// differs from the recorded one
+func (k Keeper) updateRelayersPowerHash(ctx sdk.Context, relayersPower map[string]int64) bool {
+	validators := make([]string, 0, len(relayersPower))
+	for validator := range relayersPower {
+		validators = append(validators, validator)
+	}
+	sort.Strings(validators)
+
+	hasher := tmhash.New()
+	for _, validator := range validators {
+		bz := make([]byte, 8)
+		binary.BigEndian.PutUint64(bz, uint64(relayersPower[validator]))
+		hasher.Write([]byte(validator))
+		hasher.Write(bz)
+	}
+	hash := hasher.Sum(nil)
+
+	store := ctx.KVStore(k.storeKey)
+	if bytes.Equal(store.Get(types.RelayersPowerHashKey), hash) {
+		return false
+	}
+	store.Set(types.RelayersPowerHashKey, hash)
+	return true
+}
+
+func (k Keeper) getPendingProphecyInfos(ctx sdk.Context) []types.ProphecyInfo {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ProphecyKeyPrefix)
+	defer iterator.Close()
+
+	infos := make([]types.ProphecyInfo, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var info types.ProphecyInfo
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &info)
+		if info.Status.Text == types.PendingStatusText {
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+// GetPendingProphecies returns the pending prophecies of a chain ordered by sequence, whose sequence is
+// in [fromSequence, toSequence]. The sequence is not bounded above if toSequence is 0.
+func (k Keeper) GetPendingProphecies(ctx sdk.Context, chainId sdk.ChainID, fromSequence, toSequence uint64) []types.ProphecyResult {
+	prophecies := make([]types.ProphecyResult, 0)
+	for _, info := range k.getPendingProphecyInfos(ctx) {
+		result, err := k.prophecyResult(ctx, info)
+		if err != nil || result.ChainId != chainId || result.Sequence < fromSequence ||
+			(toSequence != 0 && result.Sequence > toSequence) {
+			continue
+		}
+		prophecies = append(prophecies, result)
+	}
+	sort.Slice(prophecies, func(i, j int) bool {
+		return prophecies[i].Sequence < prophecies[j].Sequence
+	})
+	return prophecies
+}
@@ -0,0 +1,199 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// powerStakeKeeper overrides the power of the oracle relayers
+type powerStakeKeeper struct {
+	types.StakingKeeper
+	powers map[string]int64
+}
+
+func (k powerStakeKeeper) GetOracleRelayersPower(_ sdk.Context) map[string]int64 {
+	return k.powers
+}
+
+func (k powerStakeKeeper) GetLastTotalPower(_ sdk.Context) int64 {
+	totalPower := int64(0)
+	for _, power := range k.powers {
+		totalPower += power
+	}
+	return totalPower
+}
+
+func TestPruneExpiredProphecies(t *testing.T) {
+	ctx, keeper, valAddrs, _ := setupProphecyTest(t)
+	enableProphecyKVStore()
+	defer sdk.UpgradeMgr.Reset()
+	expiryBlocks := int64(10)
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(6, 1), ProphecyExpiryBlocks: &expiryBlocks})
+	require.Equal(t, expiryBlocks, keeper.GetProphecyExpiryBlocks(ctx))
+
+	id1 := types.GetClaimId(1, types.RelayPackagesChannelId, 1)
+	id2 := types.GetClaimId(1, types.RelayPackagesChannelId, 2)
+	_, err := keeper.ProcessClaim(ctx.WithBlockHeight(5), types.NewClaim(id1, valAddrs[0], TestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx.WithBlockHeight(6), types.NewClaim(id2, valAddrs[0], TestString))
+	require.NoError(t, err)
+	// a later claim does not extend the expiry
+	_, err = keeper.ProcessClaim(ctx.WithBlockHeight(7), types.NewClaim(id1, valAddrs[1], AlternateTestString))
+	require.NoError(t, err)
+
+	require.Empty(t, keeper.PruneExpiredProphecies(ctx.WithBlockHeight(14)))
+	require.Len(t, keeper.GetPendingProphecies(ctx, 1, 0, 0), 2)
+
+	events := keeper.PruneExpiredProphecies(ctx.WithBlockHeight(15))
+	require.Len(t, events, 1)
+	require.Equal(t, types.EventTypeProphecyExpired, events[0].Type)
+	require.Equal(t, id1, string(events[0].Attributes[0].Value))
+	_, found := keeper.GetProphecy(ctx, id1)
+	require.False(t, found)
+	require.Empty(t, keeper.GetValidatorClaims(ctx, id1))
+
+	pending := keeper.GetPendingProphecies(ctx, 1, 0, 0)
+	require.Len(t, pending, 1)
+	require.Equal(t, uint64(2), pending[0].Sequence)
+	require.Equal(t, int64(6), pending[0].CreateHeight)
+
+	// a deleted prophecy leaves the queue as well
+	keeper.DeleteProphecy(ctx, id2)
+	require.Empty(t, keeper.PruneExpiredProphecies(ctx.WithBlockHeight(100)))
+}
+
+func TestUpgradeParams(t *testing.T) {
+	mapp, _, keeper, _, _, _, _ := getMockApp(t, 1)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	// the params of a live chain only have the consensus needed, which is the whole param set
+	consensusNeeded := types.DefaultConsensusNeeded
+	keeper.paramSpace.Set(ctx, types.ParamStoreKeyProphecyParams, &consensusNeeded)
+	var params types.Params
+	keeper.paramSpace.GetParamSet(ctx, &params)
+	require.Equal(t, consensusNeeded, params.ConsensusNeeded)
+
+	// the proof claim switch is not a key of the param set, it is only changed after the upgrade
+	enabled := true
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: consensusNeeded, ProofClaimEnabled: &enabled})
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProofClaim, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	require.False(t, keeper.IsProofClaimEnabled(ctx))
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: consensusNeeded, ProofClaimEnabled: &enabled})
+	require.True(t, keeper.IsProofClaimEnabled(ctx))
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: consensusNeeded})
+	require.True(t, keeper.IsProofClaimEnabled(ctx))
+	sdk.UpgradeMgr.Reset()
+
+	// the expiry blocks is not a key of the param set, it is only changed after the upgrade
+	expiryBlocks := int64(10)
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: consensusNeeded, ProphecyExpiryBlocks: &expiryBlocks})
+	require.Equal(t, types.DefaultProphecyExpiryBlocks, keeper.GetProphecyExpiryBlocks(ctx))
+	enableProphecyKVStore()
+	defer sdk.UpgradeMgr.Reset()
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: consensusNeeded, ProphecyExpiryBlocks: &expiryBlocks})
+	require.Equal(t, expiryBlocks, keeper.GetProphecyExpiryBlocks(ctx))
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: consensusNeeded})
+	require.Equal(t, expiryBlocks, keeper.GetProphecyExpiryBlocks(ctx))
+}
+
+func TestRecomputePendingProphecies(t *testing.T) {
+	ctx, keeper, valAddrs, _ := setupProphecyTest(t)
+	enableProphecyKVStore()
+	defer sdk.UpgradeMgr.Reset()
+
+	id1 := types.GetClaimId(1, types.RelayPackagesChannelId, 1)
+	id2 := types.GetClaimId(1, types.RelayPackagesChannelId, 2)
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(id1, valAddrs[0], TestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(id1, valAddrs[1], AlternateTestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(id2, valAddrs[0], TestString))
+	require.NoError(t, err)
+
+	// nothing changes with the same relayers
+	events, finalized := keeper.RecomputePendingProphecies(ctx)
+	require.Empty(t, events)
+	require.Empty(t, finalized)
+	require.Len(t, keeper.GetPendingProphecies(ctx, 1, 0, 0), 2)
+
+	// the third relayer loses most of its power, the split prophecy can never reach consensus
+	newPowers := map[string]int64{
+		valAddrs[0].String(): 5,
+		valAddrs[1].String(): 5,
+		valAddrs[2].String(): 1,
+	}
+	keeper.stakeKeeper = powerStakeKeeper{StakingKeeper: keeper.stakeKeeper, powers: newPowers}
+	events, finalized = keeper.RecomputePendingProphecies(ctx)
+	require.Len(t, events, 1)
+	require.Equal(t, types.EventTypeProphecyFailed, events[0].Type)
+	require.Equal(t, id1, string(events[0].Attributes[0].Value))
+
+	pending := keeper.GetPendingProphecies(ctx, 1, 0, 0)
+	require.Len(t, pending, 1)
+	require.Equal(t, uint64(2), pending[0].Sequence)
+	require.Equal(t, newPowers[valAddrs[0].String()], pending[0].ClaimsPower)
+	require.Equal(t, []types.ClaimTally{{Claim: TestString, Power: newPowers[valAddrs[0].String()]}}, pending[0].Tallies)
+
+	// the prophecies are not reweighed again while the power stays the same
+	keeper.DeleteProphecy(ctx, id2)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(id2, valAddrs[1], AlternateTestString))
+	require.NoError(t, err)
+	events, finalized = keeper.RecomputePendingProphecies(ctx)
+	require.Empty(t, events)
+	require.Empty(t, finalized)
+
+	// the prophecies are kept pending while no relayer has power
+	keeper.stakeKeeper = powerStakeKeeper{StakingKeeper: keeper.stakeKeeper, powers: map[string]int64{}}
+	events, finalized = keeper.RecomputePendingProphecies(ctx)
+	require.Empty(t, events)
+	require.Empty(t, finalized)
+	require.Len(t, keeper.GetPendingProphecies(ctx, 1, 0, 0), 1)
+
+	// the prophecy reaches consensus once the other relayers lose their power, it is finalized to be executed
+	newPowers = map[string]int64{
+		valAddrs[0].String(): 1,
+		valAddrs[1].String(): 5,
+		valAddrs[2].String(): 1,
+	}
+	keeper.stakeKeeper = powerStakeKeeper{StakingKeeper: keeper.stakeKeeper, powers: newPowers}
+	events, finalized = keeper.RecomputePendingProphecies(ctx)
+	require.Empty(t, events)
+	require.Len(t, finalized, 1)
+	require.Equal(t, id2, finalized[0].ID)
+	require.Equal(t, types.SuccessStatusText, finalized[0].Status.Text)
+	require.Equal(t, AlternateTestString, finalized[0].Status.FinalClaim)
+	require.Equal(t, []types.RewardShare{{Validator: valAddrs[1], Power: 5}}, keeper.GetRewardShares(ctx, finalized[0]))
+	require.Empty(t, keeper.GetPendingProphecies(ctx, 1, 0, 0))
+}
+
+func TestGetPendingProphecies(t *testing.T) {
+	ctx, keeper, valAddrs, _ := setupProphecyTest(t)
+	enableProphecyKVStore()
+	defer sdk.UpgradeMgr.Reset()
+
+	for sequence := uint64(1); sequence <= 4; sequence++ {
+		_, err := keeper.ProcessClaim(ctx, types.NewClaim(types.GetClaimId(1, types.RelayPackagesChannelId, sequence), valAddrs[0], TestString))
+		require.NoError(t, err)
+	}
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(types.GetClaimId(2, types.RelayPackagesChannelId, 2), valAddrs[0], TestString))
+	require.NoError(t, err)
+
+	sequences := func(prophecies []types.ProphecyResult) []uint64 {
+		result := make([]uint64, 0, len(prophecies))
+		for _, prophecy := range prophecies {
+			result = append(result, prophecy.Sequence)
+		}
+		return result
+	}
+	require.Equal(t, []uint64{1, 2, 3, 4}, sequences(keeper.GetPendingProphecies(ctx, 1, 0, 0)))
+	require.Equal(t, []uint64{3, 4}, sequences(keeper.GetPendingProphecies(ctx, 1, 3, 0)))
+	require.Equal(t, []uint64{2, 3}, sequences(keeper.GetPendingProphecies(ctx, 1, 2, 3)))
+	require.Equal(t, []uint64{2}, sequences(keeper.GetPendingProphecies(ctx, 2, 0, 0)))
+}
@@ -29,6 +29,7 @@ type Keeper struct {
 	ScKeeper    sidechain.Keeper
 	IbcKeeper   ibc.Keeper
 	BkKeeper    bank.Keeper
+	lcKeeper    types.LightClientKeeper
 
 	Metrics   *metrics.Metrics
 	pubServer *pubsub.Server
@@ -40,7 +41,10 @@ const (
 )
 
 func ParamTypeTable() param.TypeTable {
-	return param.NewTypeTable().RegisterParamSet(&types.Params{})
+	// the params written since the later upgrades are kept out of the param set, which is read as a whole
+	return param.NewTypeTable().RegisterParamSet(&types.Params{}).
+		RegisterType(types.ParamStoreKeyProphecyExpiryBlocks, int64(0)).
+		RegisterType(types.ParamStoreKeyProofClaimEnabled, false)
 }
 
 // NewKeeper creates new instances of the oracle Keeper
@@ -65,20 +69,67 @@ func (k Keeper) GetConsensusNeeded(ctx sdk.Context) (consensusNeeded sdk.Dec) {
 	return
 }
 
+// GetOracleRelayersPower returns the power of the current oracle relayers keyed by validator address
+func (k Keeper) GetOracleRelayersPower(ctx sdk.Context) map[string]int64 {
+	return k.stakeKeeper.GetOracleRelayersPower(ctx)
+}
+
+// IsProofClaimEnabled returns whether packages can be delivered with receipt proofs, it is disabled
+// until the param is set by a param change proposal after the upgrade ProofClaim
+func (k Keeper) IsProofClaimEnabled(ctx sdk.Context) (enabled bool) {
+	if !sdk.IsUpgrade(sdk.ProofClaim) {
+		return false
+	}
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyProofClaimEnabled, &enabled)
+	return
+}
+
+// SetProofClaimEnabled sets whether packages can be delivered with receipt proofs
+func (k Keeper) SetProofClaimEnabled(ctx sdk.Context, enabled bool) {
+	k.paramSpace.Set(ctx, types.ParamStoreKeyProofClaimEnabled, &enabled)
+}
+
 func (k *Keeper) EnablePrometheusMetrics() {
 	k.Metrics = metrics.PrometheusMetrics()
 }
 
 func (k *Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.paramSpace.SetParamSet(ctx, &params)
+	if params.ProphecyExpiryBlocks != nil && sdk.IsUpgrade(sdk.ProphecyKVStore) {
+		k.SetProphecyExpiryBlocks(ctx, *params.ProphecyExpiryBlocks)
+	}
+	if params.ProofClaimEnabled != nil && sdk.IsUpgrade(sdk.ProofClaim) {
+		k.SetProofClaimEnabled(ctx, *params.ProofClaimEnabled)
+	}
 }
 
 func (k *Keeper) SetPbsbServer(p *pubsub.Server) {
 	k.pubServer = p
 }
 
+func (k *Keeper) SetLightClientKeeper(lcKeeper types.LightClientKeeper) {
+	k.lcKeeper = lcKeeper
+}
+
 // GetProphecy gets the entire prophecy data struct for a given id
 func (k Keeper) GetProphecy(ctx sdk.Context, id string) (types.Prophecy, bool) {
+	if sdk.IsUpgrade(sdk.ProphecyKVStore) {
+		return k.getProphecy(ctx, id)
+	}
+	return k.getLegacyProphecy(ctx, id)
+}
+
+// DeleteProphecy delete prophecy for a given id
+func (k Keeper) DeleteProphecy(ctx sdk.Context, id string) {
+	if sdk.IsUpgrade(sdk.ProphecyKVStore) {
+		k.deleteProphecy(ctx, id)
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete([]byte(id))
+}
+
+func (k Keeper) getLegacyProphecy(ctx sdk.Context, id string) (types.Prophecy, bool) {
 	store := ctx.KVStore(k.storeKey)
 	bz := store.Get([]byte(id))
 	if bz == nil {
@@ -96,14 +147,8 @@ func (k Keeper) GetProphecy(ctx sdk.Context, id string) (types.Prophecy, bool) {
 	return deSerializedProphecy, true
 }
 
-// DeleteProphecy delete prophecy for a given id
-func (k Keeper) DeleteProphecy(ctx sdk.Context, id string) {
-	store := ctx.KVStore(k.storeKey)
-	store.Delete([]byte(id))
-}
-
-// setProphecy saves a prophecy with an initial claim
-func (k Keeper) setProphecy(ctx sdk.Context, prophecy types.Prophecy) {
+// setLegacyProphecy saves a prophecy with an initial claim
+func (k Keeper) setLegacyProphecy(ctx sdk.Context, prophecy types.Prophecy) {
 	store := ctx.KVStore(k.storeKey)
 	serializedProphecy, err := prophecy.SerializeForDB()
 	if err != nil {
@@ -128,7 +173,11 @@ func (k Keeper) ProcessClaim(ctx sdk.Context, claim types.Claim) (types.Prophecy
 		return types.Prophecy{}, types.ErrInvalidClaim()
 	}
 
-	prophecy, found := k.GetProphecy(ctx, claim.ID)
+	if sdk.IsUpgrade(sdk.ProphecyKVStore) {
+		return k.processClaim(ctx, claim)
+	}
+
+	prophecy, found := k.getLegacyProphecy(ctx, claim.ID)
 	if !found {
 		prophecy = types.NewProphecy(claim.ID)
 	}
@@ -143,7 +192,7 @@ func (k Keeper) ProcessClaim(ctx sdk.Context, claim types.Claim) (types.Prophecy
 	prophecy.AddClaim(claim.ValidatorAddress, claim.Payload)
 	prophecy = k.processCompletion(ctx, prophecy)
 
-	k.setProphecy(ctx, prophecy)
+	k.setLegacyProphecy(ctx, prophecy)
 	return prophecy, nil
 }
 
@@ -154,7 +203,15 @@ func (k Keeper) ProcessClaim(ctx sdk.Context, claim types.Claim) (types.Prophecy
 // left to push it over the threshold required for consensus.
 func (k Keeper) processCompletion(ctx sdk.Context, prophecy types.Prophecy) types.Prophecy {
 	highestClaim, highestClaimPower, totalClaimsPower, totalPower := prophecy.FindHighestClaim(ctx, k.stakeKeeper)
+	prophecy.Status = k.completeStatus(ctx, prophecy.Status, highestClaim, highestClaimPower, totalClaimsPower, totalPower)
+	return prophecy
+}
 
+func (k Keeper) completeStatus(ctx sdk.Context, status types.Status, highestClaim string, highestClaimPower, totalClaimsPower, totalPower int64) types.Status {
+	// the prophecy stays as it is until there are oracle relayers again
+	if sdk.IsUpgrade(sdk.ProphecyKVStore) && totalPower <= 0 {
+		return status
+	}
 	highestConsensusRatio := sdk.NewDec(highestClaimPower).Quo(sdk.NewDec(totalPower))
 	remainingPossibleClaimPower := totalPower - totalClaimsPower
 	highestPossibleClaimPower := highestClaimPower + remainingPossibleClaimPower
@@ -164,12 +221,12 @@ func (k Keeper) processCompletion(ctx sdk.Context, prophecy types.Prophecy) type
 	consensusNeeded := k.GetConsensusNeeded(ctx)
 
 	if highestConsensusRatio.GTE(consensusNeeded) {
-		prophecy.Status.Text = types.SuccessStatusText
-		prophecy.Status.FinalClaim = highestClaim
+		status.Text = types.SuccessStatusText
+		status.FinalClaim = highestClaim
 	} else if highestPossibleConsensusRatio.LT(consensusNeeded) {
-		prophecy.Status.Text = types.FailedStatusText
+		status.Text = types.FailedStatusText
 	}
-	return prophecy
+	return status
 }
 
 func (k *Keeper) SubscribeParamChange(hub pTypes.ParamChangePublisher) {
@@ -198,15 +255,21 @@ func (k *Keeper) SubscribeParamChange(hub pTypes.ParamChangePublisher) {
 	)
 }
 
-func (k *Keeper) PublishCrossAppFailEvent(ctx sdk.Context, from string, relayerFee int64, chainId string) {
+func (k *Keeper) PublishCrossAppFailEvent(ctx sdk.Context, from string, relayerFee int64, chainId string,
+	info sdk.CrossChainPackageInfo, packageType sdk.CrossChainPackageType, result sdk.ExecuteResult) {
 	if k.pubServer != nil {
 		txHash := ctx.Value(baseapp.TxHashKey)
 		if txHashStr, ok := txHash.(string); ok {
 			event := types.CrossAppFailEvent{
-				TxHash:     txHashStr,
-				ChainId:    chainId,
-				RelayerFee: relayerFee,
-				From:       from,
+				TxHash:      txHashStr,
+				ChainId:     chainId,
+				RelayerFee:  relayerFee,
+				From:        from,
+				ChannelId:   info.ChannelID,
+				Sequence:    info.Sequence,
+				PackageType: packageType,
+				Code:        uint32(result.Code()),
+				Msg:         result.Msg(),
 			}
 			k.pubServer.Publish(event)
 		} else {
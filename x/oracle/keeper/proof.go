@@ -0,0 +1,86 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/axc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// ProcessClaimWithProof verifies the receipts of the block of a claim against the side chain headers tracked
+// by the light client, and returns the packages to execute, which are all the crossChainPackage events of the
+// oracle sequence of the claim in the block.
+func (k Keeper) ProcessClaimWithProof(ctx sdk.Context, msg types.ClaimWithProofMsg) (types.Packages, sdk.Error) {
+	if !k.IsProofClaimEnabled(ctx) || k.lcKeeper == nil {
+		return nil, types.ErrProofClaimDisabled()
+	}
+
+	if !k.stakeKeeper.CheckIsValidOracleRelayer(ctx, sdk.ValAddress(msg.ValidatorAddress)) {
+		return nil, types.ErrInvalidValidator()
+	}
+
+	sideChainId, err := k.ScKeeper.GetDestChainName(msg.ChainId)
+	if err != nil {
+		return nil, types.ErrInvalidPackageProof(err.Error())
+	}
+
+	receipts, sdkErr := k.verifyBlockProof(ctx, sideChainId, msg.Proof)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	packages := make(types.Packages, 0)
+	for _, receipt := range receipts {
+		if receipt.Failed() {
+			continue
+		}
+		for _, log := range receipt.Logs {
+			if !bytes.Equal(log.Address.Bytes(), types.CrossChainContractAddr.Bytes()) ||
+				len(log.Topics) == 0 || log.Topics[0].Cmp(types.CrossChainPackageEventSig) != 0 {
+				continue
+			}
+			event, err := types.DecodeCrossChainPackageEvent(log)
+			if err != nil {
+				return nil, types.ErrInvalidPackageProof(err.Error())
+			}
+			if event.ChainId != uint16(msg.ChainId) || event.OracleSequence != msg.Sequence {
+				continue
+			}
+			packages = append(packages, types.Package{
+				ChannelId: sdk.ChannelID(event.ChannelId),
+				Sequence:  event.PackageSequence,
+				Payload:   event.Payload,
+			})
+		}
+	}
+	if len(packages) == 0 {
+		return nil, types.ErrInvalidPackageProof(fmt.Sprintf("block %d has no package of oracle sequence %d",
+			msg.Proof.HeaderNumber, msg.Sequence))
+	}
+	return packages, nil
+}
+
+// verifyBlockProof returns the receipts of all the transactions of a block which is verified by the light client
+// and confirmed by ProofConfirmations headers of the canonical chain
+func (k Keeper) verifyBlockProof(ctx sdk.Context, sideChainId string, proof types.BlockProof) ([]*axc.Receipt, sdk.Error) {
+	latest, found := k.lcKeeper.GetLatestNumber(ctx, sideChainId)
+	if !found {
+		return nil, types.ErrInvalidPackageProof(fmt.Sprintf("light client of side chain %s is not found", sideChainId))
+	}
+	if proof.HeaderNumber+types.ProofConfirmations > latest {
+		return nil, types.ErrInvalidPackageProof(fmt.Sprintf("header %d is not confirmed by %d headers, the latest header is %d",
+			proof.HeaderNumber, types.ProofConfirmations, latest))
+	}
+	header, found := k.lcKeeper.GetHeader(ctx, sideChainId, proof.HeaderNumber)
+	if !found {
+		return nil, types.ErrInvalidPackageProof(fmt.Sprintf("header %d is not verified by light client", proof.HeaderNumber))
+	}
+
+	receipts, err := axc.VerifyBlockReceipts(header.ReceiptHash, proof.TxCount, proof.ReceiptProof)
+	if err != nil {
+		return nil, types.ErrInvalidPackageProof(err.Error())
+	}
+	return receipts, nil
+}
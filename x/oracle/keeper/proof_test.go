@@ -0,0 +1,160 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/axc"
+	"github.com/cosmos/cosmos-sdk/axc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+const (
+	testSideChainId = "axc"
+	testChainId     = sdk.ChainID(56)
+)
+
+type mockLightClientKeeper struct {
+	latest  int64
+	headers map[int64]axc.Header
+}
+
+func (k mockLightClientKeeper) GetHeader(_ sdk.Context, sideChainId string, number int64) (axc.Header, bool) {
+	if sideChainId != testSideChainId {
+		return axc.Header{}, false
+	}
+	header, found := k.headers[number]
+	return header, found
+}
+
+func (k mockLightClientKeeper) GetLatestNumber(_ sdk.Context, sideChainId string) (int64, bool) {
+	if sideChainId != testSideChainId {
+		return 0, false
+	}
+	return k.latest, true
+}
+
+func newPackageLog(oracleSequence uint64, pack types.Package) *axc.Log {
+	return types.CrossChainPackageEvent{
+		ChainId:         uint16(testChainId),
+		OracleSequence:  oracleSequence,
+		PackageSequence: pack.Sequence,
+		ChannelId:       uint8(pack.ChannelId),
+		Payload:         pack.Payload,
+	}.ToLog()
+}
+
+// newBlockProof builds a block whose only receipt contains the logs
+func newBlockProof(t *testing.T, logs []*axc.Log, number int64) (axc.Header, types.BlockProof) {
+	receipt := &axc.Receipt{
+		PostStateOrStatus: []byte{0x01},
+		CumulativeGasUsed: 21000,
+		Bloom:             axc.LogsBloom(logs),
+		Logs:              logs,
+	}
+	encodedReceipt, err := rlp.EncodeToBytes(receipt)
+	require.NoError(t, err)
+	// the trie of a single receipt is a leaf of the whole key rlp(0) = 0x80
+	leaf, err := rlp.EncodeToBytes([]interface{}{[]byte{0x20, 0x80}, encodedReceipt})
+	require.NoError(t, err)
+
+	header := axc.Header{
+		Number:      number,
+		ReceiptHash: axc.BytesToHash(axc.Keccak256(leaf)),
+		Bloom:       receipt.Bloom,
+	}
+	return header, types.BlockProof{
+		HeaderNumber: number,
+		TxCount:      1,
+		ReceiptProof: [][]byte{leaf},
+	}
+}
+
+func TestProcessClaimWithProof(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 2)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := []sdk.ValAddress{sdk.ValAddress(addrs[0])}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5})
+	stake.EndBlocker(ctx, sk)
+	require.NoError(t, keeper.ScKeeper.RegisterDestChain(testSideChainId, testChainId))
+
+	pack := types.Package{ChannelId: 2, Sequence: 3, Payload: []byte("a cross chain package payload longer than its header")}
+	otherPack := types.Package{ChannelId: 3, Sequence: 1, Payload: []byte("another cross chain package payload of the block")}
+	nextPack := types.Package{ChannelId: 2, Sequence: 4, Payload: []byte("a package of the next oracle sequence")}
+	notContract := newPackageLog(1, nextPack)
+	notContract.Address = axc.Address{19: 0x01}
+	header, proof := newBlockProof(t, []*axc.Log{newPackageLog(1, pack), notContract, newPackageLog(1, otherPack), newPackageLog(2, nextPack)}, 100)
+	msg := types.NewClaimWithProofMsg(testChainId, 1, proof, addrs[0])
+	require.NoError(t, msg.ValidateBasic())
+
+	// disabled by default
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1)})
+	_, err := keeper.ProcessClaimWithProof(ctx, msg)
+	require.Equal(t, types.CodeProofClaimDisabled, err.Code())
+
+	// disabled before the upgrade even if the param is given
+	enabled := true
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1), ProofClaimEnabled: &enabled})
+	_, err = keeper.ProcessClaimWithProof(ctx, msg)
+	require.Equal(t, types.CodeProofClaimDisabled, err.Code())
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProofClaim, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer sdk.UpgradeMgr.Reset()
+
+	// still disabled until the param is set after the upgrade
+	_, err = keeper.ProcessClaimWithProof(ctx, msg)
+	require.Equal(t, types.CodeProofClaimDisabled, err.Code())
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1), ProofClaimEnabled: &enabled})
+
+	// disabled without the light client
+	_, err = keeper.ProcessClaimWithProof(ctx, msg)
+	require.Equal(t, types.CodeProofClaimDisabled, err.Code())
+
+	headers := map[int64]axc.Header{100: header}
+	keeper.SetLightClientKeeper(mockLightClientKeeper{latest: 100 + types.ProofConfirmations - 1, headers: headers})
+	_, err = keeper.ProcessClaimWithProof(ctx, msg)
+	require.Equal(t, types.CodeInvalidPackageProof, err.Code(), "header is not confirmed")
+
+	keeper.SetLightClientKeeper(mockLightClientKeeper{latest: 100 + types.ProofConfirmations, headers: map[int64]axc.Header{}})
+	_, err = keeper.ProcessClaimWithProof(ctx, msg)
+	require.Equal(t, types.CodeInvalidPackageProof, err.Code(), "header is not verified")
+
+	// all the packages of the oracle sequence emitted by the contract in the block are delivered
+	keeper.SetLightClientKeeper(mockLightClientKeeper{latest: 100 + types.ProofConfirmations, headers: headers})
+	packages, err := keeper.ProcessClaimWithProof(ctx, msg)
+	require.Nil(t, err)
+	require.Equal(t, types.Packages{pack, otherPack}, packages)
+
+	packages, err = keeper.ProcessClaimWithProof(ctx, types.NewClaimWithProofMsg(testChainId, 2, proof, addrs[0]))
+	require.Nil(t, err)
+	require.Equal(t, types.Packages{nextPack}, packages)
+
+	// not a relayer
+	_, err = keeper.ProcessClaimWithProof(ctx, types.NewClaimWithProofMsg(testChainId, 1, proof, addrs[1]))
+	require.Equal(t, types.CodeInvalidValidator, err.Code())
+
+	// no package of the oracle sequence in the block
+	_, err = keeper.ProcessClaimWithProof(ctx, types.NewClaimWithProofMsg(testChainId, 3, proof, addrs[0]))
+	require.Equal(t, types.CodeInvalidPackageProof, err.Code())
+
+	// the block has less transactions than claimed
+	moreTxs := proof
+	moreTxs.TxCount = 2
+	_, err = keeper.ProcessClaimWithProof(ctx, types.NewClaimWithProofMsg(testChainId, 1, moreTxs, addrs[0]))
+	require.Equal(t, types.CodeInvalidPackageProof, err.Code())
+
+	// the proof does not match the receipt root
+	badProof := proof
+	badProof.ReceiptProof = [][]byte{append([]byte{}, proof.ReceiptProof[0][:10]...)}
+	_, err = keeper.ProcessClaimWithProof(ctx, types.NewClaimWithProofMsg(testChainId, 1, badProof, addrs[0]))
+	require.Equal(t, types.CodeInvalidPackageProof, err.Code())
+}
@@ -0,0 +1,321 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// getProphecy assembles a prophecy from the deterministic store, the claims are read in the order of
+// validator address
+func (k Keeper) getProphecy(ctx sdk.Context, id string) (types.Prophecy, bool) {
+	info, found := k.getProphecyInfo(ctx, id)
+	if !found {
+		return types.Prophecy{}, false
+	}
+
+	prophecy := types.NewProphecy(id)
+	prophecy.Status = info.Status
+	for _, claim := range k.GetValidatorClaims(ctx, id) {
+		prophecy.ValidatorClaims[claim.Validator.String()] = claim.Claim
+		prophecy.ClaimValidators[claim.Claim] = append(prophecy.ClaimValidators[claim.Claim], claim.Validator)
+	}
+	return prophecy, true
+}
+
+func (k Keeper) getProphecyInfo(ctx sdk.Context, id string) (types.ProphecyInfo, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetProphecyKey(id))
+	if bz == nil {
+		return types.ProphecyInfo{}, false
+	}
+
+	var info types.ProphecyInfo
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &info)
+	return info, true
+}
+
+func (k Keeper) setProphecyInfo(ctx sdk.Context, info types.ProphecyInfo) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetProphecyKey(info.ID), k.cdc.MustMarshalBinaryLengthPrefixed(info))
+}
+
+// GetValidatorClaims returns the claims of a prophecy sorted by validator address
+func (k Keeper) GetValidatorClaims(ctx sdk.Context, id string) []types.ValidatorClaim {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetProphecyClaimsKey(id))
+	defer iterator.Close()
+
+	claims := make([]types.ValidatorClaim, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var claim types.ValidatorClaim
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &claim)
+		claims = append(claims, claim)
+	}
+	return claims
+}
+
+func (k Keeper) getValidatorClaim(ctx sdk.Context, id string, validator sdk.ValAddress) (types.ValidatorClaim, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetProphecyClaimKey(id, validator))
+	if bz == nil {
+		return types.ValidatorClaim{}, false
+	}
+
+	var claim types.ValidatorClaim
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &claim)
+	return claim, true
+}
+
+func (k Keeper) setValidatorClaim(ctx sdk.Context, id string, claim types.ValidatorClaim) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetProphecyClaimKey(id, claim.Validator), k.cdc.MustMarshalBinaryLengthPrefixed(claim))
+}
+
+// GetClaimTallies returns the power tallies of the claims of a prophecy
+func (k Keeper) GetClaimTallies(ctx sdk.Context, id string) []types.ClaimTally {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetProphecyTalliesKey(id))
+	defer iterator.Close()
+
+	tallies := make([]types.ClaimTally, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var tally types.ClaimTally
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &tally)
+		tallies = append(tallies, tally)
+	}
+	return tallies
+}
+
+// addClaimTally adds the power to the tally of a claim, the tally is removed when no power is left
+func (k Keeper) addClaimTally(ctx sdk.Context, id string, claim string, power int64) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetProphecyTallyKey(id, claim)
+
+	tally := types.ClaimTally{Claim: claim}
+	if bz := store.Get(key); bz != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &tally)
+	}
+	tally.Power += power
+	if tally.Power <= 0 {
+		store.Delete(key)
+		return
+	}
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(tally))
+}
+
+func (k Keeper) deleteProphecy(ctx sdk.Context, id string) {
+	store := ctx.KVStore(k.storeKey)
+	if info, found := k.getProphecyInfo(ctx, id); found {
+		store.Delete(types.GetProphecyQueueKey(info.CreateHeight, id))
+	}
+	store.Delete(types.GetProphecyKey(id))
+	deleteKeysWithPrefix(store, types.GetProphecyClaimsKey(id))
+	deleteKeysWithPrefix(store, types.GetProphecyTalliesKey(id))
+}
+
+func deleteKeysWithPrefix(store sdk.KVStore, prefix []byte) {
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	keys := make([][]byte, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// processClaim records the claim of a validator and updates the tallies of the prophecy incrementally,
+// the power of the validator is taken when the claim is made.
+func (k Keeper) processClaim(ctx sdk.Context, claim types.Claim) (types.Prophecy, sdk.Error) {
+	info, found := k.getProphecyInfo(ctx, claim.ID)
+	if !found {
+		info = k.newProphecyInfo(ctx, claim.ID)
+	}
+
+	if info.Status.Text != types.PendingStatusText {
+		return types.Prophecy{}, types.ErrProphecyFinalized()
+	}
+
+	relayersPower := k.stakeKeeper.GetOracleRelayersPower(ctx)
+	power := relayersPower[claim.ValidatorAddress.String()]
+
+	// a validator can replace its claim, the power of its previous claim is withdrawn
+	if previous, found := k.getValidatorClaim(ctx, claim.ID, claim.ValidatorAddress); found {
+		k.addClaimTally(ctx, claim.ID, previous.Claim, -previous.Power)
+		info.ClaimsPower -= previous.Power
+	}
+	k.setValidatorClaim(ctx, claim.ID, types.ValidatorClaim{
+		Validator: claim.ValidatorAddress,
+		Claim:     claim.Payload,
+		Power:     power,
+	})
+	k.addClaimTally(ctx, claim.ID, claim.Payload, power)
+	info.ClaimsPower += power
+
+	highestClaim, highestClaimPower := k.findHighestTally(ctx, claim.ID)
+	info.Status = k.completeStatus(ctx, info.Status, highestClaim, highestClaimPower, info.ClaimsPower, k.getTotalPower(ctx, relayersPower))
+	k.setProphecyInfo(ctx, info)
+
+	prophecy, _ := k.getProphecy(ctx, claim.ID)
+	return prophecy, nil
+}
+
+func (k Keeper) newProphecyInfo(ctx sdk.Context, id string) types.ProphecyInfo {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetProphecyQueueKey(ctx.BlockHeight(), id), []byte(id))
+	return types.ProphecyInfo{
+		ID:           id,
+		Status:       types.NewStatus(types.PendingStatusText, ""),
+		CreateHeight: ctx.BlockHeight(),
+	}
+}
+
+// findHighestTally returns the claim with the highest power, ties are broken by the smaller claim
+func (k Keeper) findHighestTally(ctx sdk.Context, id string) (string, int64) {
+	highestClaim := ""
+	highestClaimPower := int64(-1)
+	for _, tally := range k.GetClaimTallies(ctx, id) {
+		if tally.Power > highestClaimPower || (tally.Power == highestClaimPower && tally.Claim < highestClaim) {
+			highestClaim = tally.Claim
+			highestClaimPower = tally.Power
+		}
+	}
+	return highestClaim, highestClaimPower
+}
+
+func (k Keeper) getTotalPower(ctx sdk.Context, relayersPower map[string]int64) int64 {
+	if !sdk.IsUpgrade(sdk.BEP159Phase2) {
+		return k.stakeKeeper.GetLastTotalPower(ctx)
+	}
+	totalPower := int64(0)
+	for _, power := range relayersPower {
+		totalPower += power
+	}
+	return totalPower
+}
+
+// GetProphecyResult returns the query result of a prophecy, the claims of a legacy prophecy are
+// weighted by the current power of the oracle relayers
+func (k Keeper) GetProphecyResult(ctx sdk.Context, id string) (types.ProphecyResult, bool) {
+	if sdk.IsUpgrade(sdk.ProphecyKVStore) {
+		info, found := k.getProphecyInfo(ctx, id)
+		if !found {
+			return types.ProphecyResult{}, false
+		}
+		result, err := k.prophecyResult(ctx, info)
+		return result, err == nil
+	}
+
+	prophecy, found := k.getLegacyProphecy(ctx, id)
+	if !found {
+		return types.ProphecyResult{}, false
+	}
+	chainId, _, sequence, err := types.ParseClaimId(id)
+	if err != nil {
+		return types.ProphecyResult{}, false
+	}
+	relayersPower := k.stakeKeeper.GetOracleRelayersPower(ctx)
+	result := types.ProphecyResult{ChainId: chainId, Sequence: sequence, Status: prophecy.Status}
+	for validator, claim := range prophecy.ValidatorClaims {
+		valAddr, err := sdk.ValAddressFromBech32(validator)
+		if err != nil {
+			return types.ProphecyResult{}, false
+		}
+		power := relayersPower[validator]
+		result.Claims = append(result.Claims, types.ValidatorClaim{Validator: valAddr, Claim: claim, Power: power})
+		result.ClaimsPower += power
+	}
+	sort.Slice(result.Claims, func(i, j int) bool {
+		return bytes.Compare(result.Claims[i].Validator, result.Claims[j].Validator) < 0
+	})
+
+	tallies := make(map[string]int64)
+	for _, claim := range result.Claims {
+		if _, exist := tallies[claim.Claim]; !exist {
+			result.Tallies = append(result.Tallies, types.ClaimTally{Claim: claim.Claim})
+		}
+		tallies[claim.Claim] += claim.Power
+	}
+	for i := range result.Tallies {
+		result.Tallies[i].Power = tallies[result.Tallies[i].Claim]
+	}
+	return result, true
+}
+
+func (k Keeper) prophecyResult(ctx sdk.Context, info types.ProphecyInfo) (types.ProphecyResult, error) {
+	chainId, _, sequence, err := types.ParseClaimId(info.ID)
+	if err != nil {
+		return types.ProphecyResult{}, err
+	}
+	return types.ProphecyResult{
+		ChainId:      chainId,
+		Sequence:     sequence,
+		Status:       info.Status,
+		CreateHeight: info.CreateHeight,
+		ClaimsPower:  info.ClaimsPower,
+		Claims:       k.GetValidatorClaims(ctx, info.ID),
+		Tallies:      k.GetClaimTallies(ctx, info.ID),
+	}, nil
+}
+
+// MigrateLegacyProphecies moves the prophecies stored as DBProphecy under the raw prophecy id into
+// the deterministic store, the claims are weighted by the current power of the oracle relayers and
+// the expiry of the prophecies starts from the migration.
+func (k Keeper) MigrateLegacyProphecies(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(nil, nil)
+	legacyKeys := make([][]byte, 0)
+	legacyValues := make([][]byte, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		if !types.IsLegacyProphecyKey(key) {
+			continue
+		}
+		legacyKeys = append(legacyKeys, key)
+		legacyValues = append(legacyValues, iterator.Value())
+	}
+	iterator.Close()
+
+	relayersPower := k.stakeKeeper.GetOracleRelayersPower(ctx)
+	for i, key := range legacyKeys {
+		var dbProphecy types.DBProphecy
+		k.cdc.MustUnmarshalBinaryBare(legacyValues[i], &dbProphecy)
+
+		var validatorClaims map[string]string
+		if err := json.Unmarshal(dbProphecy.ValidatorClaims, &validatorClaims); err != nil {
+			panic(fmt.Errorf("unmarshal claims of prophecy %s err: %v", dbProphecy.ID, err))
+		}
+		validators := make([]string, 0, len(validatorClaims))
+		for validator := range validatorClaims {
+			validators = append(validators, validator)
+		}
+		sort.Strings(validators)
+
+		info := k.newProphecyInfo(ctx, dbProphecy.ID)
+		info.Status = dbProphecy.Status
+		for _, validator := range validators {
+			valAddr, err := sdk.ValAddressFromBech32(validator)
+			if err != nil {
+				panic(fmt.Errorf("unmarshal validator address err, address=%s", validator))
+			}
+			power := relayersPower[validator]
+			k.setValidatorClaim(ctx, info.ID, types.ValidatorClaim{
+				Validator: valAddr,
+				Claim:     validatorClaims[validator],
+				Power:     power,
+			})
+			k.addClaimTally(ctx, info.ID, validatorClaims[validator], power)
+			info.ClaimsPower += power
+		}
+		k.setProphecyInfo(ctx, info)
+		store.Delete(key)
+	}
+	ctx.Logger().With("module", "oracle").Info("migrated legacy prophecies", "count", len(legacyKeys))
+}
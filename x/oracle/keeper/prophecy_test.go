@@ -0,0 +1,153 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func setupProphecyTest(t *testing.T) (sdk.Context, Keeper, []sdk.ValAddress, map[string]int64) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5, 5})
+	stake.EndBlocker(ctx, sk)
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(6, 1)})
+
+	return ctx, keeper, valAddrs, sk.GetOracleRelayersPower(ctx)
+}
+
+func enableProphecyKVStore() {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProphecyKVStore, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+}
+
+func TestProphecyKVStore(t *testing.T) {
+	ctx, keeper, valAddrs, powers := setupProphecyTest(t)
+	enableProphecyKVStore()
+	defer sdk.UpgradeMgr.Reset()
+	power := powers[valAddrs[0].String()]
+
+	prophecy, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], AlternateTestString))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+	require.Equal(t, TestString, prophecy.ValidatorClaims[valAddrs[0].String()])
+	require.Equal(t, AlternateTestString, prophecy.ValidatorClaims[valAddrs[1].String()])
+	require.ElementsMatch(t, []types.ClaimTally{
+		{Claim: TestString, Power: power},
+		{Claim: AlternateTestString, Power: power},
+	}, keeper.GetClaimTallies(ctx, TestID))
+
+	// the legacy store is not touched
+	require.Nil(t, ctx.KVStore(keeper.storeKey).Get([]byte(TestID)))
+
+	// the second validator changes its mind, the tally of its previous claim is withdrawn
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+	require.Equal(t, TestString, prophecy.Status.FinalClaim)
+	require.Equal(t, []types.ClaimTally{{Claim: TestString, Power: 2 * power}}, keeper.GetClaimTallies(ctx, TestID))
+
+	claims := keeper.GetValidatorClaims(ctx, TestID)
+	require.Len(t, claims, 2)
+	require.True(t, bytes.Compare(claims[0].Validator, claims[1].Validator) < 0, "claims should be sorted by validator")
+
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[2], TestString))
+	require.Error(t, err)
+
+	keeper.DeleteProphecy(ctx, TestID)
+	_, found := keeper.GetProphecy(ctx, TestID)
+	require.False(t, found)
+	require.Empty(t, keeper.GetValidatorClaims(ctx, TestID))
+	require.Empty(t, keeper.GetClaimTallies(ctx, TestID))
+}
+
+func TestProphecyKVStoreIsolatesIds(t *testing.T) {
+	ctx, keeper, valAddrs, _ := setupProphecyTest(t)
+	enableProphecyKVStore()
+	defer sdk.UpgradeMgr.Reset()
+
+	// "1:0:1" is a prefix of "1:0:12", their claims must not be mixed
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim("1:0:1", valAddrs[0], TestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim("1:0:12", valAddrs[1], TestString))
+	require.NoError(t, err)
+
+	require.Len(t, keeper.GetValidatorClaims(ctx, "1:0:1"), 1)
+	require.Len(t, keeper.GetValidatorClaims(ctx, "1:0:12"), 1)
+
+	keeper.DeleteProphecy(ctx, "1:0:1")
+	_, found := keeper.GetProphecy(ctx, "1:0:12")
+	require.True(t, found)
+}
+
+func TestMigrateLegacyProphecies(t *testing.T) {
+	ctx, keeper, valAddrs, powers := setupProphecyTest(t)
+	defer sdk.UpgradeMgr.Reset()
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], AlternateTestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(AlternateTestID, valAddrs[2], TestString))
+	require.NoError(t, err)
+	queryID := types.GetClaimId(1, types.RelayPackagesChannelId, 1)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(queryID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(queryID, valAddrs[1], AlternateTestString))
+	require.NoError(t, err)
+	legacy, found := keeper.GetProphecy(ctx, TestID)
+	require.True(t, found)
+	legacyResult, found := keeper.GetProphecyResult(ctx, queryID)
+	require.True(t, found)
+	require.Len(t, legacyResult.Claims, 2)
+
+	enableProphecyKVStore()
+	keeper.MigrateLegacyProphecies(ctx)
+
+	require.Nil(t, ctx.KVStore(keeper.storeKey).Get([]byte(TestID)))
+	require.Nil(t, ctx.KVStore(keeper.storeKey).Get([]byte(AlternateTestID)))
+
+	migrated, found := keeper.GetProphecy(ctx, TestID)
+	require.True(t, found)
+	require.Equal(t, legacy.Status, migrated.Status)
+	require.Equal(t, legacy.ValidatorClaims, migrated.ValidatorClaims)
+	require.ElementsMatch(t, []types.ClaimTally{
+		{Claim: TestString, Power: powers[valAddrs[0].String()]},
+		{Claim: AlternateTestString, Power: powers[valAddrs[1].String()]},
+	}, keeper.GetClaimTallies(ctx, TestID))
+
+	_, found = keeper.GetProphecy(ctx, AlternateTestID)
+	require.True(t, found)
+
+	// the query result is the same in both stores
+	result, found := keeper.GetProphecyResult(ctx, queryID)
+	require.True(t, found)
+	require.Equal(t, legacyResult.Claims, result.Claims)
+	require.ElementsMatch(t, legacyResult.Tallies, result.Tallies)
+	require.Equal(t, legacyResult.ClaimsPower, result.ClaimsPower)
+	require.Equal(t, uint64(1), result.Sequence)
+
+	// the migrated prophecy continues to collect claims
+	prophecy, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[2], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+	require.Equal(t, TestString, prophecy.Status.FinalClaim)
+}
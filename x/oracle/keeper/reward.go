@@ -0,0 +1,137 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// GetRelayerReward returns the relay fees accrued to a relayer and not yet withdrawn
+func (k Keeper) GetRelayerReward(ctx sdk.Context, validator sdk.ValAddress) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetRelayerRewardKey(validator))
+	if bz == nil {
+		return 0
+	}
+
+	var amount int64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &amount)
+	return amount
+}
+
+func (k Keeper) setRelayerReward(ctx sdk.Context, validator sdk.ValAddress, amount int64) {
+	store := ctx.KVStore(k.storeKey)
+	if amount == 0 {
+		store.Delete(types.GetRelayerRewardKey(validator))
+		return
+	}
+	store.Set(types.GetRelayerRewardKey(validator), k.cdc.MustMarshalBinaryLengthPrefixed(amount))
+}
+
+// GetAllRelayerRewards returns the accrued relay fees of all the relayers sorted by validator address
+func (k Keeper) GetAllRelayerRewards(ctx sdk.Context) []types.RelayerReward {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.RelayerRewardKeyPrefix)
+	defer iterator.Close()
+
+	rewards := make([]types.RelayerReward, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var amount int64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &amount)
+		rewards = append(rewards, types.RelayerReward{
+			Validator: sdk.ValAddress(iterator.Key()[len(types.RelayerRewardKeyPrefix):]),
+			Amount:    amount,
+		})
+	}
+	return rewards
+}
+
+// GetRewardShares returns the validators which voted for the final claim of a successful prophecy, weighted by
+// the power they claimed with, or by their current power for a legacy prophecy
+func (k Keeper) GetRewardShares(ctx sdk.Context, prophecy types.Prophecy) []types.RewardShare {
+	validators := prophecy.ClaimValidators[prophecy.Status.FinalClaim]
+
+	var relayersPower map[string]int64
+	if !sdk.IsUpgrade(sdk.ProphecyKVStore) {
+		relayersPower = k.stakeKeeper.GetOracleRelayersPower(ctx)
+	}
+
+	shares := make([]types.RewardShare, 0, len(validators))
+	for _, validator := range validators {
+		share := types.RewardShare{Validator: validator}
+		if relayersPower == nil {
+			if claim, found := k.getValidatorClaim(ctx, prophecy.ID, validator); found {
+				share.Power = claim.Power
+			}
+		} else {
+			share.Power = relayersPower[validator.String()]
+		}
+		shares = append(shares, share)
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		return bytes.Compare(shares[i].Validator, shares[j].Validator) < 0
+	})
+	return shares
+}
+
+// AccrueRelayerRewards moves the relay fee of a package from the peg account to the relayer reward account and
+// splits it among the shares by power. The rounding remainder goes to the deliverer, which gets the whole fee
+// when the shares carry no power.
+func (k Keeper) AccrueRelayerRewards(ctx sdk.Context, shares []types.RewardShare, deliverer sdk.ValAddress, feeAmount int64) sdk.Error {
+	if feeAmount == 0 {
+		return nil
+	}
+
+	fee := sdk.Coins{sdk.Coin{Denom: sdk.NativeTokenSymbol, Amount: feeAmount}}
+	if _, sdkErr := k.BkKeeper.SendCoins(ctx, sdk.PegAccount, types.RelayerRewardAccount, fee); sdkErr != nil {
+		return sdkErr
+	}
+	if ctx.IsDeliverTx() {
+		k.Pool.AddAddrs([]sdk.AccAddress{sdk.PegAccount, types.RelayerRewardAccount})
+	}
+
+	totalPower := int64(0)
+	for _, share := range shares {
+		totalPower += share.Power
+	}
+
+	remaining := feeAmount
+	if totalPower > 0 {
+		for _, share := range shares {
+			amount := new(big.Int).Mul(big.NewInt(feeAmount), big.NewInt(share.Power))
+			amount.Quo(amount, big.NewInt(totalPower))
+			if amount.Sign() == 0 {
+				continue
+			}
+			k.setRelayerReward(ctx, share.Validator, k.GetRelayerReward(ctx, share.Validator)+amount.Int64())
+			remaining -= amount.Int64()
+		}
+	}
+	if remaining > 0 {
+		k.setRelayerReward(ctx, deliverer, k.GetRelayerReward(ctx, deliverer)+remaining)
+	}
+	return nil
+}
+
+// WithdrawRelayerReward sends the accrued relay fees of a relayer to its account
+func (k Keeper) WithdrawRelayerReward(ctx sdk.Context, validator sdk.ValAddress) (int64, sdk.Error) {
+	amount := k.GetRelayerReward(ctx, validator)
+	if amount <= 0 {
+		return 0, types.ErrNoRelayerReward(fmt.Sprintf("no relayer reward accrued to %s", validator.String()))
+	}
+
+	reward := sdk.Coins{sdk.Coin{Denom: sdk.NativeTokenSymbol, Amount: amount}}
+	if _, sdkErr := k.BkKeeper.SendCoins(ctx, types.RelayerRewardAccount, sdk.AccAddress(validator), reward); sdkErr != nil {
+		return 0, sdkErr
+	}
+	if ctx.IsDeliverTx() {
+		k.Pool.AddAddrs([]sdk.AccAddress{types.RelayerRewardAccount, sdk.AccAddress(validator)})
+	}
+
+	k.setRelayerReward(ctx, validator, 0)
+	return amount, nil
+}
@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+func TestRelayerRewards(t *testing.T) {
+	ctx, keeper, valAddrs, powers := setupProphecyTest(t)
+	enableProphecyKVStore()
+	defer sdk.UpgradeMgr.Reset()
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[2], AlternateTestString))
+	require.NoError(t, err)
+	prophecy, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+
+	// only the validators which voted for the final claim share the fee
+	shares := keeper.GetRewardShares(ctx, prophecy)
+	require.Len(t, shares, 2)
+	require.True(t, bytes.Compare(shares[0].Validator, shares[1].Validator) < 0, "shares should be sorted by validator")
+	for _, share := range shares {
+		require.Equal(t, powers[share.Validator.String()], share.Power)
+		require.NotEqual(t, valAddrs[2], share.Validator)
+	}
+
+	_, _, sdkErr := keeper.BkKeeper.AddCoins(ctx, sdk.PegAccount, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 101)})
+	require.Nil(t, sdkErr)
+	require.Nil(t, keeper.AccrueRelayerRewards(ctx, shares, valAddrs[1], 101))
+
+	// the rounding remainder goes to the deliverer
+	require.Equal(t, int64(50), keeper.GetRelayerReward(ctx, valAddrs[0]))
+	require.Equal(t, int64(51), keeper.GetRelayerReward(ctx, valAddrs[1]))
+	require.Equal(t, int64(0), keeper.GetRelayerReward(ctx, valAddrs[2]))
+	require.Len(t, keeper.GetAllRelayerRewards(ctx), 2)
+	require.Equal(t, int64(0), keeper.BkKeeper.GetCoins(ctx, sdk.PegAccount).AmountOf(sdk.NativeTokenSymbol))
+	require.Equal(t, int64(101), keeper.BkKeeper.GetCoins(ctx, types.RelayerRewardAccount).AmountOf(sdk.NativeTokenSymbol))
+
+	// the peg account can not pay more than it holds
+	require.NotNil(t, keeper.AccrueRelayerRewards(ctx, shares, valAddrs[1], 1))
+
+	balance := keeper.BkKeeper.GetCoins(ctx, sdk.AccAddress(valAddrs[0])).AmountOf(sdk.NativeTokenSymbol)
+	amount, sdkErr := keeper.WithdrawRelayerReward(ctx, valAddrs[0])
+	require.Nil(t, sdkErr)
+	require.Equal(t, int64(50), amount)
+	require.Equal(t, balance+50, keeper.BkKeeper.GetCoins(ctx, sdk.AccAddress(valAddrs[0])).AmountOf(sdk.NativeTokenSymbol))
+	require.Equal(t, int64(51), keeper.BkKeeper.GetCoins(ctx, types.RelayerRewardAccount).AmountOf(sdk.NativeTokenSymbol))
+	require.Equal(t, []types.RelayerReward{{Validator: valAddrs[1], Amount: 51}}, keeper.GetAllRelayerRewards(ctx))
+
+	_, sdkErr = keeper.WithdrawRelayerReward(ctx, valAddrs[0])
+	require.NotNil(t, sdkErr)
+	require.Equal(t, types.CodeNoRelayerReward, sdkErr.Code())
+}
+
+func TestRelayerRewardsWithoutPower(t *testing.T) {
+	ctx, keeper, valAddrs, _ := setupProphecyTest(t)
+
+	_, _, sdkErr := keeper.BkKeeper.AddCoins(ctx, sdk.PegAccount, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 10)})
+	require.Nil(t, sdkErr)
+	shares := []types.RewardShare{{Validator: valAddrs[0]}, {Validator: valAddrs[1]}}
+	require.Nil(t, keeper.AccrueRelayerRewards(ctx, shares, valAddrs[2], 10))
+	require.Equal(t, []types.RelayerReward{{Validator: valAddrs[2], Amount: 10}}, keeper.GetAllRelayerRewards(ctx))
+}
@@ -9,6 +9,9 @@ func RegisterUpgradeBeginBlocker(keeper Keeper) {
 	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.LaunchAxcUpgrade, func(ctx sdk.Context) {
 		keeper.SetParams(ctx, types.Params{ConsensusNeeded: types.DefaultConsensusNeeded})
 	})
+	sdk.UpgradeMgr.RegisterBeginBlockerByName(sdk.ProphecyKVStore, func(ctx sdk.Context) {
+		keeper.MigrateLegacyProphecies(ctx)
+	})
 
 	err := keeper.ScKeeper.RegisterChannel(types.RelayPackagesChannelName, types.RelayPackagesChannelId, nil)
 	if err != nil {
@@ -0,0 +1,141 @@
+package oracle
+
+import (
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+const (
+	QueryProphecy          = "prophecy"
+	QuerySequence          = "sequence"
+	QueryRelayers          = "relayers"
+	QueryPendingProphecies = "pendingProphecies"
+	QueryRelayerRewards    = "relayerRewards"
+)
+
+// QueryProphecyParams is the params of the prophecy and relayers queries
+type QueryProphecyParams struct {
+	SideChainId string
+	Sequence    uint64
+}
+
+type QuerySequenceParams struct {
+	SideChainId string
+	ChannelId   sdk.ChannelID
+}
+
+type QueryPendingPropheciesParams struct {
+	SideChainId  string
+	FromSequence uint64
+	ToSequence   uint64 // 0 for no upper bound
+}
+
+// QueryRelayerRewardsParams is the params of the relayer rewards query, the rewards of all the relayers
+// are returned if the validator is empty
+type QueryRelayerRewardsParams struct {
+	Validator sdk.ValAddress
+}
+
+// NewQuerier creates a querier for oracle REST endpoints
+func NewQuerier(keeper Keeper, cdc *codec.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryProphecy:
+			var params QueryProphecyParams
+			chainId, err := unmarshalQueryParams(keeper, cdc, req, &params, &params.SideChainId)
+			if err != nil {
+				return nil, err
+			}
+			prophecy, found := keeper.GetProphecyResult(ctx, types.GetClaimId(chainId, types.RelayPackagesChannelId, params.Sequence))
+			if !found {
+				return nil, types.ErrProphecyNotFound()
+			}
+			return marshalQueryResult(cdc, prophecy)
+		case QuerySequence:
+			var params QuerySequenceParams
+			chainId, err := unmarshalQueryParams(keeper, cdc, req, &params, &params.SideChainId)
+			if err != nil {
+				return nil, err
+			}
+			return marshalQueryResult(cdc, keeper.ScKeeper.GetReceiveSequence(ctx, chainId, params.ChannelId))
+		case QueryRelayers:
+			var params QueryProphecyParams
+			chainId, err := unmarshalQueryParams(keeper, cdc, req, &params, &params.SideChainId)
+			if err != nil {
+				return nil, err
+			}
+			return marshalQueryResult(cdc, queryRelayers(ctx, keeper, types.GetClaimId(chainId, types.RelayPackagesChannelId, params.Sequence)))
+		case QueryPendingProphecies:
+			if !sdk.IsUpgrade(sdk.ProphecyKVStore) {
+				return nil, sdk.ErrUnknownRequest("pending prophecies are not indexed before upgrade " + sdk.ProphecyKVStore)
+			}
+			var params QueryPendingPropheciesParams
+			chainId, err := unmarshalQueryParams(keeper, cdc, req, &params, &params.SideChainId)
+			if err != nil {
+				return nil, err
+			}
+			return marshalQueryResult(cdc, keeper.GetPendingProphecies(ctx, chainId, params.FromSequence, params.ToSequence))
+		case QueryRelayerRewards:
+			var params QueryRelayerRewardsParams
+			if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrInternal("can not unmarshal request")
+			}
+			if len(params.Validator) == 0 {
+				return marshalQueryResult(cdc, keeper.GetAllRelayerRewards(ctx))
+			}
+			return marshalQueryResult(cdc, []types.RelayerReward{{
+				Validator: params.Validator,
+				Amount:    keeper.GetRelayerReward(ctx, params.Validator),
+			}})
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown oracle query endpoint")
+		}
+	}
+}
+
+// queryRelayers lists the oracle relayers with their power and their claims on the prophecy
+func queryRelayers(ctx sdk.Context, keeper Keeper, id string) []types.RelayerVote {
+	prophecy, found := keeper.GetProphecyResult(ctx, id)
+	claims := make(map[string]string)
+	if found {
+		for _, claim := range prophecy.Claims {
+			claims[claim.Validator.String()] = claim.Claim
+		}
+	}
+
+	powers := keeper.GetOracleRelayersPower(ctx)
+	relayers := make([]types.RelayerVote, 0, len(powers))
+	for validator, power := range powers {
+		vote := types.RelayerVote{Validator: validator, Power: power}
+		vote.Claim, vote.Voted = claims[validator]
+		relayers = append(relayers, vote)
+	}
+	sort.Slice(relayers, func(i, j int) bool {
+		return relayers[i].Validator < relayers[j].Validator
+	})
+	return relayers
+}
+
+// unmarshalQueryParams unmarshals the params of a request and resolves the chain id of the side chain
+func unmarshalQueryParams(keeper Keeper, cdc *codec.Codec, req abci.RequestQuery, params interface{}, sideChainId *string) (sdk.ChainID, sdk.Error) {
+	if err := cdc.UnmarshalJSON(req.Data, params); err != nil {
+		return 0, sdk.ErrInternal("can not unmarshal request")
+	}
+	chainId, err := keeper.ScKeeper.GetDestChainID(*sideChainId)
+	if err != nil {
+		return 0, sdk.ErrUnknownRequest(err.Error())
+	}
+	return chainId, nil
+}
+
+func marshalQueryResult(cdc *codec.Codec, result interface{}) ([]byte, sdk.Error) {
+	res, err := cdc.MarshalJSON(result)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
@@ -24,6 +24,10 @@ const (
 	CodeInvalidLengthOfPayload        sdk.CodeType = 1011
 	CodeFeeOverflow                   sdk.CodeType = 1012
 	CodeInvalidPayload                sdk.CodeType = 1013
+	CodeProofClaimDisabled            sdk.CodeType = 1014
+	CodeInvalidPackageProof           sdk.CodeType = 1015
+	CodeRelayerRewardDisabled         sdk.CodeType = 1016
+	CodeNoRelayerReward               sdk.CodeType = 1017
 )
 
 func ErrProphecyNotFound() sdk.Error {
@@ -85,3 +89,19 @@ func ErrFeeOverflow(msg string) sdk.Error {
 func ErrInvalidPayload(msg string) sdk.Error {
 	return sdk.NewError(DefaultCodespace, CodeInvalidPayload, msg)
 }
+
+func ErrProofClaimDisabled() sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeProofClaimDisabled, "claim with proof is not enabled")
+}
+
+func ErrInvalidPackageProof(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidPackageProof, msg)
+}
+
+func ErrRelayerRewardDisabled() sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeRelayerRewardDisabled, "relayer reward is not enabled")
+}
+
+func ErrNoRelayerReward(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeNoRelayerReward, msg)
+}
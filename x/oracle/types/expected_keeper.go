@@ -1,6 +1,7 @@
 package types
 
 import (
+	"github.com/cosmos/cosmos-sdk/axc"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
@@ -14,3 +15,9 @@ type StakingKeeper interface {
 	GetOracleRelayersPower(ctx sdk.Context) map[string]int64
 	CheckIsValidOracleRelayer(ctx sdk.Context, validatorAddress sdk.ValAddress) bool
 }
+
+// LightClientKeeper defines the expected light client keeper which tracks the verified side chain headers
+type LightClientKeeper interface {
+	GetHeader(ctx sdk.Context, sideChainId string, number int64) (axc.Header, bool)
+	GetLatestNumber(ctx sdk.Context, sideChainId string) (int64, bool)
+}
@@ -1,5 +1,13 @@
 package types
 
+import (
+	"encoding/binary"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	EventTypeClaim = "claim"
 
@@ -10,4 +18,73 @@ const (
 	ClaimSendSequence    = "ClaimSendSequence"
 	ClaimCrash           = "ClaimCrash"
 	ClaimPackageType     = "ClaimPackageType"
+	ClaimTimedOut        = "ClaimTimedOut"
+
+	EventTypeProphecyExpired = "prophecy_expired"
+	EventTypeProphecyFailed  = "prophecy_failed"
+
+	EventTypeWithdrawRelayerReward = "withdraw_relayer_reward"
+
+	AttributeKeyProphecyId   = "prophecy_id"
+	AttributeKeyCreateHeight = "create_height"
+	AttributeKeyValidator    = "validator"
+	AttributeKeyAmount       = "amount"
+)
+
+// Legacy prophecies are stored under the raw prophecy id, which never starts with the following prefixes
+var (
+	ProphecyKeyPrefix      = []byte{0x01} // prefix for the status of each prophecy
+	ProphecyClaimKeyPrefix = []byte{0x02} // prefix for the claims of each prophecy, sorted by validator address
+	ProphecyTallyKeyPrefix = []byte{0x03} // prefix for the power tally of each claim of a prophecy
+	ProphecyQueueKeyPrefix = []byte{0x04} // prefix for the prophecies ordered by create height
+	RelayerRewardKeyPrefix = []byte{0x05} // prefix for the accrued relay fees of each relayer
+	RelayersPowerHashKey   = []byte{0x06} // key for the hash of the oracle relayers power last weighed the prophecies
 )
+
+// IsLegacyProphecyKey returns whether the key belongs to a prophecy stored as DBProphecy
+func IsLegacyProphecyKey(key []byte) bool {
+	return len(key) > 0 && key[0] > RelayersPowerHashKey[0]
+}
+
+// GetProphecyKey returns the key of the status of a prophecy
+func GetProphecyKey(id string) []byte {
+	return append(ProphecyKeyPrefix, []byte(id)...)
+}
+
+// GetProphecyClaimsKey returns the prefix of all the claims of a prophecy, the id is length prefixed
+// so that the claims of an id never fall under the prefix of another id
+func GetProphecyClaimsKey(id string) []byte {
+	return append(append(ProphecyClaimKeyPrefix, byte(len(id))), []byte(id)...)
+}
+
+// GetProphecyClaimKey returns the key of the claim of a validator on a prophecy
+func GetProphecyClaimKey(id string, validator []byte) []byte {
+	return append(GetProphecyClaimsKey(id), validator...)
+}
+
+// GetProphecyTalliesKey returns the prefix of all the claim tallies of a prophecy
+func GetProphecyTalliesKey(id string) []byte {
+	return append(append(ProphecyTallyKeyPrefix, byte(len(id))), []byte(id)...)
+}
+
+// GetProphecyTallyKey returns the key of the tally of a claim on a prophecy
+func GetProphecyTallyKey(id string, claim string) []byte {
+	return append(GetProphecyTalliesKey(id), tmhash.Sum([]byte(claim))...)
+}
+
+// GetProphecyQueueTimeKey returns the prefix of the prophecies created at the height
+func GetProphecyQueueTimeKey(height int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return append(ProphecyQueueKeyPrefix, bz...)
+}
+
+// GetProphecyQueueKey returns the key of a prophecy in the queue ordered by create height
+func GetProphecyQueueKey(height int64, id string) []byte {
+	return append(GetProphecyQueueTimeKey(height), []byte(id)...)
+}
+
+// GetRelayerRewardKey returns the key of the accrued relay fees of a relayer
+func GetRelayerRewardKey(validator sdk.ValAddress) []byte {
+	return append(RelayerRewardKeyPrefix, validator...)
+}
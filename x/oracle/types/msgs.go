@@ -11,10 +11,13 @@ import (
 const (
 	RouteOracle = "oracle"
 
-	ClaimMsgType = "oracleClaim"
+	ClaimMsgType                 = "oracleClaim"
+	ClaimWithProofMsgType        = "oracleClaimWithProof"
+	WithdrawRelayerRewardMsgType = "oracleWithdrawReward"
 )
 
 var _ sdk.Msg = ClaimMsg{}
+var _ sdk.Msg = ClaimWithProofMsg{}
 
 type Packages []Package
 
@@ -75,3 +78,61 @@ func (msg ClaimMsg) ValidateBasic() sdk.Error {
 	}
 	return nil
 }
+
+// ClaimWithProofMsg delivers the packages of an oracle sequence of a side chain without voting. The packages
+// are the crossChainPackage events of the sequence read from the proven receipts of all the transactions of
+// the block which emitted them, so that none of them can be left out.
+type ClaimWithProofMsg struct {
+	ChainId          sdk.ChainID    `json:"chain_id"`
+	Sequence         uint64         `json:"sequence"`
+	Proof            BlockProof     `json:"proof"`
+	ValidatorAddress sdk.AccAddress `json:"validator_address"`
+}
+
+func NewClaimWithProofMsg(chainId sdk.ChainID, sequence uint64, proof BlockProof, validatorAddr sdk.AccAddress) ClaimWithProofMsg {
+	return ClaimWithProofMsg{
+		ChainId:          chainId,
+		Sequence:         sequence,
+		Proof:            proof,
+		ValidatorAddress: validatorAddr,
+	}
+}
+
+// nolint
+func (msg ClaimWithProofMsg) Route() string { return RouteOracle }
+func (msg ClaimWithProofMsg) Type() string  { return ClaimWithProofMsgType }
+func (msg ClaimWithProofMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.ValidatorAddress}
+}
+
+func (msg ClaimWithProofMsg) String() string {
+	return fmt.Sprintf("ClaimWithProof{%v#%v#%v#%d}",
+		msg.ChainId, msg.Sequence, msg.ValidatorAddress.String(), msg.Proof.HeaderNumber)
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg ClaimWithProofMsg) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (msg ClaimWithProofMsg) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg ClaimWithProofMsg) ValidateBasic() sdk.Error {
+	if msg.Proof.TxCount == 0 {
+		return ErrInvalidPackageProof("block of the packages should have transactions")
+	}
+	if len(msg.Proof.ReceiptProof) == 0 {
+		return ErrInvalidPackageProof("receipt proof should not be empty")
+	}
+	if len(msg.ValidatorAddress) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(msg.ValidatorAddress.String())
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package types
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/axc"
+)
+
+var (
+	// CrossChainContractAddr is the system contract on the side chain which emits the cross chain packages
+	CrossChainContractAddr = axc.Address{18: 0x20}
+
+	// CrossChainPackageEventSig is the topic of event crossChainPackage(uint16 chainId, uint64 indexed oracleSequence,
+	// uint64 indexed packageSequence, uint8 indexed channelId, bytes payload)
+	CrossChainPackageEventSig = axc.BytesToHash(axc.Keccak256([]byte("crossChainPackage(uint16,uint64,uint64,uint8,bytes)")))
+
+	ParamStoreKeyProofClaimEnabled = []byte("proofClaimEnabled")
+)
+
+const abiWordLength = 32
+
+// ProofConfirmations is the number of headers the light client must have verified on top of the proven block,
+// so that the block is sealed by more than 2/3 of the validators of a side chain of 21 validators
+const ProofConfirmations int64 = 15
+
+// BlockProof proves the receipts of all the transactions of a side chain block verified by the light client.
+// The cross chain contract emits all the packages of an oracle sequence in a single block, so that the packages
+// of the sequence are exactly the crossChainPackage events of the sequence in the block.
+type BlockProof struct {
+	HeaderNumber int64    `json:"header_number"`
+	TxCount      uint64   `json:"tx_count"`
+	ReceiptProof [][]byte `json:"receipt_proof"` // the trie nodes of all the receipts of the block
+}
+
+// CrossChainPackageEvent is the decoded crossChainPackage event
+type CrossChainPackageEvent struct {
+	ChainId         uint16
+	OracleSequence  uint64
+	PackageSequence uint64
+	ChannelId       uint8
+	Payload         []byte
+}
+
+// DecodeCrossChainPackageEvent decodes the crossChainPackage event from the log
+func DecodeCrossChainPackageEvent(log *axc.Log) (CrossChainPackageEvent, error) {
+	if len(log.Topics) != 4 || log.Topics[0].Cmp(CrossChainPackageEventSig) != 0 {
+		return CrossChainPackageEvent{}, errors.New("log is not a cross chain package event")
+	}
+	oracleSequence, err := abiUint(log.Topics[1].Bytes(), 64)
+	if err != nil {
+		return CrossChainPackageEvent{}, err
+	}
+	packageSequence, err := abiUint(log.Topics[2].Bytes(), 64)
+	if err != nil {
+		return CrossChainPackageEvent{}, err
+	}
+	channelId, err := abiUint(log.Topics[3].Bytes(), 8)
+	if err != nil {
+		return CrossChainPackageEvent{}, err
+	}
+
+	// the data is abi.encode(uint16 chainId, bytes payload)
+	if len(log.Data) < 3*abiWordLength {
+		return CrossChainPackageEvent{}, errors.New("data of cross chain package event is too short")
+	}
+	chainId, err := abiUint(log.Data[:abiWordLength], 16)
+	if err != nil {
+		return CrossChainPackageEvent{}, err
+	}
+	offset, err := abiUint(log.Data[abiWordLength:2*abiWordLength], 64)
+	if err != nil || offset != 2*abiWordLength {
+		return CrossChainPackageEvent{}, errors.New("invalid offset of payload in cross chain package event")
+	}
+	length, err := abiUint(log.Data[2*abiWordLength:3*abiWordLength], 64)
+	if err != nil || length > uint64(len(log.Data)-3*abiWordLength) {
+		return CrossChainPackageEvent{}, errors.New("invalid length of payload in cross chain package event")
+	}
+
+	return CrossChainPackageEvent{
+		ChainId:         uint16(chainId),
+		OracleSequence:  oracleSequence,
+		PackageSequence: packageSequence,
+		ChannelId:       uint8(channelId),
+		Payload:         log.Data[3*abiWordLength : 3*abiWordLength+length],
+	}, nil
+}
+
+// ToLog encodes the event as the log emitted by the cross chain contract
+func (event CrossChainPackageEvent) ToLog() *axc.Log {
+	paddedLength := (len(event.Payload) + abiWordLength - 1) / abiWordLength * abiWordLength
+	data := make([]byte, 3*abiWordLength+paddedLength)
+	new(big.Int).SetUint64(uint64(event.ChainId)).FillBytes(data[:abiWordLength])
+	new(big.Int).SetUint64(2 * abiWordLength).FillBytes(data[abiWordLength : 2*abiWordLength])
+	new(big.Int).SetUint64(uint64(len(event.Payload))).FillBytes(data[2*abiWordLength : 3*abiWordLength])
+	copy(data[3*abiWordLength:], event.Payload)
+
+	return &axc.Log{
+		Address: CrossChainContractAddr,
+		Topics: []axc.Hash{
+			CrossChainPackageEventSig,
+			axc.BytesToHash(new(big.Int).SetUint64(event.OracleSequence).Bytes()),
+			axc.BytesToHash(new(big.Int).SetUint64(event.PackageSequence).Bytes()),
+			axc.BytesToHash([]byte{event.ChannelId}),
+		},
+		Data: data,
+	}
+}
+
+// abiUint decodes a 32 bytes abi word as an unsigned integer of the given bit size
+func abiUint(word []byte, bitSize int) (uint64, error) {
+	value := new(big.Int).SetBytes(word)
+	if value.BitLen() > bitSize {
+		return 0, errors.New("abi value overflow")
+	}
+	return value.Uint64(), nil
+}
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/axc"
+)
+
+func TestDecodeCrossChainPackageEvent(t *testing.T) {
+	payload := []byte{0x01, 0x02, 0x03}
+	expected := CrossChainPackageEvent{
+		ChainId:         56,
+		OracleSequence:  7,
+		PackageSequence: 9,
+		ChannelId:       2,
+		Payload:         payload,
+	}
+	log := expected.ToLog()
+
+	event, err := DecodeCrossChainPackageEvent(log)
+	require.NoError(t, err)
+	require.Equal(t, expected, event)
+
+	// wrong event signature
+	wrongSig := *log
+	wrongSig.Topics = append([]axc.Hash{{0x01}}, log.Topics[1:]...)
+	_, err = DecodeCrossChainPackageEvent(&wrongSig)
+	require.Error(t, err)
+
+	// channel id overflows uint8
+	overflow := *log
+	overflow.Topics = append(append([]axc.Hash{}, log.Topics[:3]...), axc.BytesToHash(big.NewInt(256).Bytes()))
+	_, err = DecodeCrossChainPackageEvent(&overflow)
+	require.Error(t, err)
+
+	// truncated payload
+	truncated := *log
+	truncated.Data = log.Data[:3*abiWordLength+1]
+	_, err = DecodeCrossChainPackageEvent(&truncated)
+	require.Error(t, err)
+}
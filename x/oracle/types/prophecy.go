@@ -11,18 +11,31 @@ import (
 var (
 	// DefaultConsensusNeeded defines the default consensus value required for a
 	// prophecy to be finalized
-	DefaultConsensusNeeded      sdk.Dec = sdk.NewDecWithPrec(7, 1)
-	ParamStoreKeyProphecyParams         = []byte("prophecyParams")
+	DefaultConsensusNeeded            sdk.Dec = sdk.NewDecWithPrec(7, 1)
+	DefaultProphecyExpiryBlocks       int64   = 0 // the pending prophecies never expire by default
+	ParamStoreKeyProphecyParams               = []byte("prophecyParams")
+	ParamStoreKeyProphecyExpiryBlocks         = []byte("prophecyExpiryBlocks")
 )
 
 type Params struct {
 	ConsensusNeeded sdk.Dec `json:"ConsensusNeeded"` //  Minimum deposit for a proposal to enter voting period.
+	// ProphecyExpiryBlocks is the number of blocks after which a pending prophecy is pruned, 0 means never.
+	// It is not a key of the param set, which is written as a whole since LaunchAxcUpgrade, it is only changed
+	// from the upgrade ProphecyKVStore and only if it is given.
+	ProphecyExpiryBlocks *int64 `json:"ProphecyExpiryBlocks,omitempty"`
+	// ProofClaimEnabled allows a single relayer to deliver packages with receipt proofs instead of a prophecy.
+	// Like ProphecyExpiryBlocks it is kept out of the param set, it is only changed since the upgrade ProofClaim
+	// and only if it is given.
+	ProofClaimEnabled *bool `json:"ProofClaimEnabled,omitempty"`
 }
 
 func (p *Params) UpdateCheck() error {
 	if p.ConsensusNeeded.IsNil() || p.ConsensusNeeded.GT(sdk.OneDec()) || p.ConsensusNeeded.LT(sdk.NewDecWithPrec(5, 1)) {
 		return fmt.Errorf("the value should be in range 0.5 to 1")
 	}
+	if p.ProphecyExpiryBlocks != nil && *p.ProphecyExpiryBlocks < 0 {
+		return fmt.Errorf("the prophecy expiry blocks should not be negative")
+	}
 	return nil
 }
 
@@ -63,6 +76,49 @@ type DBProphecy struct {
 	ValidatorClaims []byte `json:"validator_claims"`
 }
 
+// ProphecyInfo is the status of a prophecy in the deterministic store, the claims of the prophecy
+// are kept in separate entries sorted by validator address, and the power of each claim is tallied
+// incrementally as claims arrive.
+type ProphecyInfo struct {
+	ID           string `json:"id"`
+	Status       Status `json:"status"`
+	ClaimsPower  int64  `json:"claims_power"`
+	CreateHeight int64  `json:"create_height"`
+}
+
+// ValidatorClaim is the claim of a validator on a prophecy, along with the power the validator had
+// when the claim was made
+type ValidatorClaim struct {
+	Validator sdk.ValAddress `json:"validator"`
+	Claim     string         `json:"claim"`
+	Power     int64          `json:"power"`
+}
+
+// ClaimTally is the total power of the validators which made the same claim on a prophecy
+type ClaimTally struct {
+	Claim string `json:"claim"`
+	Power int64  `json:"power"`
+}
+
+// ProphecyResult is the query result of a prophecy, the claims are sorted by validator address
+type ProphecyResult struct {
+	ChainId      sdk.ChainID      `json:"chain_id"`
+	Sequence     uint64           `json:"sequence"`
+	Status       Status           `json:"status"`
+	CreateHeight int64            `json:"create_height"`
+	ClaimsPower  int64            `json:"claims_power"`
+	Claims       []ValidatorClaim `json:"claims"`
+	Tallies      []ClaimTally     `json:"tallies"`
+}
+
+// RelayerVote is the query result of an oracle relayer and its claim on a prophecy
+type RelayerVote struct {
+	Validator string `json:"validator"`
+	Power     int64  `json:"power"`
+	Voted     bool   `json:"voted"`
+	Claim     string `json:"claim"`
+}
+
 // SerializeForDB serializes a prophecy into a DBProphecy
 func (prophecy Prophecy) SerializeForDB() (DBProphecy, error) {
 	validatorClaims, err := json.Marshal(prophecy.ValidatorClaims)
@@ -2,17 +2,25 @@ package types
 
 import (
 	"github.com/cosmos/cosmos-sdk/pubsub"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 const (
 	Topic = pubsub.Topic("oracle-event")
 )
 
+// CrossAppFailEvent is published when a cross chain application fails to execute a package, the code and the
+// message are the ones of the execution result
 type CrossAppFailEvent struct {
-	TxHash     string
-	ChainId    string
-	RelayerFee int64
-	From       string
+	TxHash      string
+	ChainId     string
+	RelayerFee  int64
+	From        string
+	ChannelId   sdk.ChannelID
+	Sequence    uint64
+	PackageType sdk.CrossChainPackageType
+	Code        uint32
+	Msg         string
 }
 
 func (event CrossAppFailEvent) GetTopic() pubsub.Topic {
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	// RelayerRewardAccount holds the relay fees accrued to the oracle relayers until they are withdrawn
+	RelayerRewardAccount = sdk.AccAddress(crypto.AddressHash([]byte("AximchainRelayerRewardAccount")))
+)
+
+// RewardShare is the weight of a validator in the relay fees of the packages it voted for
+type RewardShare struct {
+	Validator sdk.ValAddress `json:"validator"`
+	Power     int64          `json:"power"`
+}
+
+// RelayerReward is the relay fees accrued to an oracle relayer
+type RelayerReward struct {
+	Validator sdk.ValAddress `json:"validator"`
+	Amount    int64          `json:"amount"`
+}
+
+var _ sdk.Msg = WithdrawRelayerRewardMsg{}
+
+// WithdrawRelayerRewardMsg withdraws the accrued relay fees of a relayer to its account
+type WithdrawRelayerRewardMsg struct {
+	ValidatorAddress sdk.AccAddress `json:"validator_address"`
+}
+
+func NewWithdrawRelayerRewardMsg(validatorAddr sdk.AccAddress) WithdrawRelayerRewardMsg {
+	return WithdrawRelayerRewardMsg{
+		ValidatorAddress: validatorAddr,
+	}
+}
+
+// nolint
+func (msg WithdrawRelayerRewardMsg) Route() string { return RouteOracle }
+func (msg WithdrawRelayerRewardMsg) Type() string  { return WithdrawRelayerRewardMsgType }
+func (msg WithdrawRelayerRewardMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.ValidatorAddress}
+}
+
+func (msg WithdrawRelayerRewardMsg) String() string {
+	return fmt.Sprintf("WithdrawRelayerReward{%v}", msg.ValidatorAddress.String())
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg WithdrawRelayerRewardMsg) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (msg WithdrawRelayerRewardMsg) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.ValidatorAddress, RelayerRewardAccount}
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg WithdrawRelayerRewardMsg) ValidateBasic() sdk.Error {
+	if len(msg.ValidatorAddress) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(msg.ValidatorAddress.String())
+	}
+	return nil
+}
@@ -11,6 +11,11 @@ func RegisterWire(cdc *codec.Codec) {
 	cdc.RegisterConcrete(Prophecy{}, "oracle/Prophecy", nil)
 	cdc.RegisterConcrete(Status{}, "oracle/Status", nil)
 	cdc.RegisterConcrete(DBProphecy{}, "oracle/DBProphecy", nil)
+	cdc.RegisterConcrete(types.ProphecyInfo{}, "oracle/ProphecyInfo", nil)
+	cdc.RegisterConcrete(types.ValidatorClaim{}, "oracle/ValidatorClaim", nil)
+	cdc.RegisterConcrete(types.ClaimTally{}, "oracle/ClaimTally", nil)
 	cdc.RegisterConcrete(ClaimMsg{}, "oracle/ClaimMsg", nil)
+	cdc.RegisterConcrete(ClaimWithProofMsg{}, "oracle/ClaimWithProofMsg", nil)
+	cdc.RegisterConcrete(WithdrawRelayerRewardMsg{}, "oracle/WithdrawRelayerRewardMsg", nil)
 	cdc.RegisterConcrete(&types.Params{}, "params/OracleParamSet", nil)
 }
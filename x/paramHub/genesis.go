@@ -43,6 +43,7 @@ const (
 	ChainDelegateFee      = 1e5
 	ChainRedelegateFee    = 3e5
 	ChainUndelegateFee    = 2e5
+	SetAutoCompoundFee    = 1e5
 
 	// slashing fee
 	AxcSubmitEvidenceFee = 10e8
@@ -73,6 +74,12 @@ const (
 	CrossBindRelayFee        = 2e6
 	CrossUnbindRelayFee      = 2e6
 
+	// oracle fee
+	OracleWithdrawRewardFee = 1e5
+
+	// light client fee
+	SubmitSideChainHeadersFee = 1e4
+
 	//MiniToken fee
 	TinyIssueFee   = 2e8
 	MiniIssueFee   = 3e8
@@ -82,6 +89,8 @@ const (
 	// Cross stake fee
 	CrossDistributeRewardRelayFee      = 6e5 // 0.006 AXC
 	CrossDistributeUndelegatedRelayFee = 6e5 // 0.006 AXC
+	CrossClaimRewardRelayFee           = 6e5 // 0.006 AXC
+	CrossCompoundRewardFee             = 1e5 // 0.001 AXC
 )
 
 var DefaultGenesisState = param.GenesisState{
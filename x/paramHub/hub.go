@@ -83,6 +83,13 @@ func RegisterUpgradeBeginBlocker(paramHub *ParamHub) {
 		}
 		paramHub.UpdateFeeParams(ctx, crossStakeFeeParams)
 	})
+	sdk.UpgradeMgr.RegisterBeginBlockerByName(sdk.CrossStakeRewardOps, func(ctx sdk.Context) {
+		crossStakeFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "crossClaimRewardRelayFee", Fee: CrossClaimRewardRelayFee, FeeFor: sdk.FeeForAll},
+			&param.FixedFeeParams{MsgType: "crossCompoundRewardFee", Fee: CrossCompoundRewardFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, crossStakeFeeParams)
+	})
 	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.BEP159, func(ctx sdk.Context) {
 		updateFeeParams := []param.FeeParam{
 			&param.FixedFeeParams{MsgType: "create_validator_open", Fee: CreateValidatorFee, FeeFor: sdk.FeeForProposer},
@@ -94,6 +101,30 @@ func RegisterUpgradeBeginBlocker(paramHub *ParamHub) {
 		}
 		paramHub.UpdateFeeParams(ctx, updateFeeParams)
 	})
+	sdk.UpgradeMgr.RegisterBeginBlockerByName(sdk.RelayerReward, func(ctx sdk.Context) {
+		updateFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "oracleWithdrawReward", Fee: OracleWithdrawRewardFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, updateFeeParams)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlockerByName(sdk.AutoCompound, func(ctx sdk.Context) {
+		updateFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "set_auto_compound", Fee: SetAutoCompoundFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, updateFeeParams)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlockerByName(sdk.ProofClaim, func(ctx sdk.Context) {
+		updateFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "oracleClaimWithProof", Fee: sdk.ZeroFee, FeeFor: sdk.FeeFree},
+		}
+		paramHub.UpdateFeeParams(ctx, updateFeeParams)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlockerByName(sdk.LightClient, func(ctx sdk.Context) {
+		updateFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "submit_side_headers", Fee: SubmitSideChainHeadersFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, updateFeeParams)
+	})
 }
 
 func EndBreatheBlock(ctx sdk.Context, paramHub *ParamHub) {
@@ -149,17 +180,23 @@ func init() {
 		"crossUnbindRelayFee":                fees.FixedFeeCalculatorGen,
 		"crossTransferOutRelayFee":           fees.FixedFeeCalculatorGen,
 		"oracleClaim":                        fees.FixedFeeCalculatorGen,
+		"oracleClaimWithProof":               fees.FixedFeeCalculatorGen,
+		"oracleWithdrawReward":               fees.FixedFeeCalculatorGen,
 		"miniTokensSetURI":                   fees.FixedFeeCalculatorGen,
 		"dexListMini":                        fees.FixedFeeCalculatorGen,
 		"tinyIssueMsg":                       fees.FixedFeeCalculatorGen,
 		"miniIssueMsg":                       fees.FixedFeeCalculatorGen,
 		"crossDistributeRewardRelayFee":      fees.FixedFeeCalculatorGen,
 		"crossDistributeUndelegatedRelayFee": fees.FixedFeeCalculatorGen,
+		"crossClaimRewardRelayFee":           fees.FixedFeeCalculatorGen,
+		"crossCompoundRewardFee":             fees.FixedFeeCalculatorGen,
 		"create_validator_open":              fees.FixedFeeCalculatorGen,
 		"edit_validator":                     fees.FixedFeeCalculatorGen,
 		"delegate":                           fees.FixedFeeCalculatorGen,
 		"redelegate":                         fees.FixedFeeCalculatorGen,
 		"undelegate":                         fees.FixedFeeCalculatorGen,
 		"unjail":                             fees.FixedFeeCalculatorGen,
+		"set_auto_compound":                  fees.FixedFeeCalculatorGen,
+		"submit_side_headers":                fees.FixedFeeCalculatorGen,
 	}
 }
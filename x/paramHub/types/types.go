@@ -46,6 +46,8 @@ var (
 		"crossUnbindRelayFee":      {},
 		"crossTransferOutRelayFee": {},
 		"oracleClaim":              {},
+		"oracleClaimWithProof":     {},
+		"oracleWithdrawReward":     {},
 
 		"HTLT":        {},
 		"depositHTLT": {},
@@ -71,6 +73,8 @@ var (
 
 		"crossDistributeRewardRelayFee":      {},
 		"crossDistributeUndelegatedRelayFee": {},
+		"crossClaimRewardRelayFee":           {},
+		"crossCompoundRewardFee":             {},
 
 		"create_validator_open": {},
 		"edit_validator":        {},
@@ -78,6 +82,8 @@ var (
 		"redelegate":            {},
 		"undelegate":            {},
 		"unjail":                {},
+		"set_auto_compound":     {},
+		"submit_side_headers":   {},
 	}
 
 	ValidTransferFeeMsgTypes = map[string]struct{}{
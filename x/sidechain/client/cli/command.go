@@ -22,9 +22,11 @@ func AddCommands(cmd *cobra.Command, cdc *amino.Codec) {
 	}
 	dexCmd.AddCommand(
 		client.PostCommands(
-			SubmitChannelManageProposalCmd(cdc))...)
+			SubmitChannelManageProposalCmd(cdc),
+			SubmitSideChainRegistrationProposalCmd(cdc))...)
 	dexCmd.AddCommand(
 		client.GetCommands(
-			ShowChannelPermissionCmd(cdc))...)
+			ShowChannelPermissionCmd(cdc),
+			ShowSideChainsCmd(cdc))...)
 	cmd.AddCommand(dexCmd)
 }
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/go-amino"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+const (
+	flagChainId         = "chain-id-of-side-chain"
+	flagStorePrefix     = "store-prefix"
+	flagAllowedChannels = "allowed-channels"
+)
+
+func SubmitSideChainRegistrationProposalCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit-side-chain-registration-proposal",
+		Short: "Submit a proposal to register a new side chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+			title := viper.GetString(flagTitle)
+			initialDeposit := viper.GetString(flagDeposit)
+			votingPeriodInSeconds := viper.GetInt64(flagVotingPeriod)
+
+			storePrefix, err := hex.DecodeString(viper.GetString(flagStorePrefix))
+			if err != nil {
+				return fmt.Errorf("invalid store prefix: %v", err)
+			}
+			registration := types.SideChainRegistration{
+				SideChainId: viper.GetString(flagSideChainId),
+				ChainId:     sdk.ChainID(viper.GetUint(flagChainId)),
+				StorePrefix: storePrefix,
+			}
+			if allowed := viper.GetString(flagAllowedChannels); allowed != "" {
+				for _, channel := range strings.Split(allowed, ",") {
+					channelId, err := strconv.ParseUint(strings.TrimSpace(channel), 10, 8)
+					if err != nil {
+						return fmt.Errorf("invalid channel id %s", channel)
+					}
+					registration.ChannelPermissions = append(registration.ChannelPermissions, types.ChannelPermission{
+						ChannelId:  sdk.ChannelID(channelId),
+						Permission: sdk.ChannelAllow,
+					})
+				}
+			}
+
+			err = registration.Check()
+			if err != nil {
+				return err
+			}
+			fromAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoins(initialDeposit)
+			if err != nil {
+				return err
+			}
+			registrationBz, err := cdc.MarshalJSON(registration)
+			if err != nil {
+				return err
+			}
+
+			if votingPeriodInSeconds <= 0 {
+				return errors.New("voting period should be positive")
+			}
+
+			votingPeriod := time.Duration(votingPeriodInSeconds) * time.Second
+			if votingPeriod > gov.MaxVotingPeriod {
+				return fmt.Errorf("voting period should less than %d seconds", gov.MaxVotingPeriod/time.Second)
+			}
+
+			msg := gov.NewMsgSubmitProposal(title, string(registrationBz), gov.ProposalTypeRegisterSideChain, fromAddr, amount, votingPeriod)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+			return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagSideChainId, "", "the id of the new side chain")
+	cmd.Flags().Uint16(flagChainId, 0, "the cross chain id of the new side chain")
+	cmd.Flags().String(flagStorePrefix, "", "hex encoded store prefix of the new side chain")
+	cmd.Flags().String(flagAllowedChannels, "", "comma separated ids of the channels allowed to send packages, the others are forbidden")
+	cmd.Flags().String(flagTitle, "", "title of proposal")
+	cmd.Flags().Int64(flagVotingPeriod, 7*24*60*60, "voting period in seconds")
+	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
+	return cmd
+}
+
+func ShowSideChainsCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-side-chains",
+		Short: "Show the side chains registered by gov",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			bz, err := cliCtx.Query(fmt.Sprintf("custom/sideChain/%s", "sideChains"), nil)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+	return cmd
+}
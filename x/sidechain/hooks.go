@@ -43,3 +43,32 @@ func (hooks ChanPermissionSettingHooks) OnProposalSubmitted(ctx sdk.Context, pro
 	}
 	return nil
 }
+
+//---------------------    SideChainRegistrationHooks  -----------------
+type SideChainRegistrationHooks struct {
+	cdc *amino.Codec
+	k   *Keeper
+}
+
+func NewSideChainRegistrationHook(cdc *amino.Codec, keeper *Keeper) SideChainRegistrationHooks {
+	return SideChainRegistrationHooks{cdc, keeper}
+}
+
+var _ gov.GovHooks = SideChainRegistrationHooks{}
+
+func (hooks SideChainRegistrationHooks) OnProposalSubmitted(ctx sdk.Context, proposal gov.Proposal) error {
+	if proposal.GetProposalType() != gov.ProposalTypeRegisterSideChain {
+		panic(fmt.Sprintf("received wrong type of proposal %x", proposal.GetProposalType()))
+	}
+	if !sdk.IsUpgrade(sdk.SideChainRegistry) {
+		return fmt.Errorf("side chain registration is not enabled")
+	}
+
+	var registration types.SideChainRegistration
+	err := hooks.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &registration)
+	if err != nil {
+		return fmt.Errorf("get broken data when unmarshal SideChainRegistration msg, err %v", err)
+	}
+	// the side chain may still be taken by another proposal before this one passes
+	return hooks.k.checkSideChainRegistration(ctx, registration)
+}
@@ -56,8 +56,8 @@ func (k *Keeper) PrepareCtxForSideChain(ctx sdk.Context, sideChainId string) (sd
 	return ctx.WithSideChainKeyPrefix(storePrefix).WithSideChainId(sideChainId), nil
 }
 
-// TODO: to support multi side chains in the future. We will enable a registration mechanism and add these chain ids to db.
-// then we need to check if the sideChainId already exists
+// SetSideChainIdAndStorePrefix binds a side chain id to its store prefix, the side chains registered by gov
+// are checked against the existing ones in RegisterSideChain
 func (k Keeper) SetSideChainIdAndStorePrefix(ctx sdk.Context, sideChainId string, storePrefix []byte) {
 	store := ctx.KVStore(k.storeKey)
 	key := GetSideChainStorePrefixKey(sideChainId)
@@ -225,6 +225,17 @@ func (k *Keeper) incrSequence(ctx sdk.Context, destChainID sdk.ChainID, channelI
 }
 
 func EndBlock(ctx sdk.Context, k Keeper) {
+	// register the new side chains first, so that their channels can be managed in the same block
+	if sdk.IsUpgrade(sdk.SideChainRegistry) && k.govKeeper != nil {
+		registrations := k.getLastSideChainRegistrations(ctx)
+		// should in reverse order
+		for j := len(registrations) - 1; j >= 0; j-- {
+			if err := k.RegisterSideChain(ctx, registrations[j]); err != nil {
+				ctx.Logger().With("module", "side_chain").Error("failed to register side chain",
+					"sideChainId", registrations[j].SideChainId, "err", err)
+			}
+		}
+	}
 	if sdk.IsUpgrade(sdk.LaunchAxcUpgrade) && k.govKeeper != nil {
 		chanPermissions := k.getLastChanPermissionChanges(ctx)
 		// should in reverse order
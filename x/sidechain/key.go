@@ -15,6 +15,7 @@ const (
 
 var (
 	SideChainStorePrefixByIdKey = []byte{0x01} // prefix for each key to a side chain store prefix, by side chain id
+	SideChainRegistrationKey    = []byte{0x02} // prefix for each key to a side chain registered by gov, by side chain id
 
 	PrefixForSendSequenceKey    = []byte{0xf0}
 	PrefixForReceiveSequenceKey = []byte{0xf1}
@@ -26,6 +27,10 @@ func GetSideChainStorePrefixKey(sideChainId string) []byte {
 	return append(SideChainStorePrefixByIdKey, []byte(sideChainId)...)
 }
 
+func GetSideChainRegistrationKey(sideChainId string) []byte {
+	return append(SideChainRegistrationKey, []byte(sideChainId)...)
+}
+
 func buildChannelSequenceKey(destChainID sdk.ChainID, channelID sdk.ChannelID, prefix []byte) []byte {
 	key := make([]byte, prefixLength+destChainIDLength+channelIDLength)
 
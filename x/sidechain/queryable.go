@@ -8,6 +8,7 @@ import (
 
 const (
 	QuerychannelSettings = "channelSettings"
+	QuerySideChains      = "sideChains"
 )
 
 // creates a querier for staking REST endpoints
@@ -24,6 +25,12 @@ func NewQuerier(k Keeper) sdk.Querier {
 				return nil, ErrInvalidSideChainId(DefaultCodespace, "SideChainId is missing")
 			}
 			return queryChannelSettings(ctx, k, sideChainId)
+		case QuerySideChains:
+			res, err := k.cdc.MarshalJSON(k.GetSideChainRegistrations(ctx))
+			if err != nil {
+				return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+			}
+			return res, nil
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown side chain query endpoint")
 		}
@@ -0,0 +1,124 @@
+package sidechain
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+// RegisterSideChain binds the store prefix of a new side chain, sets its initial channel permissions, adds it
+// to the cross chain config and persists the registration so that it is loaded again on restart
+func (k *Keeper) RegisterSideChain(ctx sdk.Context, registration types.SideChainRegistration) error {
+	if err := k.checkSideChainRegistration(ctx, registration); err != nil {
+		return err
+	}
+	if err := k.RegisterDestChain(registration.SideChainId, registration.ChainId); err != nil {
+		return err
+	}
+
+	k.SetSideChainIdAndStorePrefix(ctx, registration.SideChainId, registration.StorePrefix)
+	for _, p := range registration.ChannelPermissions {
+		k.SetChannelSendPermission(ctx, registration.ChainId, p.ChannelId, p.Permission)
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetSideChainRegistrationKey(registration.SideChainId), k.cdc.MustMarshalBinaryLengthPrefixed(registration))
+	return nil
+}
+
+// GetSideChainRegistrations returns the side chains registered by gov, sorted by side chain id
+func (k *Keeper) GetSideChainRegistrations(ctx sdk.Context) []types.SideChainRegistration {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, SideChainRegistrationKey)
+	defer iterator.Close()
+
+	registrations := make([]types.SideChainRegistration, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var registration types.SideChainRegistration
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &registration)
+		registrations = append(registrations, registration)
+	}
+	return registrations
+}
+
+// LoadSideChainRegistrations adds the side chains registered by gov to the cross chain config, it should be
+// called once the store is loaded, after the side chains configured by the app are registered
+func (k *Keeper) LoadSideChainRegistrations(ctx sdk.Context) error {
+	for _, registration := range k.GetSideChainRegistrations(ctx) {
+		if id, ok := k.cfg.destChainNameToID[registration.SideChainId]; ok {
+			if id != registration.ChainId {
+				return fmt.Errorf("side chain %s is registered with chain id %d, but configured with %d",
+					registration.SideChainId, registration.ChainId, id)
+			}
+			continue
+		}
+		if err := k.RegisterDestChain(registration.SideChainId, registration.ChainId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *Keeper) checkSideChainRegistration(ctx sdk.Context, registration types.SideChainRegistration) error {
+	if err := registration.Check(); err != nil {
+		return err
+	}
+	if strings.Contains(registration.SideChainId, separator) {
+		return fmt.Errorf("side chain id should not contains %s", separator)
+	}
+	if _, ok := k.cfg.destChainNameToID[registration.SideChainId]; ok {
+		return fmt.Errorf("side chain %s already exists", registration.SideChainId)
+	}
+	if _, ok := k.cfg.destChainIDToName[registration.ChainId]; ok {
+		return fmt.Errorf("chain id %d already exists", registration.ChainId)
+	}
+	if k.GetSideChainStorePrefix(ctx, registration.SideChainId) != nil {
+		return fmt.Errorf("store prefix of side chain %s already exists", registration.SideChainId)
+	}
+	// the keys of a side chain must never fall under the store prefix of another side chain
+	_, prefixes := k.GetAllSideChainPrefixes(ctx)
+	for _, prefix := range prefixes {
+		if bytes.HasPrefix(prefix, registration.StorePrefix) || bytes.HasPrefix(registration.StorePrefix, prefix) {
+			return fmt.Errorf("store prefix %X conflicts with the existing store prefix %X", registration.StorePrefix, prefix)
+		}
+	}
+	for _, p := range registration.ChannelPermissions {
+		if _, ok := k.cfg.channelIDToName[p.ChannelId]; !ok {
+			return fmt.Errorf("channel %d does not exist", p.ChannelId)
+		}
+	}
+	return nil
+}
+
+func (k *Keeper) getLastSideChainRegistrations(ctx sdk.Context) []types.SideChainRegistration {
+	registrations := make([]types.SideChainRegistration, 0)
+	// It can still find the valid proposal if the block chain stop for SafeToleratePeriod time
+	backPeriod := SafeToleratePeriod + gov.MaxVotingPeriod
+	k.govKeeper.Iterate(ctx, nil, nil, gov.StatusNil, 0, true, func(proposal gov.Proposal) bool {
+		if proposal.GetProposalType() == gov.ProposalTypeRegisterSideChain {
+			if ctx.BlockHeader().Time.Sub(proposal.GetVotingStartTime()) > backPeriod {
+				return true
+			}
+			if proposal.GetStatus() != gov.StatusPassed {
+				return false
+			}
+
+			proposal.SetStatus(gov.StatusExecuted)
+			k.govKeeper.SetProposal(ctx, proposal)
+
+			var registration types.SideChainRegistration
+			err := k.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &registration)
+			if err != nil {
+				ctx.Logger().With("module", "side_chain").Error("Get broken data when unmarshal SideChainRegistration msg, will skip.",
+					"proposalId", proposal.GetProposalID(), "err", err)
+				return false
+			}
+			registrations = append(registrations, registration)
+		}
+		return false
+	})
+	return registrations
+}
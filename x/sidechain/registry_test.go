@@ -0,0 +1,85 @@
+package sidechain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+func TestRegisterSideChain(t *testing.T) {
+	ctx, keeper := CreateTestInput(t, false)
+	require.NoError(t, keeper.RegisterChannel("bind", sdk.ChannelID(1), nil))
+	require.NoError(t, keeper.RegisterChannel("transfer", sdk.ChannelID(2), nil))
+	keeper.SetSideChainIdAndStorePrefix(ctx, "axc", []byte{0x99})
+	require.NoError(t, keeper.RegisterDestChain("axc", sdk.ChainID(1)))
+
+	registration := types.SideChainRegistration{
+		SideChainId: "abc",
+		ChainId:     sdk.ChainID(2),
+		StorePrefix: []byte{0x98},
+		ChannelPermissions: []types.ChannelPermission{
+			{ChannelId: sdk.ChannelID(1), Permission: sdk.ChannelAllow},
+		},
+	}
+	require.NoError(t, keeper.RegisterSideChain(ctx, registration))
+
+	chainId, err := keeper.GetDestChainID("abc")
+	require.NoError(t, err)
+	require.Equal(t, sdk.ChainID(2), chainId)
+	require.Equal(t, []byte{0x98}, keeper.GetSideChainStorePrefix(ctx, "abc"))
+	require.Equal(t, sdk.ChannelAllow, keeper.GetChannelSendPermission(ctx, chainId, sdk.ChannelID(1)))
+	require.Equal(t, sdk.ChannelForbidden, keeper.GetChannelSendPermission(ctx, chainId, sdk.ChannelID(2)))
+	require.Equal(t, []types.SideChainRegistration{registration}, keeper.GetSideChainRegistrations(ctx))
+
+	conflicts := []types.SideChainRegistration{
+		{SideChainId: "abc", ChainId: sdk.ChainID(3), StorePrefix: []byte{0x97}},
+		{SideChainId: "axc", ChainId: sdk.ChainID(3), StorePrefix: []byte{0x97}},
+		{SideChainId: "xyz", ChainId: sdk.ChainID(1), StorePrefix: []byte{0x97}},
+		{SideChainId: "xyz", ChainId: sdk.ChainID(3), StorePrefix: []byte{0x99, 0x01}},
+		{SideChainId: "xyz", ChainId: sdk.ChainID(3), StorePrefix: []byte{}},
+		{SideChainId: "x::z", ChainId: sdk.ChainID(3), StorePrefix: []byte{0x97}},
+		{SideChainId: "xyz", ChainId: sdk.ChainID(3), StorePrefix: []byte{0x97},
+			ChannelPermissions: []types.ChannelPermission{{ChannelId: sdk.ChannelID(5), Permission: sdk.ChannelAllow}}},
+		{SideChainId: "xyz", ChainId: sdk.ChainID(3), StorePrefix: []byte{0x97},
+			ChannelPermissions: []types.ChannelPermission{{ChannelId: types.GovChannelId, Permission: sdk.ChannelAllow}}},
+	}
+	for _, conflict := range conflicts {
+		require.Error(t, keeper.RegisterSideChain(ctx, conflict), "registration %v should be rejected", conflict)
+	}
+	_, err = keeper.GetDestChainID("xyz")
+	require.Error(t, err)
+	require.Len(t, keeper.GetSideChainRegistrations(ctx), 1)
+}
+
+func TestLoadSideChainRegistrations(t *testing.T) {
+	ctx, keeper := CreateTestInput(t, false)
+	require.NoError(t, keeper.RegisterSideChain(ctx, types.SideChainRegistration{
+		SideChainId: "abc",
+		ChainId:     sdk.ChainID(2),
+		StorePrefix: []byte{0x98},
+	}))
+
+	// a restarted node only has the side chains configured by the app
+	restarted := keeper
+	restarted.cfg = newCrossChainCfg()
+	require.NoError(t, restarted.RegisterDestChain("axc", sdk.ChainID(1)))
+	_, err := restarted.GetDestChainID("abc")
+	require.Error(t, err)
+
+	require.NoError(t, restarted.LoadSideChainRegistrations(ctx))
+	chainId, err := restarted.GetDestChainID("abc")
+	require.NoError(t, err)
+	require.Equal(t, sdk.ChainID(2), chainId)
+
+	// loading twice is harmless
+	require.NoError(t, restarted.LoadSideChainRegistrations(ctx))
+
+	// a registration which disagrees with the app config is an error
+	conflicting := keeper
+	conflicting.cfg = newCrossChainCfg()
+	require.NoError(t, conflicting.RegisterDestChain("abc", sdk.ChainID(3)))
+	require.Error(t, conflicting.LoadSideChainRegistrations(ctx))
+}
@@ -68,3 +68,43 @@ func (c *ChanPermissionSetting) Check() error {
 	}
 	return nil
 }
+
+// ChannelPermission is the initial send permission of a channel of a registered side chain
+type ChannelPermission struct {
+	ChannelId  sdk.ChannelID         `json:"channel_id"`
+	Permission sdk.ChannelPermission `json:"permission"`
+}
+
+// SideChainRegistration describes a side chain registered by a RegisterSideChain proposal
+type SideChainRegistration struct {
+	SideChainId        string              `json:"side_chain_id"`
+	ChainId            sdk.ChainID         `json:"chain_id"`
+	StorePrefix        []byte              `json:"store_prefix"`
+	ChannelPermissions []ChannelPermission `json:"channel_permissions"`
+}
+
+func (r *SideChainRegistration) Check() error {
+	if len(r.SideChainId) == 0 || len(r.SideChainId) > MaxSideChainIdLength {
+		return fmt.Errorf("invalid side chain id")
+	}
+	if r.ChainId == 0 {
+		return fmt.Errorf("chain id should not be 0")
+	}
+	if len(r.StorePrefix) == 0 {
+		return fmt.Errorf("store prefix should not be empty")
+	}
+	channels := make(map[sdk.ChannelID]bool, len(r.ChannelPermissions))
+	for _, p := range r.ChannelPermissions {
+		if p.ChannelId == GovChannelId {
+			return fmt.Errorf("gov channel id is forbidden to set")
+		}
+		if p.Permission != sdk.ChannelAllow && p.Permission != sdk.ChannelForbidden {
+			return fmt.Errorf("permission %d is invalid", p.Permission)
+		}
+		if channels[p.ChannelId] {
+			return fmt.Errorf("duplicated permission of channel %d", p.ChannelId)
+		}
+		channels[p.ChannelId] = true
+	}
+	return nil
+}
@@ -1,8 +1,10 @@
-//nolint
+// nolint
 package slashing
 
 import (
 	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/axc"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -27,6 +29,12 @@ const (
 	CodeInvalidEvidence        CodeType = 204
 	CodeInvalidSideChain       CodeType = 205
 	CodeDuplicateDowntimeClaim CodeType = 206
+
+	CodeInvalidEvidenceHeader    CodeType = 207
+	CodeEvidenceHeightMismatch   CodeType = 208
+	CodeIdenticalEvidenceHeaders CodeType = 209
+	CodeEvidenceSignerMismatch   CodeType = 210
+	CodeEvidenceSignerNotInSet   CodeType = 211
 )
 
 func ErrNoValidatorForAddress(codespace sdk.CodespaceType) sdk.Error {
@@ -73,6 +81,26 @@ func ErrInvalidEvidence(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidEvidence, msg)
 }
 
+func ErrInvalidEvidenceHeader(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidEvidenceHeader, msg)
+}
+
+func ErrEvidenceHeightMismatch(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeEvidenceHeightMismatch, "The numbers of two block headers are not the same")
+}
+
+func ErrIdenticalEvidenceHeaders(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeIdenticalEvidenceHeaders, "The two blocks are the same")
+}
+
+func ErrEvidenceSignerMismatch(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeEvidenceSignerMismatch, "The signers of two block headers are not the same")
+}
+
+func ErrEvidenceSignerNotInSet(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeEvidenceSignerNotInSet, "The signer of block headers is not a validator of the side chain")
+}
+
 func ErrInvalidSideChainId(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidSideChain, "invalid side chain id")
 }
@@ -84,3 +112,21 @@ func ErrDuplicateDowntimeClaim(codespace sdk.CodespaceType) sdk.Error {
 func ErrInvalidInput(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidInput, msg)
 }
+
+// evidenceError maps the errors of axc evidence verification to slashing errors
+func evidenceError(codespace sdk.CodespaceType, err error) sdk.Error {
+	switch err {
+	case axc.ErrMissingVanity, axc.ErrMissingSignature, axc.ErrExtraValidators, axc.ErrInvalidEpochValidators:
+		return ErrInvalidEvidenceHeader(codespace, err.Error())
+	case axc.ErrHeightMismatch:
+		return ErrEvidenceHeightMismatch(codespace)
+	case axc.ErrIdenticalHeaders:
+		return ErrIdenticalEvidenceHeaders(codespace)
+	case axc.ErrSignerMismatch:
+		return ErrEvidenceSignerMismatch(codespace)
+	case axc.ErrSignerNotInValidatorSet:
+		return ErrEvidenceSignerNotInSet(codespace)
+	default:
+		return ErrInvalidEvidence(codespace, err.Error())
+	}
+}
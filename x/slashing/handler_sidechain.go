@@ -25,24 +25,43 @@ func handleMsgAxcSubmitEvidence(ctx sdk.Context, msg MsgAxcSubmitEvidence, k Kee
 	header := ctx.BlockHeader()
 
 	var sideConsAddr axc.Address
-	var sideConsAddr2 axc.Address
-	var err2 error
-
-	if sdk.IsUpgrade(sdk.FixDoubleSignChainId) {
-		sideConsAddr, err = msg.Headers[0].ExtractSignerFromHeader(chainID)
-		sideConsAddr2, err2 = msg.Headers[1].ExtractSignerFromHeader(chainID)
+	if sdk.IsUpgrade(sdk.ParliaEvidence) {
+		sideConsAddr, err = axc.VerifyDoubleSign(&msg.Headers[0], &msg.Headers[1], chainID, axc.DefaultEpochLength)
+		if err != nil {
+			return evidenceError(k.Codespace, err).Result()
+		}
+		if k.validatorSet.ValidatorBySideChainConsAddr(sideCtx, sideConsAddr.Bytes()) == nil {
+			return ErrEvidenceSignerNotInSet(k.Codespace).Result()
+		}
+		// the signer must also be in the validator set sealing the headers, which is tracked by the light client
+		// of the side chain as long as the parent of the headers is in its window
+		if k.lcKeeper != nil {
+			if validators, found := k.lcKeeper.GetValidatorsAt(ctx, sideChainId, msg.Headers[0].Number); found {
+				if err := axc.VerifySignerInValidatorSet(sideConsAddr, validators); err != nil {
+					return evidenceError(k.Codespace, err).Result()
+				}
+			}
+		}
 	} else {
-		sideConsAddr, err = msg.Headers[0].ExtractSignerFromHeader(nil)
-		sideConsAddr2, err2 = msg.Headers[1].ExtractSignerFromHeader(nil)
-	}
-	if err != nil {
-		return ErrInvalidEvidence(DefaultCodespace, fmt.Sprintf("Failed to extract signer from block header, %s", err.Error())).Result()
-	}
-	if err2 != nil {
-		return ErrInvalidEvidence(DefaultCodespace, fmt.Sprintf("Failed to extract signer from block header, %s", err.Error())).Result()
-	}
-	if bytes.Compare(sideConsAddr.Bytes(), sideConsAddr2.Bytes()) != 0 {
-		return ErrInvalidEvidence(DefaultCodespace, "The signers of two block headers are not the same").Result()
+		var sideConsAddr2 axc.Address
+		var err2 error
+
+		if sdk.IsUpgrade(sdk.FixDoubleSignChainId) {
+			sideConsAddr, err = msg.Headers[0].ExtractSignerFromHeader(chainID)
+			sideConsAddr2, err2 = msg.Headers[1].ExtractSignerFromHeader(chainID)
+		} else {
+			sideConsAddr, err = msg.Headers[0].ExtractSignerFromHeader(nil)
+			sideConsAddr2, err2 = msg.Headers[1].ExtractSignerFromHeader(nil)
+		}
+		if err != nil {
+			return ErrInvalidEvidence(DefaultCodespace, fmt.Sprintf("Failed to extract signer from block header, %s", err.Error())).Result()
+		}
+		if err2 != nil {
+			return ErrInvalidEvidence(DefaultCodespace, fmt.Sprintf("Failed to extract signer from block header, %s", err.Error())).Result()
+		}
+		if bytes.Compare(sideConsAddr.Bytes(), sideConsAddr2.Bytes()) != 0 {
+			return ErrInvalidEvidence(DefaultCodespace, "The signers of two block headers are not the same").Result()
+		}
 	}
 
 	if k.hasSlashRecord(sideCtx, sideConsAddr.Bytes(), DoubleSign, uint64(msg.Headers[0].Number)) {
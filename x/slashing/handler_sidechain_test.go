@@ -15,6 +15,30 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+const doubleSignHeadersJson = `[{"parentHash":"0x6116de25352c93149542e950162c7305f207bbc17b0eb725136b78c80aed79cc","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0xe7cb9d2fd449f7bd11126bff55266e7b74936f2f230e21d44d75c04b7780dfeb","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x20000","number":"0x1","gasLimit":"0x47e7c4","gasUsed":"0x0","timestamp":"0x5ea6a002","extraData":"0x0000000000000000000000000000000000000000000000000000000000000000fc3e4bbcd4936a8e1fd9fc45461d071ca571ca80fbed85e0cc52e007ed557aff0a6ea1875b4e13171d301037036b3a26af3c7c2b317487323fd7557df717856b00","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","hash":"0x1532065752393ff2f6e7ef9b64f80d6e10efe42a4d9bdd8149fcbac6f86b365b"},{"parentHash":"0x6116de25352c93149542e950162c7305f207bbc17b0eb725136b78c80aed79cc","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0xe7cb9d2fd449f7bd11126bff55266e7b74936f2f230e21d44d75c04b7780dfeb","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x20000","number":"0x1","gasLimit":"0x47e7c4","gasUsed":"0x64","timestamp":"0x5ea6a002","extraData":"0x00000000000000000000000000000000000000000000000000000000000000003a849df14e9cc1502f218431c449f239a51fddb1fd408ca37e61834adf921f0c21fd269c86acf7f0b40aa7ce691bbd7f446d8234a4a6b19a98c77614da9a5fcb01","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","hash":"0x811a42453f826f05e9d85998551636f59eb740d5b03fe2416700058a4f31ca1e"}]`
+
+// loadDoubleSignHeaders returns two conflicting headers of number 1 sealed by 0xed24ff64903c07B5bD57C898CE0967D407aFCB0d
+func loadDoubleSignHeaders(t *testing.T) []axc.Header {
+	headers := make([]axc.Header, 0)
+	err := json.Unmarshal([]byte(doubleSignHeadersJson), &headers)
+	require.Nil(t, err)
+	return headers
+}
+
+func toSideAddr(bz []byte) (addr axc.Address) {
+	copy(addr[:], bz)
+	return
+}
+
+type mockLightClientKeeper struct {
+	validators map[int64][]axc.Address
+}
+
+func (k mockLightClientKeeper) GetValidatorsAt(_ sdk.Context, _ string, number int64) ([]axc.Address, bool) {
+	validators, found := k.validators[number]
+	return validators, found
+}
+
 func TestSideChainSlashDoubleSign(t *testing.T) {
 	slashParams := DefaultParams()
 	slashParams.DoubleSignUnbondDuration = 5 * time.Second
@@ -49,10 +73,7 @@ func TestSideChainSlashDoubleSign(t *testing.T) {
 	require.EqualValues(t, bondAmount*2, stakingPoolBalance)
 
 	ctx = ctx.WithBlockHeight(300)
-	headers := make([]axc.Header, 0)
-	headersJson := `[{"parentHash":"0x6116de25352c93149542e950162c7305f207bbc17b0eb725136b78c80aed79cc","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0xe7cb9d2fd449f7bd11126bff55266e7b74936f2f230e21d44d75c04b7780dfeb","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x20000","number":"0x1","gasLimit":"0x47e7c4","gasUsed":"0x0","timestamp":"0x5ea6a002","extraData":"0x0000000000000000000000000000000000000000000000000000000000000000fc3e4bbcd4936a8e1fd9fc45461d071ca571ca80fbed85e0cc52e007ed557aff0a6ea1875b4e13171d301037036b3a26af3c7c2b317487323fd7557df717856b00","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","hash":"0x1532065752393ff2f6e7ef9b64f80d6e10efe42a4d9bdd8149fcbac6f86b365b"},{"parentHash":"0x6116de25352c93149542e950162c7305f207bbc17b0eb725136b78c80aed79cc","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0xe7cb9d2fd449f7bd11126bff55266e7b74936f2f230e21d44d75c04b7780dfeb","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x20000","number":"0x1","gasLimit":"0x47e7c4","gasUsed":"0x64","timestamp":"0x5ea6a002","extraData":"0x00000000000000000000000000000000000000000000000000000000000000003a849df14e9cc1502f218431c449f239a51fddb1fd408ca37e61834adf921f0c21fd269c86acf7f0b40aa7ce691bbd7f446d8234a4a6b19a98c77614da9a5fcb01","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","hash":"0x811a42453f826f05e9d85998551636f59eb740d5b03fe2416700058a4f31ca1e"}]`
-	err = json.Unmarshal([]byte(headersJson), &headers)
-	require.Nil(t, err)
+	headers := loadDoubleSignHeaders(t)
 
 	feesInPoolBefore := fees.Pool.BlockFees().Tokens.AmountOf("steak")
 	msgSubmitEvidence := NewMsgAxcSubmitEvidence(submitter, headers)
@@ -90,7 +111,7 @@ func TestSideChainSlashDoubleSign(t *testing.T) {
 	expectedAfterSubmitterBalance := submitterBalance + realSubmitterReward
 	// send submit evidence tx
 	ctx = ctx.WithBlockHeight(350).WithBlockTime(time.Now())
-	headersJson = `[{"parentHash":"0x9dc70cfc956472119b82b6bbc1e6be139a68d03e99a4dcec1ccd0d9b4fd9c822","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0x0988fe1673073b5e1c5f052e5a9a30ec871f90768041a7bfed5ee03f6304b138","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x20000","number":"0x2","gasLimit":"0x47e7c4","gasUsed":"0x0","timestamp":"0x5eb8fc64","extraData":"0x00000000000000000000000000000000000000000000000000000000000000001d5b50270c673b96065304de53acb9617ef235be1ab6a7c16d7a660c2b13a8c22f513f4f8f43f427d64cbe57e23cd73f86e1efc79cabc1a89392a9e1c267f57d00","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","hash":"0x132a6caa72f3e5e98b086c5bcf2d7fe95ac612152114caca3e95bc8ec8e068a0"},{"parentHash":"0x9dc70cfc956472119b82b6bbc1e6be139a68d03e99a4dcec1ccd0d9b4fd9c822","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0x0988fe1673073b5e1c5f052e5a9a30ec871f90768041a7bfed5ee03f6304b138","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x20000","number":"0x2","gasLimit":"0x47e7c4","gasUsed":"0x64","timestamp":"0x5eb8fc64","extraData":"0x000000000000000000000000000000000000000000000000000000000000000065f3ddb3c4d6a42f220ead9cb60eebaef4f31f198dbfc18f3b226b84dde4f9232dca010aff3c8a647fe9e51a3f59b6e46fee461d456e69fafd33362faec0813601","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","hash":"0x9c4f11247e697a75ba633f87112895d537156265bf52b6e85e43e551b4d1cb78"}]`
+	headersJson := `[{"parentHash":"0x9dc70cfc956472119b82b6bbc1e6be139a68d03e99a4dcec1ccd0d9b4fd9c822","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0x0988fe1673073b5e1c5f052e5a9a30ec871f90768041a7bfed5ee03f6304b138","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x20000","number":"0x2","gasLimit":"0x47e7c4","gasUsed":"0x0","timestamp":"0x5eb8fc64","extraData":"0x00000000000000000000000000000000000000000000000000000000000000001d5b50270c673b96065304de53acb9617ef235be1ab6a7c16d7a660c2b13a8c22f513f4f8f43f427d64cbe57e23cd73f86e1efc79cabc1a89392a9e1c267f57d00","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","hash":"0x132a6caa72f3e5e98b086c5bcf2d7fe95ac612152114caca3e95bc8ec8e068a0"},{"parentHash":"0x9dc70cfc956472119b82b6bbc1e6be139a68d03e99a4dcec1ccd0d9b4fd9c822","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0x0988fe1673073b5e1c5f052e5a9a30ec871f90768041a7bfed5ee03f6304b138","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x20000","number":"0x2","gasLimit":"0x47e7c4","gasUsed":"0x64","timestamp":"0x5eb8fc64","extraData":"0x000000000000000000000000000000000000000000000000000000000000000065f3ddb3c4d6a42f220ead9cb60eebaef4f31f198dbfc18f3b226b84dde4f9232dca010aff3c8a647fe9e51a3f59b6e46fee461d456e69fafd33362faec0813601","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","hash":"0x9c4f11247e697a75ba633f87112895d537156265bf52b6e85e43e551b4d1cb78"}]`
 	err = json.Unmarshal([]byte(headersJson), &headers)
 	require.Nil(t, err)
 	msgSubmitEvidence = NewMsgAxcSubmitEvidence(submitter, headers)
@@ -158,10 +179,7 @@ func TestSideChainSlashDoubleSignUBD(t *testing.T) {
 	stake.EndBreatheBlock(ctx, stakeKeeper)
 
 	ctx = ctx.WithBlockHeight(201)
-	headers := make([]axc.Header, 0)
-	headersJson := `[{"parentHash":"0x6116de25352c93149542e950162c7305f207bbc17b0eb725136b78c80aed79cc","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0xe7cb9d2fd449f7bd11126bff55266e7b74936f2f230e21d44d75c04b7780dfeb","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x20000","number":"0x1","gasLimit":"0x47e7c4","gasUsed":"0x0","timestamp":"0x5ea6a002","extraData":"0x0000000000000000000000000000000000000000000000000000000000000000fc3e4bbcd4936a8e1fd9fc45461d071ca571ca80fbed85e0cc52e007ed557aff0a6ea1875b4e13171d301037036b3a26af3c7c2b317487323fd7557df717856b00","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","hash":"0x1532065752393ff2f6e7ef9b64f80d6e10efe42a4d9bdd8149fcbac6f86b365b"},{"parentHash":"0x6116de25352c93149542e950162c7305f207bbc17b0eb725136b78c80aed79cc","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0xe7cb9d2fd449f7bd11126bff55266e7b74936f2f230e21d44d75c04b7780dfeb","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x20000","number":"0x1","gasLimit":"0x47e7c4","gasUsed":"0x64","timestamp":"0x5ea6a002","extraData":"0x00000000000000000000000000000000000000000000000000000000000000003a849df14e9cc1502f218431c449f239a51fddb1fd408ca37e61834adf921f0c21fd269c86acf7f0b40aa7ce691bbd7f446d8234a4a6b19a98c77614da9a5fcb01","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","hash":"0x811a42453f826f05e9d85998551636f59eb740d5b03fe2416700058a4f31ca1e"}]`
-	err = json.Unmarshal([]byte(headersJson), &headers)
-	require.Nil(t, err)
+	headers := loadDoubleSignHeaders(t)
 
 	feesInPoolBefore := fees.Pool.BlockFees().Tokens.AmountOf("steak")
 
@@ -190,3 +208,60 @@ func TestSideChainSlashDoubleSignUBD(t *testing.T) {
 	require.EqualValues(t, 4000e8, stakingPoolBalance)
 
 }
+
+func TestSideChainSlashDoubleSignParliaEvidence(t *testing.T) {
+	defer sdk.UpgradeMgr.AddUpgradeHeight(sdk.ParliaEvidence, 0)
+	slashParams := DefaultParams()
+	slashParams.MaxEvidenceAge = math.MaxInt64
+	submitter := sdk.AccAddress(addrs[2])
+	ctx, sideCtx, _, stakeKeeper, _, keeper := createSideTestInput(t, slashParams)
+
+	ctx = ctx.WithBlockHeight(300)
+	headers := loadDoubleSignHeaders(t)
+	mSideConsAddr, err := sdk.HexDecode("0xed24ff64903c07B5bD57C898CE0967D407aFCB0d")
+	require.Nil(t, err)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.FixDoubleSignChainId, 199)
+	sdk.UpgradeMgr.SetHeight(200)
+
+	// the legacy checks and errors apply before the upgrade
+	msgSubmitEvidence := NewMsgAxcSubmitEvidence(submitter, []axc.Header{headers[0], headers[1], headers[1]})
+	require.EqualValues(t, CodeInvalidEvidence, msgSubmitEvidence.ValidateBasic().Code())
+	msgSubmitEvidence = NewMsgAxcSubmitEvidence(submitter, []axc.Header{headers[0], headers[0]})
+	require.EqualValues(t, CodeInvalidEvidence, msgSubmitEvidence.ValidateBasic().Code())
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ParliaEvidence, 200)
+
+	msgSubmitEvidence = NewMsgAxcSubmitEvidence(submitter, []axc.Header{headers[0], headers[1], headers[1]})
+	require.EqualValues(t, CodeInvalidEvidence, msgSubmitEvidence.ValidateBasic().Code())
+	msgSubmitEvidence = NewMsgAxcSubmitEvidence(submitter, []axc.Header{headers[0], headers[0]})
+	require.EqualValues(t, CodeIdenticalEvidenceHeaders, msgSubmitEvidence.ValidateBasic().Code())
+
+	// the signer must be a validator of the side chain
+	msgSubmitEvidence = NewMsgAxcSubmitEvidence(submitter, headers)
+	require.Nil(t, msgSubmitEvidence.ValidateBasic())
+	res := NewHandler(keeper)(ctx, msgSubmitEvidence)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeEvidenceSignerNotInSet), res.Code, res.Log)
+
+	mValAddr := addrs[0]
+	msgCreateVal := newTestMsgCreateSideValidator(mValAddr, mSideConsAddr, createSideAddr(20), 10000e8)
+	got := stake.NewHandler(stakeKeeper, gov.Keeper{})(ctx.WithBlockHeight(100), msgCreateVal)
+	require.True(t, got.IsOK(), "expected create validator msg to be ok, got: %v", got)
+	stake.EndBreatheBlock(ctx.WithBlockHeight(100), stakeKeeper)
+
+	// the signer must be in the validator set sealing the headers known by the light client, even if it's a validator now
+	keeper.SetLightClientKeeper(mockLightClientKeeper{validators: map[int64][]axc.Address{
+		1: {toSideAddr(createSideAddr(20))},
+	}})
+	res = NewHandler(keeper)(ctx, msgSubmitEvidence)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeEvidenceSignerNotInSet), res.Code, res.Log)
+
+	keeper.SetLightClientKeeper(mockLightClientKeeper{validators: map[int64][]axc.Address{
+		1: {toSideAddr(createSideAddr(20)), toSideAddr(mSideConsAddr)},
+	}})
+	res = NewHandler(keeper)(ctx, msgSubmitEvidence)
+	require.True(t, res.IsOK(), res.Log)
+
+	mValidator, found := stakeKeeper.GetValidator(sideCtx, mValAddr)
+	require.True(t, found)
+	require.True(t, mValidator.Jailed)
+}
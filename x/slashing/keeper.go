@@ -34,6 +34,7 @@ type Keeper struct {
 
 	BankKeeper bank.Keeper
 	ScKeeper   *sidechain.Keeper
+	lcKeeper   LightClientKeeper
 
 	PbsbServer *pubsub.Server
 }
@@ -67,6 +68,10 @@ func (k *Keeper) SetPbsbServer(server *pubsub.Server) {
 	k.PbsbServer = server
 }
 
+func (k *Keeper) SetLightClientKeeper(lcKeeper LightClientKeeper) {
+	k.lcKeeper = lcKeeper
+}
+
 // handle a validator signing two blocks at the same height
 // power: power of the double-signing validator at the height of infraction
 func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractionHeight int64, timestamp time.Time, power int64) {
@@ -137,6 +137,25 @@ func (msg MsgAxcSubmitEvidence) ValidateBasic() sdk.Error {
 	if len(msg.Submitter) != sdk.AddrLen {
 		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.Submitter)))
 	}
+	if !sdk.IsUpgrade(sdk.ParliaEvidence) {
+		return msg.validateBasicLegacy()
+	}
+	if len(msg.Headers) != 2 {
+		return ErrInvalidEvidence(DefaultCodespace, "Must have 2 headers exactly")
+	}
+	if err := headerEmptyCheck(msg.Headers[0]); err != nil {
+		return err
+	}
+	if err := headerEmptyCheck(msg.Headers[1]); err != nil {
+		return err
+	}
+	if err := axc.CheckEquivocation(&msg.Headers[0], &msg.Headers[1], axc.DefaultEpochLength); err != nil {
+		return evidenceError(DefaultCodespace, err)
+	}
+	return nil
+}
+
+func (msg MsgAxcSubmitEvidence) validateBasicLegacy() sdk.Error {
 	if len(msg.Headers) != 2 {
 		return ErrInvalidEvidence(DefaultCodespace, "Must have 2 headers exactly")
 	}
@@ -26,3 +26,8 @@ type SideSlashEvent struct {
 func (event SideSlashEvent) GetTopic() pubsub.Topic {
 	return Topic
 }
+
+// RegisterJournalEvents registers the events of slashing, so that they are recorded in the pubsub journal
+func RegisterJournalEvents(journal *pubsub.Journal) {
+	journal.RegisterEvent(SideSlashEvent{}, "slashing/SideSlashEvent")
+}
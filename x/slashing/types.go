@@ -1,6 +1,14 @@
 package slashing
 
-import "github.com/cosmos/cosmos-sdk/types"
+import (
+	"github.com/cosmos/cosmos-sdk/axc"
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// LightClientKeeper provides the validator sets of the side chain tracked by its light client
+type LightClientKeeper interface {
+	GetValidatorsAt(ctx types.Context, sideChainId string, number int64) ([]axc.Address, bool)
+}
 
 type SideDowntimeSlashPackage struct {
 	SideConsAddr  []byte        `json:"side_cons_addr"`
@@ -22,6 +22,7 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 			GetCmdCreateValidatorOpen(cdc),
 			GetCmdEditValidator(cdc),
 			GetCmdDelegate(cdc),
+			GetCmdSetAutoCompound(cdc),
 			GetCmdRedelegate(storeKey, cdc),
 			GetCmdUnbond(storeKey, cdc),
 		)...,
@@ -70,6 +71,11 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 			GetCmdQuerySideChainTopValidators(cdc),
 			GetCmdQuerySideAllValidatorsCount(cdc),
 			GetCmdQueryCrossStakeInfoByAxcAddress(cdc),
+			GetCmdQueryCrossStakeRequest(cdc),
+			GetCmdQueryArchivedHeights(cdc),
+			GetCmdQueryArchivedValidators(cdc),
+			GetCmdQueryArchivedDelegations(cdc),
+			GetCmdQueryRewardStatement(cdc),
 		)...,
 	)
 
@@ -40,6 +40,13 @@ const (
 	FlagSideChainId  = "side-chain-id"
 	FlagSideConsAddr = "side-cons-addr"
 	FlagSideFeeAddr  = "side-fee-addr"
+
+	FlagChannelId = "channel-id"
+
+	FlagStartDate = "start-date"
+	FlagEndDate   = "end-date"
+
+	FlagEnable = "enable"
 )
 
 // common flagsets to add to various functions
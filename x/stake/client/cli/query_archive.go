@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// GetCmdQueryArchivedHeights implements the query of the heights of the archived validator-set snapshots.
+func GetCmdQueryArchivedHeights(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "archived-heights",
+		Short: "Query the heights of the validator-set snapshots archived by the node",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			response, err := queryArchive(cliCtx, stake.QueryArchivedHeights, stake.NewBaseParams(viper.GetString(FlagSideChainId)))
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(response))
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsSideChainId)
+
+	return cmd
+}
+
+// GetCmdQueryArchivedValidators implements the query of the archived validator-set snapshot at a height.
+func GetCmdQueryArchivedValidators(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "archived-validators [height]",
+		Short: "Query the validator-set snapshot at the height archived by the node",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			response, err := queryArchive(cliCtx, stake.QueryArchivedValidators, stake.QueryArchivedSnapshotParams{
+				BaseParams: stake.NewBaseParams(viper.GetString(FlagSideChainId)),
+				Height:     height,
+			})
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				var vals []types.Validator
+				if err = cdc.UnmarshalJSON(response, &vals); err != nil {
+					return err
+				}
+				for _, val := range vals {
+					resp, err := val.HumanReadableString()
+					if err != nil {
+						return err
+					}
+					fmt.Println(resp)
+				}
+			case "json":
+				fmt.Println(string(response))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsSideChainId)
+
+	return cmd
+}
+
+// GetCmdQueryArchivedDelegations implements the query of the archived delegations of a validator at a height.
+func GetCmdQueryArchivedDelegations(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "archived-delegations [height] [validator-addr]",
+		Short: "Query the delegations of the validator snapshotted at the height archived by the node",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			valAddr, err := sdk.ValAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			response, err := queryArchive(cliCtx, stake.QueryArchivedDelegations, stake.QueryArchivedSnapshotParams{
+				BaseParams:    stake.NewBaseParams(viper.GetString(FlagSideChainId)),
+				Height:        height,
+				ValidatorAddr: valAddr,
+			})
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				var simDels []stake.SimplifiedDelegation
+				if err = cdc.UnmarshalJSON(response, &simDels); err != nil {
+					return err
+				}
+				for _, del := range simDels {
+					fmt.Printf("Delegator: %s\nShares: %s\nCross Stake: %v\n", del.DelegatorAddr, del.Shares, del.CrossStake)
+				}
+			case "json":
+				fmt.Println(string(response))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsSideChainId)
+
+	return cmd
+}
+
+func queryArchive(cliCtx context.CLIContext, route string, params interface{}) ([]byte, error) {
+	bz, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	return cliCtx.QueryWithData("custom/stake/"+route, bz)
+}
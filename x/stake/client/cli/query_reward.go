@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+const statementDateFormat = "2006-01-02"
+
+// GetCmdQueryRewardStatement implements the command to write the rewards credited to a delegator as a CSV statement.
+func GetCmdQueryRewardStatement(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reward-statement [delegator-addr]",
+		Short: "Write the rewards credited to a delegator between two dates (UTC, both inclusive) as a CSV statement",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			delAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			start, err := time.Parse(statementDateFormat, viper.GetString(FlagStartDate))
+			if err != nil {
+				return fmt.Errorf("invalid %s: %v", FlagStartDate, err)
+			}
+			end, err := time.Parse(statementDateFormat, viper.GetString(FlagEndDate))
+			if err != nil {
+				return fmt.Errorf("invalid %s: %v", FlagEndDate, err)
+			}
+			var valAddr sdk.ValAddress
+			if addr := viper.GetString(FlagAddressValidator); len(addr) != 0 {
+				if valAddr, err = sdk.ValAddressFromBech32(addr); err != nil {
+					return err
+				}
+			}
+
+			params := stake.QueryRewardLedgerParams{
+				BaseParams:    stake.NewBaseParams(viper.GetString(FlagSideChainId)),
+				DelegatorAddr: delAddr,
+				ValidatorAddr: valAddr,
+				StartTime:     start,
+				EndTime:       end.AddDate(0, 0, 1),
+			}
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			response, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryRewardLedger, bz)
+			if err != nil {
+				return err
+			}
+			var entries []stake.RewardLedgerEntry
+			if err = cdc.UnmarshalJSON(response, &entries); err != nil {
+				return err
+			}
+
+			writer := csv.NewWriter(os.Stdout)
+			if err = writer.Write([]string{"time", "height", "validator", "delegator", "amount", "cross_stake"}); err != nil {
+				return err
+			}
+			for _, entry := range entries {
+				err = writer.Write([]string{
+					entry.Time.UTC().Format(time.RFC3339),
+					strconv.FormatInt(entry.Height, 10),
+					entry.ValidatorAddr.String(),
+					entry.DelegatorAddr.String(),
+					strconv.FormatInt(entry.Amount, 10),
+					strconv.FormatBool(entry.CrossStake),
+				})
+				if err != nil {
+					return err
+				}
+			}
+			writer.Flush()
+			return writer.Error()
+		},
+	}
+
+	cmd.Flags().String(FlagStartDate, "", "the first date of the statement, in the format of 2006-01-02")
+	cmd.Flags().String(FlagEndDate, "", "the last date of the statement, in the format of 2006-01-02")
+	cmd.Flags().String(FlagAddressValidator, "", "only the rewards of the delegation to the validator are written")
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	cmd.MarkFlagRequired(FlagStartDate)
+	cmd.MarkFlagRequired(FlagEndDate)
+
+	return cmd
+}
@@ -846,6 +846,65 @@ func GetCmdQueryCrossStakeInfoByAxcAddress(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdQueryCrossStakeRequest implements the cross stake request status query command.
+func GetCmdQueryCrossStakeRequest(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cross-stake-request [sequence]",
+		Short: "Query the status of a cross stake request received from the side chain",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sequence, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sideChainId, _, err := getSideChainConfig(cliCtx)
+			if err != nil {
+				return err
+			}
+
+			params := stake.QueryCrossStakeRequestParams{
+				BaseParams: stake.NewBaseParams(sideChainId),
+				ChannelId:  sdk.ChannelID(viper.GetUint(FlagChannelId)),
+				Sequence:   sequence,
+			}
+
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+
+			response, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryCrossStakeRequest, bz)
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				var request types.CrossStakeRequest
+				if err = cdc.UnmarshalJSON(response, &request); err != nil {
+					return err
+				}
+				resp, err := request.HumanReadableString()
+				if err != nil {
+					return err
+				}
+				fmt.Println(resp)
+			case "json":
+				fmt.Println(string(response))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Uint(FlagChannelId, uint(types.CrossStakeChannelID), "the channel the cross stake request is received on")
+	cmd.Flags().AddFlagSet(fsSideChainId)
+
+	return cmd
+}
+
 func getSideChainConfig(cliCtx context.CLIContext) (sideChainId string, prefix []byte, error error) {
 	sideChainId, error = getSideChainId()
 	if error != nil {
@@ -386,6 +386,38 @@ func GetCmdDelegate(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdSetAutoCompound implements the command to opt a delegation in or out of auto-compounding its rewards.
+func GetCmdSetAutoCompound(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-auto-compound",
+		Short: "delegate the rewards of a delegation to its validator again, the delegation is on the side chain if side-chain-id is given",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			delAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := sdk.ValAddressFromBech32(viper.GetString(FlagAddressValidator))
+			if err != nil {
+				return err
+			}
+
+			msg := stake.NewMsgSetAutoCompound(viper.GetString(FlagSideChainId), delAddr, valAddr, viper.GetBool(FlagEnable))
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().Bool(FlagEnable, true, "whether the rewards of the delegation are auto-compounded")
+	cmd.Flags().AddFlagSet(fsValidator)
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	return cmd
+}
+
 // GetCmdRedelegate implements the redelegate validator command.
 func GetCmdRedelegate(storeName string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
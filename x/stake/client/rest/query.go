@@ -1,7 +1,9 @@
 package rest
 
 import (
+	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -9,6 +11,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/utils"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 	"github.com/gorilla/mux"
 )
@@ -101,6 +104,12 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Co
 		paramsHandlerFn(cliCtx, cdc),
 	).Methods("GET")
 
+	// Get the status of a cross stake request received from a side chain
+	r.HandleFunc(
+		"/stake/cross_stake_requests/{channelId}/{sequence}",
+		crossStakeRequestHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
 }
 
 // HTTP request handler to query a delegator delegations
@@ -256,3 +265,40 @@ func paramsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFu
 		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
 	}
 }
+
+// HTTP request handler to query the status of a cross stake request, the side chain is given by the
+// side_chain_id query parameter
+func crossStakeRequestHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		channelId, err := strconv.ParseUint(vars["channelId"], 10, 8)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		sequence, err := strconv.ParseUint(vars["sequence"], 10, 64)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		params := stake.QueryCrossStakeRequestParams{
+			BaseParams: stake.NewBaseParams(r.URL.Query().Get("side_chain_id")),
+			ChannelId:  sdk.ChannelID(channelId),
+			Sequence:   sequence,
+		}
+
+		bz, err := json.Marshal(params)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryCrossStakeRequest, bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
@@ -1,6 +1,7 @@
 package cross_stake
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/cosmos/cosmos-sdk/axc"
@@ -31,6 +32,9 @@ func (app *CrossStakeApp) ExecuteSynPackage(ctx sdk.Context, payload []byte, rel
 	pack, err := DeserializeCrossStakeSynPackage(payload)
 	if err != nil {
 		app.stakeKeeper.Logger(ctx).Error("unmarshal cross stake sync claim error", "err", err.Error(), "claim", string(payload))
+		if sdk.IsUpgrade(sdk.CrossStakeFailAck) {
+			return malformedPackageResult(payload, err)
+		}
 		panic("unmarshal cross stake claim error")
 	}
 
@@ -43,7 +47,16 @@ func (app *CrossStakeApp) ExecuteSynPackage(ctx sdk.Context, payload []byte, rel
 		result, errCode, err = app.handleUndelegate(ctx, p, relayFee)
 	case *types.CrossStakeRedelegateSynPackage:
 		result, errCode, err = app.handleRedelegate(ctx, p, relayFee)
+	case *types.CrossStakeClaimRewardSynPackage:
+		result, errCode, err = app.handleClaimReward(ctx, p, relayFee)
+	case *types.CrossStakeCompoundRewardSynPackage:
+		result, errCode, err = app.handleCompoundReward(ctx, p, relayFee)
+	case *types.CrossStakeBatchDelegateSynPackage:
+		result, errCode, err = app.handleBatchDelegate(ctx, p, relayFee)
 	default:
+		if sdk.IsUpgrade(sdk.CrossStakeFailAck) {
+			return malformedPackageResult(payload, fmt.Errorf("unknown cross stake syn package type %T", pack))
+		}
 		panic("Unknown cross stake syn package type")
 	}
 	if err != nil {
@@ -67,6 +80,24 @@ func (app *CrossStakeApp) ExecuteSynPackage(ctx sdk.Context, payload []byte, rel
 	return result
 }
 
+// malformedPackageResult fails a syn package which can not be handled, the ack package tells the side chain
+// to refund the request rather than crashing the execution of the claim
+func malformedPackageResult(payload []byte, err error) sdk.ExecuteResult {
+	ackPayload, encodeErr := rlp.EncodeToBytes(&types.CrossStakeAckPackage{
+		Status:    types.CrossStakeFailed,
+		ErrorCode: CrossStakeErrMalformedPackage,
+		PackBytes: payload,
+	})
+	if encodeErr != nil {
+		panic(encodeErr)
+	}
+	return sdk.ExecuteResult{
+		Err:     types.ErrDeserializePackageFailed(err.Error()),
+		Tags:    sdk.NewTags(types.TagCrossStakeErrorCode, []byte{CrossStakeErrMalformedPackage}),
+		Payload: ackPayload,
+	}
+}
+
 func (app *CrossStakeApp) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
 	if len(payload) == 0 {
 		app.stakeKeeper.Logger(ctx).Error("receive empty cross stake ack package")
@@ -81,7 +112,7 @@ func (app *CrossStakeApp) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk
 
 	var result sdk.ExecuteResult
 	switch pack.EventType {
-	case types.CrossStakeTypeDistributeReward:
+	case types.CrossStakeTypeDistributeReward, types.CrossStakeTypeClaimReward:
 		result, err = app.handleDistributeRewardRefund(ctx, pack)
 	case types.CrossStakeTypeDistributeUndelegated:
 		result, err = app.handleDistributeUndelegatedRefund(ctx, pack)
@@ -114,7 +145,7 @@ func (app *CrossStakeApp) ExecuteFailAckPackage(ctx sdk.Context, payload []byte)
 	case *types.CrossStakeDistributeRewardSynPackage:
 		bcAmount := axc.ConvertAXCAmountToBCAmount(p.Amount)
 		refundPackage := &types.CrossStakeRefundPackage{
-			EventType: types.CrossStakeTypeDistributeReward,
+			EventType: p.EventType,
 			Amount:    big.NewInt(bcAmount),
 			Recipient: p.Recipient,
 		}
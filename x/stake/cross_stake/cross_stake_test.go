@@ -0,0 +1,39 @@
+package cross_stake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/axc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/keeper"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestExecuteMalformedSynPackage(t *testing.T) {
+	ctx, _, k := keeper.CreateTestInput(t, false, 1000)
+	defer sdk.UpgradeMgr.Reset()
+	app := NewCrossStakeApp(k)
+
+	unknown, err := rlp.EncodeToBytes(CrossStakeSynPackageFromAXC{EventType: 100, ParamsBytes: []byte{0x01}})
+	require.NoError(t, err)
+	malformed := []byte{0xff, 0x01}
+
+	// the claim crashes before the upgrade
+	require.Panics(t, func() { app.ExecuteSynPackage(ctx, unknown, 0) })
+	require.Panics(t, func() { app.ExecuteSynPackage(ctx, malformed, 0) })
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.CrossStakeFailAck, 1)
+	for _, payload := range [][]byte{unknown, malformed} {
+		result := app.ExecuteSynPackage(ctx, payload, 0)
+		require.False(t, result.IsOk())
+		require.Equal(t, types.CodeDeserializePackageFailed, result.Err.Code())
+
+		var ack types.CrossStakeAckPackage
+		require.NoError(t, rlp.DecodeBytes(result.Payload, &ack))
+		require.Equal(t, types.CrossStakeFailed, ack.Status)
+		require.Equal(t, CrossStakeErrMalformedPackage, ack.ErrorCode)
+		require.Equal(t, payload, ack.PackBytes)
+	}
+}
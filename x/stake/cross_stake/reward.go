@@ -0,0 +1,217 @@
+package cross_stake
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/pubsub"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func (app *CrossStakeApp) handleClaimReward(ctx sdk.Context, pack *types.CrossStakeClaimRewardSynPackage, relayFee int64) (sdk.ExecuteResult, uint8, error) {
+	var errCode uint8
+	sideChainId := app.stakeKeeper.DestChainName
+	if scCtx, err := app.stakeKeeper.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId); err != nil {
+		return sdk.ExecuteResult{}, errCode, err
+	} else {
+		ctx = scCtx
+	}
+
+	delAddr := types.GetStakeCAoB(pack.DelAddr[:], types.DelegateCAoBSalt)
+	rewardCAoB := types.GetStakeCAoB(delAddr.Bytes(), types.RewardCAoBSalt)
+	events, sdkErr := app.stakeKeeper.CrossClaimReward(ctx, rewardCAoB)
+	if sdkErr != nil {
+		if sdkErr.Code() != types.CodeCrossStakingNotEnoughBalance {
+			return sdk.ExecuteResult{}, errCode, sdkErr
+		}
+		errCode = CrossStakeErrNotEnoughReward
+		return sdk.ExecuteResult{
+			Err: sdkErr,
+		}, errCode, nil
+	}
+
+	if app.stakeKeeper.AddrPool != nil && ctx.IsDeliverTx() {
+		app.stakeKeeper.AddrPool.AddAddrs([]sdk.AccAddress{sdk.PegAccount, rewardCAoB})
+	}
+
+	var resultTags sdk.Tags
+	for _, event := range events {
+		resultTags = append(resultTags, event.Attributes...)
+	}
+	return sdk.ExecuteResult{
+		Tags: resultTags,
+	}, errCode, nil
+}
+
+func (app *CrossStakeApp) handleCompoundReward(ctx sdk.Context, pack *types.CrossStakeCompoundRewardSynPackage, relayFee int64) (sdk.ExecuteResult, uint8, error) {
+	var errCode uint8
+	sideChainId := app.stakeKeeper.DestChainName
+	if scCtx, err := app.stakeKeeper.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId); err != nil {
+		return sdk.ExecuteResult{}, errCode, err
+	} else {
+		ctx = scCtx
+	}
+
+	validator, sdkErr, errCode := app.getBondableValidator(ctx, pack.Validator)
+	if sdkErr != nil {
+		return sdk.ExecuteResult{
+			Err: sdkErr,
+		}, errCode, nil
+	}
+
+	feeCalc := fees.GetCalculator(types.CrossCompoundRewardFee)
+	if feeCalc == nil {
+		return sdk.ExecuteResult{}, errCode, fmt.Errorf("no fee calculator of %s", types.CrossCompoundRewardFee)
+	}
+	denom := app.stakeKeeper.BondDenom(ctx)
+	fee := feeCalc(nil).Tokens.AmountOf(denom)
+
+	delAddr := types.GetStakeCAoB(pack.DelAddr[:], types.DelegateCAoBSalt)
+	rewardCAoB := types.GetStakeCAoB(delAddr.Bytes(), types.RewardCAoBSalt)
+	reward := app.stakeKeeper.BankKeeper.GetCoins(ctx, rewardCAoB).AmountOf(denom)
+	if reward <= fee {
+		errCode = CrossStakeErrNotEnoughReward
+		return sdk.ExecuteResult{
+			Err: types.ErrNotEnoughBalance("not enough reward to cover compound fee"),
+		}, errCode, nil
+	}
+
+	// the fee is paid out of the reward, the rest of the reward is delegated by the delegator
+	if _, _, sdkErr := app.stakeKeeper.BankKeeper.SubtractCoins(ctx, rewardCAoB, sdk.Coins{sdk.NewCoin(denom, reward)}); sdkErr != nil {
+		return sdk.ExecuteResult{}, errCode, sdkErr
+	}
+	delegation := sdk.NewCoin(denom, reward-fee)
+	if _, _, sdkErr := app.stakeKeeper.BankKeeper.AddCoins(ctx, delAddr, sdk.Coins{delegation}); sdkErr != nil {
+		return sdk.ExecuteResult{}, errCode, sdkErr
+	}
+	if _, err := app.stakeKeeper.Delegate(ctx.WithCrossStake(true), delAddr, delegation, validator, true); err != nil {
+		return sdk.ExecuteResult{}, errCode, err
+	}
+
+	if ctx.IsDeliverTx() {
+		if fee > 0 {
+			fees.Pool.AddAndCommitFee(fmt.Sprintf("cross_compound_reward:%s", delAddr.String()),
+				sdk.NewFee(sdk.Coins{sdk.NewCoin(denom, fee)}, sdk.FeeForProposer))
+		}
+		if app.stakeKeeper.AddrPool != nil {
+			app.stakeKeeper.AddrPool.AddAddrs([]sdk.AccAddress{rewardCAoB, delAddr})
+		}
+		app.publishDelegateEvent(ctx, delAddr, pack.Validator, delegation.Amount)
+	}
+
+	resultTags := sdk.NewTags(
+		types.TagCrossStakePackageType, []byte{uint8(types.CrossStakeTypeCompoundReward)},
+	)
+	return sdk.ExecuteResult{
+		Tags: resultTags,
+	}, errCode, nil
+}
+
+func (app *CrossStakeApp) handleBatchDelegate(ctx sdk.Context, pack *types.CrossStakeBatchDelegateSynPackage, relayFee int64) (sdk.ExecuteResult, uint8, error) {
+	var errCode uint8
+	sideChainId := app.stakeKeeper.DestChainName
+	if scCtx, err := app.stakeKeeper.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId); err != nil {
+		return sdk.ExecuteResult{}, errCode, err
+	} else {
+		ctx = scCtx
+	}
+
+	if len(pack.Validators) == 0 || len(pack.Validators) > types.MaxBatchDelegateValidators ||
+		len(pack.Validators) != len(pack.Amounts) {
+		errCode = CrossStakeErrBadBatch
+		return sdk.ExecuteResult{
+			Err: types.ErrBadBatchDelegation(types.DefaultCodespace),
+		}, errCode, nil
+	}
+
+	// all the delegations are checked before any of them is made
+	validators := make([]types.Validator, len(pack.Validators))
+	total := int64(0)
+	for i, valAddr := range pack.Validators {
+		validator, sdkErr, code := app.getBondableValidator(ctx, valAddr)
+		if sdkErr != nil {
+			return sdk.ExecuteResult{
+				Err: sdkErr,
+			}, code, nil
+		}
+		if pack.Amounts[i] == nil || pack.Amounts[i].Sign() <= 0 || !pack.Amounts[i].IsInt64() {
+			errCode = CrossStakeErrBadBatch
+			return sdk.ExecuteResult{
+				Err: types.ErrBadBatchDelegation(types.DefaultCodespace),
+			}, errCode, nil
+		}
+		validators[i] = validator
+		total += pack.Amounts[i].Int64()
+	}
+
+	denom := app.stakeKeeper.BondDenom(ctx)
+	delAddr := types.GetStakeCAoB(pack.DelAddr[:], types.DelegateCAoBSalt)
+	_, sdkErr := app.stakeKeeper.BankKeeper.SendCoins(ctx, sdk.PegAccount, delAddr, sdk.Coins{sdk.NewCoin(denom, total)})
+	if sdkErr != nil {
+		app.stakeKeeper.Logger(ctx).Error("send coins error", "err", sdkErr.Error())
+		return sdk.ExecuteResult{}, errCode, sdkErr
+	}
+	for i, validator := range validators {
+		delegation := sdk.NewCoin(denom, pack.Amounts[i].Int64())
+		if _, err := app.stakeKeeper.Delegate(ctx.WithCrossStake(true), delAddr, delegation, validator, true); err != nil {
+			return sdk.ExecuteResult{}, errCode, err
+		}
+	}
+
+	if app.stakeKeeper.PbsbServer != nil && ctx.IsDeliverTx() {
+		app.stakeKeeper.AddrPool.AddAddrs([]sdk.AccAddress{sdk.PegAccount, delAddr})
+		for i, valAddr := range pack.Validators {
+			app.publishDelegateEvent(ctx, delAddr, valAddr, pack.Amounts[i].Int64())
+		}
+		PublishCrossStakeEvent(ctx, app.stakeKeeper, sdk.PegAccount.String(), []pubsub.CrossReceiver{{delAddr.String(), total}},
+			denom, types.TransferInType, relayFee)
+	}
+
+	resultTags := sdk.NewTags(
+		types.TagCrossStakePackageType, []byte{uint8(types.CrossStakeTypeBatchDelegate)},
+	)
+	resultTags = append(resultTags, sdk.GetPegOutTag(denom, total))
+	return sdk.ExecuteResult{
+		Tags: resultTags,
+	}, errCode, nil
+}
+
+// getBondableValidator gets a validator which can be delegated to, or the error with the error code of the ack package
+func (app *CrossStakeApp) getBondableValidator(ctx sdk.Context, valAddr sdk.ValAddress) (types.Validator, sdk.Error, uint8) {
+	validator, found := app.stakeKeeper.GetValidator(ctx, valAddr)
+	if !found {
+		return validator, types.ErrNoValidatorFound(types.DefaultCodespace), CrossStakeErrValidatorNotFound
+	}
+	if validator.Jailed {
+		return validator, types.ErrValidatorJailed(types.DefaultCodespace), CrossStakeErrValidatorJailed
+	}
+	return validator, nil, 0
+}
+
+func (app *CrossStakeApp) publishDelegateEvent(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, amount int64) {
+	if app.stakeKeeper.PbsbServer == nil {
+		return
+	}
+	txHash, ok := ctx.Value(baseapp.TxHashKey).(string)
+	if !ok {
+		ctx.Logger().With("module", "stake").Error("failed to get txhash, will not publish side delegate event ")
+		return
+	}
+	event := types.ChainDelegateEvent{
+		DelegateEvent: types.DelegateEvent{
+			StakeEvent: types.StakeEvent{
+				IsFromTx: true,
+			},
+			Delegator:  delAddr,
+			Validator:  valAddr,
+			Amount:     amount,
+			Denom:      app.stakeKeeper.BondDenom(ctx),
+			TxHash:     txHash,
+			CrossStake: true,
+		},
+		ChainId: app.stakeKeeper.DestChainName,
+	}
+	app.stakeKeeper.PbsbServer.Publish(event)
+}
@@ -0,0 +1,152 @@
+package cross_stake
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/axc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/stake/keeper"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func setupRewardOps(t *testing.T) (sdk.Context, sdk.Context, keeper.Keeper, *CrossStakeApp) {
+	ctx, _, k := keeper.CreateTestInput(t, false, 1000)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.CrossStakeRewardOps, 1)
+	k.DestChainName = "bsc"
+	k.ScKeeper.SetSideChainIdAndStorePrefix(ctx, k.DestChainName, []byte{0x99})
+	scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, k.DestChainName)
+	require.NoError(t, err)
+	k.SetPool(scCtx, types.InitialPool())
+	// the fees are charged in the native token
+	params := types.DefaultParams()
+	params.BondDenom = sdk.NativeTokenSymbol
+	k.SetParams(scCtx, params)
+
+	fees.RegisterCalculator(types.CrossClaimRewardRelayFee, fees.FixedFeeCalculator(6e5, sdk.FeeForAll))
+	fees.RegisterCalculator(types.CrossCompoundRewardFee, fees.FixedFeeCalculator(1e5, sdk.FeeForProposer))
+	return ctx, scCtx, k, NewCrossStakeApp(k)
+}
+
+func decodeAck(t *testing.T, result sdk.ExecuteResult) types.CrossStakeAckPackage {
+	var ack types.CrossStakeAckPackage
+	require.NoError(t, rlp.DecodeBytes(result.Payload, &ack))
+	return ack
+}
+
+func TestDeserializeRewardOpsSynPackage(t *testing.T) {
+	defer sdk.UpgradeMgr.Reset()
+	delAddr, err := sdk.NewSmartChainAddress("0x7de3642c66220e1136a42bf9897a6f8527ef9a03")
+	require.NoError(t, err)
+	claim := encodeSynPackage(t, types.CrossStakeTypeClaimReward, types.CrossStakeClaimRewardSynPackage{DelAddr: delAddr})
+
+	// the new packages are unknown before the upgrade
+	sdk.UpgradeMgr.Reset()
+	_, err = DeserializeCrossStakeSynPackage(claim)
+	require.Error(t, err)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.CrossStakeRewardOps, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	pack, err := DeserializeCrossStakeSynPackage(claim)
+	require.NoError(t, err)
+	require.Equal(t, delAddr, pack.(*types.CrossStakeClaimRewardSynPackage).DelAddr)
+
+	batch := encodeSynPackage(t, types.CrossStakeTypeBatchDelegate, types.CrossStakeBatchDelegateSynPackage{
+		DelAddr:    delAddr,
+		Validators: []sdk.ValAddress{sdk.ValAddress(keeper.Addrs[0]), sdk.ValAddress(keeper.Addrs[1])},
+		Amounts:    []*big.Int{big.NewInt(1e8), big.NewInt(2e8)},
+	})
+	pack, err = DeserializeCrossStakeSynPackage(batch)
+	require.NoError(t, err)
+	require.Len(t, pack.(*types.CrossStakeBatchDelegateSynPackage).Validators, 2)
+	require.Equal(t, int64(2e8), pack.(*types.CrossStakeBatchDelegateSynPackage).Amounts[1].Int64())
+
+	// the reward sent on claim is refunded like the distributed one
+	failAck, err := rlp.EncodeToBytes(types.CrossStakeDistributeRewardSynPackage{
+		EventType: types.CrossStakeTypeClaimReward,
+		Recipient: delAddr,
+		Amount:    big.NewInt(1e18),
+	})
+	require.NoError(t, err)
+	pack, err = DeserializeCrossStakeFailAckPackage(failAck)
+	require.NoError(t, err)
+	require.Equal(t, types.CrossStakeTypeClaimReward, pack.(*types.CrossStakeDistributeRewardSynPackage).EventType)
+}
+
+func TestClaimRewardNotEnoughReward(t *testing.T) {
+	ctx, _, _, app := setupRewardOps(t)
+	defer sdk.UpgradeMgr.Reset()
+	defer fees.UnsetAllCalculators()
+
+	delAddr, err := sdk.NewSmartChainAddress("0x7de3642c66220e1136a42bf9897a6f8527ef9a03")
+	require.NoError(t, err)
+	claim := encodeSynPackage(t, types.CrossStakeTypeClaimReward, types.CrossStakeClaimRewardSynPackage{DelAddr: delAddr})
+	result := app.ExecuteSynPackage(ctx, claim, 0)
+	require.False(t, result.IsOk())
+	ack := decodeAck(t, result)
+	require.Equal(t, types.CrossStakeFailed, ack.Status)
+	require.Equal(t, CrossStakeErrNotEnoughReward, ack.ErrorCode)
+}
+
+func TestCompoundReward(t *testing.T) {
+	ctx, scCtx, k, app := setupRewardOps(t)
+	defer sdk.UpgradeMgr.Reset()
+	defer fees.UnsetAllCalculators()
+
+	valAddr := sdk.ValAddress(keeper.Addrs[0])
+	validator := types.NewValidator(valAddr, keeper.PKs[0], types.Description{})
+	k.SetValidator(scCtx, validator)
+	k.SetValidatorByConsAddr(scCtx, validator)
+
+	delAddr, err := sdk.NewSmartChainAddress("0x7de3642c66220e1136a42bf9897a6f8527ef9a03")
+	require.NoError(t, err)
+	delCAoB := types.GetStakeCAoB(delAddr[:], types.DelegateCAoBSalt)
+	rewardCAoB := types.GetStakeCAoB(delCAoB.Bytes(), types.RewardCAoBSalt)
+	_, _, sdkErr := k.BankKeeper.AddCoins(ctx, rewardCAoB, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 1e8)})
+	require.Nil(t, sdkErr)
+
+	compound := encodeSynPackage(t, types.CrossStakeTypeCompoundReward, types.CrossStakeCompoundRewardSynPackage{
+		DelAddr:   delAddr,
+		Validator: valAddr,
+	})
+	defer fees.Pool.Clear()
+	result := app.ExecuteSynPackage(ctx, compound, 0)
+	require.True(t, result.IsOk(), result.Err)
+	require.Equal(t, types.CrossStakeSuccess, decodeAck(t, result).Status)
+
+	delegation, found := k.GetDelegation(scCtx, delCAoB, valAddr)
+	require.True(t, found)
+	require.Equal(t, int64(1e8-1e5), delegation.Shares.RawInt())
+	require.True(t, k.BankKeeper.GetCoins(ctx, rewardCAoB).IsZero())
+	require.Equal(t, int64(1e5), fees.Pool.BlockFees().Tokens.AmountOf(sdk.NativeTokenSymbol))
+
+	// the jailed validator can not be compounded to
+	_, _, sdkErr = k.BankKeeper.AddCoins(ctx, rewardCAoB, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 1e8)})
+	require.Nil(t, sdkErr)
+	validator, _ = k.GetValidator(scCtx, valAddr)
+	validator.Jailed = true
+	k.SetValidator(scCtx, validator)
+	result = app.ExecuteSynPackage(ctx, compound, 0)
+	require.False(t, result.IsOk())
+	require.Equal(t, CrossStakeErrValidatorJailed, decodeAck(t, result).ErrorCode)
+}
+
+func TestBatchDelegateBadBatch(t *testing.T) {
+	ctx, _, _, app := setupRewardOps(t)
+	defer sdk.UpgradeMgr.Reset()
+	defer fees.UnsetAllCalculators()
+
+	delAddr, err := sdk.NewSmartChainAddress("0x7de3642c66220e1136a42bf9897a6f8527ef9a03")
+	require.NoError(t, err)
+	batch := encodeSynPackage(t, types.CrossStakeTypeBatchDelegate, types.CrossStakeBatchDelegateSynPackage{
+		DelAddr:    delAddr,
+		Validators: []sdk.ValAddress{sdk.ValAddress(keeper.Addrs[0]), sdk.ValAddress(keeper.Addrs[1])},
+		Amounts:    []*big.Int{big.NewInt(1e8)},
+	})
+	result := app.ExecuteSynPackage(ctx, batch, 0)
+	require.False(t, result.IsOk())
+	require.Equal(t, CrossStakeErrBadBatch, decodeAck(t, result).ErrorCode)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/cosmos/cosmos-sdk/axc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
@@ -11,6 +12,9 @@ const (
 	CrossStakeErrValidatorNotFound uint8 = 1
 	CrossStakeErrValidatorJailed   uint8 = 2
 	CrossStakeErrBadDelegation     uint8 = 3
+	CrossStakeErrNotEnoughReward   uint8 = 4
+	CrossStakeErrBadBatch          uint8 = 5
+	CrossStakeErrMalformedPackage  uint8 = 6 // the syn package can not be deserialized or its event type is unknown
 )
 
 type CrossStakeSynPackageFromAXC struct {
@@ -46,9 +50,34 @@ func DeserializeCrossStakeSynPackage(serializedPackage []byte) (interface{}, err
 			return nil, err
 		}
 		return &pack2, nil
-	default:
-		return nil, fmt.Errorf("unrecognized cross stake event type: %d", pack1.EventType)
 	}
+
+	if sdk.IsUpgrade(sdk.CrossStakeRewardOps) {
+		switch pack1.EventType {
+		case types.CrossStakeTypeClaimReward:
+			var pack2 types.CrossStakeClaimRewardSynPackage
+			err := rlp.DecodeBytes(pack1.ParamsBytes, &pack2)
+			if err != nil {
+				return nil, err
+			}
+			return &pack2, nil
+		case types.CrossStakeTypeCompoundReward:
+			var pack2 types.CrossStakeCompoundRewardSynPackage
+			err := rlp.DecodeBytes(pack1.ParamsBytes, &pack2)
+			if err != nil {
+				return nil, err
+			}
+			return &pack2, nil
+		case types.CrossStakeTypeBatchDelegate:
+			var pack2 types.CrossStakeBatchDelegateSynPackage
+			err := rlp.DecodeBytes(pack1.ParamsBytes, &pack2)
+			if err != nil {
+				return nil, err
+			}
+			return &pack2, nil
+		}
+	}
+	return nil, fmt.Errorf("unrecognized cross stake event type: %d", pack1.EventType)
 }
 
 func DeserializeCrossStakeRefundPackage(serializedPackage []byte) (*types.CrossStakeRefundPackage, error) {
@@ -68,7 +97,7 @@ func DeserializeCrossStakeFailAckPackage(serializedPackage []byte) (interface{},
 			if err != nil {
 				return nil, err
 			}
-			if pack.EventType != types.CrossStakeTypeDistributeReward {
+			if pack.EventType != types.CrossStakeTypeDistributeReward && pack.EventType != types.CrossStakeTypeClaimReward {
 				return nil, fmt.Errorf("wrong cross stake event type")
 			}
 			return &pack, nil
@@ -0,0 +1,109 @@
+package cross_stake
+
+import (
+	"github.com/cosmos/cosmos-sdk/axc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+var _ sdk.CrossChainPackageObserver = (*CrossStakeApp)(nil)
+
+// OnPackageReceived records a cross stake request before it is executed
+func (app *CrossStakeApp) OnPackageReceived(ctx sdk.Context, info sdk.CrossChainPackageInfo,
+	packageType sdk.CrossChainPackageType, payload []byte) {
+	if packageType != sdk.SynCrossChainPackageType || !sdk.IsUpgrade(sdk.CrossStakeStatus) {
+		return
+	}
+	request, ok := newCrossStakeRequest(info, payload)
+	if !ok {
+		return
+	}
+	scCtx, err := app.stakeKeeper.ScKeeper.PrepareCtxForSideChain(ctx, app.stakeKeeper.DestChainName)
+	if err != nil {
+		app.stakeKeeper.Logger(ctx).Error("prepare side chain ctx for cross stake request error", "err", err.Error())
+		return
+	}
+	request.Height = ctx.BlockHeight()
+	app.stakeKeeper.SetCrossStakeRequest(scCtx, request)
+}
+
+// OnPackageExecuted updates the status of a request by the result of its execution
+func (app *CrossStakeApp) OnPackageExecuted(ctx sdk.Context, info sdk.CrossChainPackageInfo,
+	packageType sdk.CrossChainPackageType, payload []byte, result sdk.ExecuteResult, crash bool) {
+	if packageType != sdk.SynCrossChainPackageType || !sdk.IsUpgrade(sdk.CrossStakeStatus) {
+		return
+	}
+	scCtx, err := app.stakeKeeper.ScKeeper.PrepareCtxForSideChain(ctx, app.stakeKeeper.DestChainName)
+	if err != nil {
+		app.stakeKeeper.Logger(ctx).Error("prepare side chain ctx for cross stake request error", "err", err.Error())
+		return
+	}
+	request, found := app.stakeKeeper.GetCrossStakeRequest(scCtx, info.ChannelID, info.Sequence)
+	if !found {
+		var ok bool
+		if request, ok = newCrossStakeRequest(info, payload); !ok {
+			return
+		}
+	}
+
+	request.Height = ctx.BlockHeight()
+	switch {
+	case crash:
+		request.Status = types.CrossStakeRequestRefunded
+	case result.IsOk():
+		request.Status = types.CrossStakeRequestExecuted
+	default:
+		request.Status = types.CrossStakeRequestFailed
+		var ack types.CrossStakeAckPackage
+		if err := rlp.DecodeBytes(result.Payload, &ack); err == nil {
+			request.ErrorCode = ack.ErrorCode
+		}
+	}
+	app.stakeKeeper.SetCrossStakeRequest(scCtx, request)
+}
+
+func newCrossStakeRequest(info sdk.CrossChainPackageInfo, payload []byte) (types.CrossStakeRequest, bool) {
+	pack, err := DeserializeCrossStakeSynPackage(payload)
+	if err != nil {
+		return types.CrossStakeRequest{}, false
+	}
+
+	request := types.CrossStakeRequest{
+		ChannelId: info.ChannelID,
+		Sequence:  info.Sequence,
+		Status:    types.CrossStakeRequestReceived,
+	}
+	switch p := pack.(type) {
+	case *types.CrossStakeDelegateSynPackage:
+		request.EventType = types.CrossStakeTypeDelegate
+		request.DelAddr, request.Validator, request.Amount = p.DelAddr, p.Validator, p.Amount.Int64()
+	case *types.CrossStakeUndelegateSynPackage:
+		request.EventType = types.CrossStakeTypeUndelegate
+		request.DelAddr, request.Validator, request.Amount = p.DelAddr, p.Validator, p.Amount.Int64()
+	case *types.CrossStakeRedelegateSynPackage:
+		request.EventType = types.CrossStakeTypeRedelegate
+		request.DelAddr, request.Validator, request.Amount = p.DelAddr, p.ValSrc, p.Amount.Int64()
+		request.ValDst = p.ValDst
+	case *types.CrossStakeClaimRewardSynPackage:
+		request.EventType = types.CrossStakeTypeClaimReward
+		request.DelAddr = p.DelAddr
+	case *types.CrossStakeCompoundRewardSynPackage:
+		request.EventType = types.CrossStakeTypeCompoundReward
+		request.DelAddr, request.Validator = p.DelAddr, p.Validator
+	case *types.CrossStakeBatchDelegateSynPackage:
+		// only the first validator of the batch is recorded, the amount is the total of the batch
+		request.EventType = types.CrossStakeTypeBatchDelegate
+		request.DelAddr = p.DelAddr
+		if len(p.Validators) != 0 {
+			request.Validator = p.Validators[0]
+		}
+		for _, amount := range p.Amounts {
+			if amount != nil {
+				request.Amount += amount.Int64()
+			}
+		}
+	default:
+		return types.CrossStakeRequest{}, false
+	}
+	return request, true
+}
@@ -0,0 +1,111 @@
+package cross_stake
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/axc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/keeper"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func encodeSynPackage(t *testing.T, eventType types.CrossStakeEventType, pack interface{}) []byte {
+	paramsBytes, err := rlp.EncodeToBytes(pack)
+	require.NoError(t, err)
+	payload, err := rlp.EncodeToBytes(CrossStakeSynPackageFromAXC{EventType: eventType, ParamsBytes: paramsBytes})
+	require.NoError(t, err)
+	return payload
+}
+
+func TestCrossStakeRequestStatus(t *testing.T) {
+	ctx, _, k := keeper.CreateTestInput(t, false, 1000)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.CrossStakeStatus, 1)
+	defer sdk.UpgradeMgr.Reset()
+	k.DestChainName = "bsc"
+	k.ScKeeper.SetSideChainIdAndStorePrefix(ctx, k.DestChainName, []byte{0x99})
+	scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, k.DestChainName)
+	require.NoError(t, err)
+	app := NewCrossStakeApp(k)
+
+	delAddr, err := sdk.NewSmartChainAddress("0x7de3642c66220e1136a42bf9897a6f8527ef9a03")
+	require.NoError(t, err)
+	delegate := encodeSynPackage(t, types.CrossStakeTypeDelegate, types.CrossStakeDelegateSynPackage{
+		DelAddr:   delAddr,
+		Validator: sdk.ValAddress(keeper.Addrs[0]),
+		Amount:    big.NewInt(1e8),
+	})
+
+	// executed
+	info := sdk.CrossChainPackageInfo{ChannelID: types.CrossStakeChannelID, Sequence: 1}
+	app.OnPackageReceived(ctx, info, sdk.SynCrossChainPackageType, delegate)
+	request, found := k.GetCrossStakeRequest(scCtx, types.CrossStakeChannelID, 1)
+	require.True(t, found)
+	require.Equal(t, types.CrossStakeRequestReceived, request.Status)
+	require.Equal(t, types.CrossStakeTypeDelegate, request.EventType)
+	require.Equal(t, delAddr, request.DelAddr)
+	require.Equal(t, int64(1e8), request.Amount)
+
+	app.OnPackageExecuted(ctx, info, sdk.SynCrossChainPackageType, delegate, sdk.ExecuteResult{}, false)
+	request, _ = k.GetCrossStakeRequest(scCtx, types.CrossStakeChannelID, 1)
+	require.Equal(t, types.CrossStakeRequestExecuted, request.Status)
+
+	// failed with the error code of the ack package
+	info.Sequence = 2
+	ack, err := rlp.EncodeToBytes(types.CrossStakeAckPackage{
+		Status:    types.CrossStakeFailed,
+		ErrorCode: CrossStakeErrValidatorJailed,
+		PackBytes: delegate,
+	})
+	require.NoError(t, err)
+	app.OnPackageReceived(ctx, info, sdk.SynCrossChainPackageType, delegate)
+	app.OnPackageExecuted(ctx, info, sdk.SynCrossChainPackageType, delegate,
+		sdk.ExecuteResult{Err: types.ErrValidatorJailed(types.DefaultCodespace), Payload: ack}, false)
+	request, _ = k.GetCrossStakeRequest(scCtx, types.CrossStakeChannelID, 2)
+	require.Equal(t, types.CrossStakeRequestFailed, request.Status)
+	require.Equal(t, CrossStakeErrValidatorJailed, request.ErrorCode)
+
+	// crashed, the side chain is refunded
+	info.Sequence = 3
+	redelegate := encodeSynPackage(t, types.CrossStakeTypeRedelegate, types.CrossStakeRedelegateSynPackage{
+		DelAddr: delAddr,
+		ValSrc:  sdk.ValAddress(keeper.Addrs[0]),
+		ValDst:  sdk.ValAddress(keeper.Addrs[1]),
+		Amount:  big.NewInt(1e8),
+	})
+	app.OnPackageReceived(ctx, info, sdk.SynCrossChainPackageType, redelegate)
+	app.OnPackageExecuted(ctx, info, sdk.SynCrossChainPackageType, redelegate,
+		sdk.ExecuteResult{Err: sdk.ErrInternal("crash")}, true)
+	request, _ = k.GetCrossStakeRequest(scCtx, types.CrossStakeChannelID, 3)
+	require.Equal(t, types.CrossStakeRequestRefunded, request.Status)
+	require.Equal(t, sdk.ValAddress(keeper.Addrs[1]), request.ValDst)
+
+	// ack packages are not tracked
+	info.Sequence = 4
+	app.OnPackageReceived(ctx, info, sdk.AckCrossChainPackageType, delegate)
+	_, found = k.GetCrossStakeRequest(scCtx, types.CrossStakeChannelID, 4)
+	require.False(t, found)
+}
+
+func TestCrossStakeRequestStatusBeforeUpgrade(t *testing.T) {
+	ctx, _, k := keeper.CreateTestInput(t, false, 1000)
+	defer sdk.UpgradeMgr.Reset()
+	k.DestChainName = "bsc"
+	k.ScKeeper.SetSideChainIdAndStorePrefix(ctx, k.DestChainName, []byte{0x99})
+	scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, k.DestChainName)
+	require.NoError(t, err)
+
+	delAddr, err := sdk.NewSmartChainAddress("0x7de3642c66220e1136a42bf9897a6f8527ef9a03")
+	require.NoError(t, err)
+	delegate := encodeSynPackage(t, types.CrossStakeTypeDelegate, types.CrossStakeDelegateSynPackage{
+		DelAddr:   delAddr,
+		Validator: sdk.ValAddress(keeper.Addrs[0]),
+		Amount:    big.NewInt(1e8),
+	})
+	info := sdk.CrossChainPackageInfo{ChannelID: types.CrossStakeChannelID, Sequence: 1}
+	NewCrossStakeApp(k).OnPackageReceived(ctx, info, sdk.SynCrossChainPackageType, delegate)
+	_, found := k.GetCrossStakeRequest(scCtx, types.CrossStakeChannelID, 1)
+	require.False(t, found)
+}
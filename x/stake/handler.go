@@ -48,6 +48,11 @@ func NewHandler(k keeper.Keeper, govKeeper gov.Keeper) sdk.Handler {
 			return handleMsgSideChainRedelegate(ctx, msg, k)
 		case types.MsgSideChainUndelegate:
 			return handleMsgSideChainUndelegate(ctx, msg, k)
+		case types.MsgSetAutoCompound:
+			if !sdk.IsUpgrade(sdk.AutoCompound) {
+				return sdk.ErrMsgNotSupported("MsgSetAutoCompound not activated yet").Result()
+			}
+			return handleMsgSetAutoCompound(ctx, msg, k)
 		default:
 			return sdk.ErrTxDecode("invalid message parse in staking module").Result()
 		}
@@ -407,6 +412,28 @@ func handleMsgDelegate(ctx sdk.Context, msg types.MsgDelegate, k keeper.Keeper)
 	}
 }
 
+func handleMsgSetAutoCompound(ctx sdk.Context, msg types.MsgSetAutoCompound, k keeper.Keeper) sdk.Result {
+	if len(msg.SideChainId) != 0 {
+		if scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId); err != nil {
+			return ErrInvalidSideChainId(k.Codespace()).Result()
+		} else {
+			ctx = scCtx
+		}
+	}
+
+	if _, found := k.GetDelegation(ctx, msg.DelegatorAddr, msg.ValidatorAddr); !found {
+		return ErrNoDelegation(k.Codespace()).Result()
+	}
+	k.SetAutoCompound(ctx, msg.DelegatorAddr, msg.ValidatorAddr, msg.Enable)
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Delegator, []byte(msg.DelegatorAddr.String()),
+			tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+		),
+	}
+}
+
 func handleMsgUndelegate(ctx sdk.Context, msg types.MsgUndelegate, k keeper.Keeper) sdk.Result {
 	if msg.Amount.Denom != k.BondDenom(ctx) {
 		return ErrBadDenom(k.Codespace()).Result()
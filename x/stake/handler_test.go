@@ -1277,3 +1277,36 @@ func TestNewStakingProcess(t *testing.T) {
 	require.Equal(t, sdk.NewDecWithoutFra(bondAmount*2), bond.Shares)
 	require.Equal(t, sdk.NewDecWithoutFra(bondAmount*3), validator.DelegatorShares)
 }
+
+func TestSetAutoCompound(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr, delegatorAddr := sdk.ValAddress(keep.Addrs[0]), keep.Addrs[1]
+	handler := NewHandler(keeper, gov.Keeper{})
+
+	msgCreateValidator := NewTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+
+	msgSetAutoCompound := NewMsgSetAutoCompound("", delegatorAddr, validatorAddr, true)
+	got = handler(ctx, msgSetAutoCompound)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeMsgNotSupported), got.Code)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.AutoCompound, 1)
+	defer sdk.UpgradeMgr.Reset()
+
+	// the delegation must exist
+	got = handler(ctx, msgSetAutoCompound)
+	require.Equal(t, sdk.ToABCICode(types.DefaultCodespace, types.CodeInvalidDelegation), got.Code)
+
+	msgDelegate := NewTestMsgDelegate(delegatorAddr, validatorAddr, 10)
+	got = handleMsgDelegate(ctx, msgDelegate, keeper)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+
+	got = handler(ctx, msgSetAutoCompound)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	require.True(t, keeper.IsAutoCompound(ctx, delegatorAddr, validatorAddr))
+
+	got = handler(ctx, NewMsgSetAutoCompound("", delegatorAddr, validatorAddr, false))
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	require.False(t, keeper.IsAutoCompound(ctx, delegatorAddr, validatorAddr))
+}
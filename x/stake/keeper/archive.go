@@ -0,0 +1,159 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"sync"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+var (
+	archivedValidatorsPrefix  = []byte{0x01}
+	archivedDelegationsPrefix = []byte{0x02}
+)
+
+// SnapshotArchive keeps the validator-set snapshots removed from the store after the reward distribution,
+// so that the rewards can be audited later. It is local to the node and not part of the consensus state.
+type SnapshotArchive struct {
+	mtx      sync.RWMutex
+	db       dbm.DB
+	keepDays int
+}
+
+// EnableSnapshotArchive enables the archival mode which keeps the snapshots of the latest keepDays days
+// per chain in the db, 0 means all the snapshots are kept. All the copies of the keeper share the archive.
+func (k Keeper) EnableSnapshotArchive(db dbm.DB, keepDays int) {
+	k.archive.mtx.Lock()
+	defer k.archive.mtx.Unlock()
+	k.archive.db = db
+	k.archive.keepDays = keepDays
+}
+
+func (k Keeper) SnapshotArchiveEnabled() bool {
+	if k.archive == nil {
+		return false
+	}
+	k.archive.mtx.RLock()
+	defer k.archive.mtx.RUnlock()
+	return k.archive.db != nil
+}
+
+func getArchivedChainPrefix(prefix []byte, sideChainId string) []byte {
+	key := make([]byte, 0, len(prefix)+1+len(sideChainId)+8)
+	key = append(key, prefix...)
+	key = append(key, byte(len(sideChainId)))
+	return append(key, sideChainId...)
+}
+
+func appendHeight(key []byte, height int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return append(key, bz...)
+}
+
+// prefix | len(chain id) | chain id | height
+func getArchivedValidatorsKey(sideChainId string, height int64) []byte {
+	return appendHeight(getArchivedChainPrefix(archivedValidatorsPrefix, sideChainId), height)
+}
+
+// prefix | len(chain id) | chain id | height | validator
+func getArchivedDelegationsKey(sideChainId string, height int64, valAddr sdk.ValAddress) []byte {
+	return append(getArchivedDelegationsByHeightKey(sideChainId, height), valAddr...)
+}
+
+func getArchivedDelegationsByHeightKey(sideChainId string, height int64) []byte {
+	return appendHeight(getArchivedChainPrefix(archivedDelegationsPrefix, sideChainId), height)
+}
+
+// archiveSnapshot copies the snapshot at the height before it is removed from the store
+func (k Keeper) archiveSnapshot(ctx sdk.Context, sideChainId string, height int64, validators []types.Validator) {
+	if !ctx.IsDeliverTx() || !k.SnapshotArchiveEnabled() {
+		return
+	}
+	k.archive.mtx.Lock()
+	defer k.archive.mtx.Unlock()
+
+	batch := k.archive.db.NewBatch()
+	defer batch.Close()
+	batch.Set(getArchivedValidatorsKey(sideChainId, height), types.MustMarshalValidators(k.cdc, validators))
+	for _, validator := range validators {
+		if simDels, found := k.GetSimplifiedDelegations(ctx, height, validator.OperatorAddr); found {
+			batch.Set(getArchivedDelegationsKey(sideChainId, height, validator.OperatorAddr),
+				types.MustMarshalSimplifiedDelegations(k.cdc, simDels))
+		}
+	}
+	if k.archive.keepDays > 0 {
+		// the snapshot of the height may be archived already if the block is executed again after a crash
+		heights := make([]int64, 0)
+		for _, archived := range k.archivedHeights(sideChainId) {
+			if archived != height {
+				heights = append(heights, archived)
+			}
+		}
+		for i := 0; i < len(heights)-(k.archive.keepDays-1); i++ {
+			batch.Delete(getArchivedValidatorsKey(sideChainId, heights[i]))
+			k.deleteArchivedDelegations(batch, sideChainId, heights[i])
+		}
+	}
+	batch.WriteSync()
+}
+
+func (k Keeper) deleteArchivedDelegations(batch dbm.Batch, sideChainId string, height int64) {
+	iterator := dbm.IteratePrefix(k.archive.db, getArchivedDelegationsByHeightKey(sideChainId, height))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		batch.Delete(iterator.Key())
+	}
+}
+
+func (k Keeper) archivedHeights(sideChainId string) []int64 {
+	prefix := getArchivedChainPrefix(archivedValidatorsPrefix, sideChainId)
+	iterator := dbm.IteratePrefix(k.archive.db, prefix)
+	defer iterator.Close()
+	heights := make([]int64, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		heights = append(heights, int64(binary.BigEndian.Uint64(iterator.Key()[len(prefix):])))
+	}
+	return heights
+}
+
+// GetArchivedHeights returns the heights of the archived snapshots of the chain in ascending order
+func (k Keeper) GetArchivedHeights(sideChainId string) []int64 {
+	if !k.SnapshotArchiveEnabled() {
+		return nil
+	}
+	k.archive.mtx.RLock()
+	defer k.archive.mtx.RUnlock()
+	return k.archivedHeights(sideChainId)
+}
+
+// GetArchivedValidators returns the archived validator set of the chain snapshotted at the height
+func (k Keeper) GetArchivedValidators(sideChainId string, height int64) (validators []types.Validator, found bool) {
+	if !k.SnapshotArchiveEnabled() {
+		return nil, false
+	}
+	k.archive.mtx.RLock()
+	defer k.archive.mtx.RUnlock()
+	bz := k.archive.db.Get(getArchivedValidatorsKey(sideChainId, height))
+	if bz == nil {
+		return nil, false
+	}
+	return types.MustUnmarshalValidators(k.cdc, bz), true
+}
+
+// GetArchivedDelegations returns the archived delegations of the validator snapshotted at the height
+func (k Keeper) GetArchivedDelegations(sideChainId string, height int64, valAddr sdk.ValAddress) (simDels []types.SimplifiedDelegation, found bool) {
+	if !k.SnapshotArchiveEnabled() {
+		return nil, false
+	}
+	k.archive.mtx.RLock()
+	defer k.archive.mtx.RUnlock()
+	bz := k.archive.db.Get(getArchivedDelegationsKey(sideChainId, height, valAddr))
+	if bz == nil {
+		return nil, false
+	}
+	return types.MustUnmarshalSimplifiedDelegations(k.cdc, bz), true
+}
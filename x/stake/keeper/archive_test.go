@@ -0,0 +1,70 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func storeSnapshot(k Keeper, ctx sdk.Context, height int64, shares int64) []types.Validator {
+	validators := make([]types.Validator, 2)
+	for i := range validators {
+		validators[i] = types.NewValidator(sdk.ValAddress(PKs[i].Address()), PKs[i], types.Description{})
+		k.SetSimplifiedDelegations(ctx, height, validators[i].OperatorAddr, []types.SimplifiedDelegation{
+			{DelegatorAddr: Addrs[i], Shares: sdk.NewDec(shares)},
+		})
+	}
+	k.SetValidatorsByHeight(ctx, height, validators)
+	return validators
+}
+
+func TestSnapshotArchive(t *testing.T) {
+	ctx, _, k := CreateTestInput(t, false, 0)
+
+	// nothing is archived before the archive is enabled
+	validators := storeSnapshot(k, ctx, 100, 1)
+	removeValidatorsAndDelegationsAtHeight(100, k, ctx, types.ChainIDForBeaconChain, validators)
+	require.False(t, k.SnapshotArchiveEnabled())
+	_, found := k.GetArchivedValidators(types.ChainIDForBeaconChain, 100)
+	require.False(t, found)
+
+	k.EnableSnapshotArchive(dbm.NewMemDB(), 2)
+	for _, height := range []int64{200, 300} {
+		validators = storeSnapshot(k, ctx, height, height)
+		removeValidatorsAndDelegationsAtHeight(height, k, ctx, types.ChainIDForBeaconChain, validators)
+		_, found = k.GetValidatorsByHeight(ctx, height)
+		require.False(t, found)
+	}
+	require.Equal(t, []int64{200, 300}, k.GetArchivedHeights(types.ChainIDForBeaconChain))
+
+	archived, found := k.GetArchivedValidators(types.ChainIDForBeaconChain, 300)
+	require.True(t, found)
+	require.Len(t, archived, 2)
+	require.Equal(t, validators[1].OperatorAddr, archived[1].OperatorAddr)
+	simDels, found := k.GetArchivedDelegations(types.ChainIDForBeaconChain, 200, validators[0].OperatorAddr)
+	require.True(t, found)
+	require.Equal(t, Addrs[0], simDels[0].DelegatorAddr)
+	require.Equal(t, sdk.NewDec(200), simDels[0].Shares)
+
+	// the snapshots of the other chains are archived separately
+	validators = storeSnapshot(k, ctx, 300, 1)
+	removeValidatorsAndDelegationsAtHeight(300, k, ctx, "bsc", validators)
+	require.Equal(t, []int64{300}, k.GetArchivedHeights("bsc"))
+
+	// only the latest days are kept
+	validators = storeSnapshot(k, ctx, 400, 400)
+	removeValidatorsAndDelegationsAtHeight(400, k, ctx, types.ChainIDForBeaconChain, validators)
+	require.Equal(t, []int64{300, 400}, k.GetArchivedHeights(types.ChainIDForBeaconChain))
+	_, found = k.GetArchivedDelegations(types.ChainIDForBeaconChain, 200, validators[0].OperatorAddr)
+	require.False(t, found)
+	require.Equal(t, []int64{300}, k.GetArchivedHeights("bsc"))
+
+	// the snapshots removed in check mode are not archived
+	validators = storeSnapshot(k, ctx, 500, 500)
+	removeValidatorsAndDelegationsAtHeight(500, k, ctx.WithRunTxMode(sdk.RunTxModeCheck), types.ChainIDForBeaconChain, validators)
+	require.Equal(t, []int64{300, 400}, k.GetArchivedHeights(types.ChainIDForBeaconChain))
+}
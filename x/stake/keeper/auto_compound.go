@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// IsAutoCompound returns whether the rewards of the delegation are delegated to the validator again
+func (k Keeper) IsAutoCompound(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(GetAutoCompoundKey(delAddr, valAddr))
+}
+
+// SetAutoCompound opts the delegation in or out of auto-compounding its rewards
+func (k Keeper) SetAutoCompound(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, enable bool) {
+	store := ctx.KVStore(k.storeKey)
+	if enable {
+		store.Set(GetAutoCompoundKey(delAddr, valAddr), []byte{})
+	} else {
+		store.Delete(GetAutoCompoundKey(delAddr, valAddr))
+	}
+}
+
+// autoCompound delegates the reward credited to the delegator to the validator again if the delegation is opted in.
+// The reward stays liquid if it is less than the min delegation change or the delegation fails.
+func (k Keeper) autoCompound(ctx sdk.Context, sideChainId string, delAddr sdk.AccAddress, valAddr sdk.ValAddress, amount int64) {
+	if !k.IsAutoCompound(ctx, delAddr, valAddr) || amount < k.MinDelegationChange(ctx) {
+		return
+	}
+	validator, found := k.GetValidator(ctx, valAddr)
+	if !found || (validator.Jailed && !bytes.Equal(validator.FeeAddr, delAddr)) {
+		return
+	}
+
+	bondDenom := k.BondDenom(ctx)
+	cacheCtx, write := ctx.CacheContext()
+	if _, err := k.Delegate(cacheCtx, delAddr, sdk.NewCoin(bondDenom, amount), validator, true); err != nil {
+		k.Logger(ctx).Error("failed to auto-compound reward", "delegator", delAddr, "validator", valAddr, "err", err.Error())
+		return
+	}
+	write()
+
+	if k.PbsbServer != nil && ctx.IsDeliverTx() {
+		event := types.ChainDelegateEvent{
+			DelegateEvent: types.DelegateEvent{
+				Delegator: delAddr,
+				Validator: valAddr,
+				Amount:    amount,
+				Denom:     bondDenom,
+			},
+			ChainId: sideChainId,
+		}
+		k.PbsbServer.Publish(event)
+	}
+}
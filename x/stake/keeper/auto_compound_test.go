@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestAutoCompound(t *testing.T) {
+	ctx, _, k := CreateTestInput(t, false, 1000)
+	bondDenom := k.BondDenom(ctx)
+	minDelegationChange := k.MinDelegationChange(ctx)
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	k.SetValidator(ctx, validator)
+	k.SetValidatorByPowerIndex(ctx, validator)
+	_, err := k.Delegate(ctx, addrDels[0], sdk.NewCoin(bondDenom, minDelegationChange), validator, true)
+	require.Nil(t, err)
+
+	// the reward stays liquid if the delegation is not opted in
+	k.autoCompound(ctx, "", addrDels[0], addrVals[0], minDelegationChange)
+	delegation, _ := k.GetDelegation(ctx, addrDels[0], addrVals[0])
+	require.Equal(t, minDelegationChange, delegation.Shares.RawInt())
+
+	k.SetAutoCompound(ctx, addrDels[0], addrVals[0], true)
+	require.True(t, k.IsAutoCompound(ctx, addrDels[0], addrVals[0]))
+	require.False(t, k.IsAutoCompound(ctx, addrDels[1], addrVals[0]))
+
+	// the reward less than the min delegation change is not compounded
+	k.autoCompound(ctx, "", addrDels[0], addrVals[0], minDelegationChange-1)
+	delegation, _ = k.GetDelegation(ctx, addrDels[0], addrVals[0])
+	require.Equal(t, minDelegationChange, delegation.Shares.RawInt())
+
+	balance := k.BankKeeper.GetCoins(ctx, addrDels[0]).AmountOf(bondDenom)
+	k.autoCompound(ctx, "", addrDels[0], addrVals[0], minDelegationChange)
+	delegation, _ = k.GetDelegation(ctx, addrDels[0], addrVals[0])
+	require.Equal(t, 2*minDelegationChange, delegation.Shares.RawInt())
+	require.Equal(t, balance-minDelegationChange, k.BankKeeper.GetCoins(ctx, addrDels[0]).AmountOf(bondDenom))
+
+	// the reward stays liquid if the delegator can not afford it
+	k.autoCompound(ctx, "", addrDels[0], addrVals[0], balance)
+	delegation, _ = k.GetDelegation(ctx, addrDels[0], addrVals[0])
+	require.Equal(t, 2*minDelegationChange, delegation.Shares.RawInt())
+
+	// the flag is removed with the delegation
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.AutoCompound, 1)
+	defer sdk.UpgradeMgr.Reset()
+	k.RemoveDelegation(ctx, delegation)
+	require.False(t, k.IsAutoCompound(ctx, addrDels[0], addrVals[0]))
+
+	k.SetAutoCompound(ctx, addrDels[1], addrVals[0], true)
+	k.SetAutoCompound(ctx, addrDels[1], addrVals[0], false)
+	require.False(t, k.IsAutoCompound(ctx, addrDels[1], addrVals[0]))
+}
@@ -0,0 +1,24 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// get the status record of a cross stake request
+func (k Keeper) GetCrossStakeRequest(ctx sdk.Context, channelId sdk.ChannelID, sequence uint64) (request types.CrossStakeRequest, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetCrossStakeRequestKey(channelId, sequence))
+	if bz == nil {
+		return request, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &request)
+	return request, true
+}
+
+// set the status record of a cross stake request
+func (k Keeper) SetCrossStakeRequest(ctx sdk.Context, request types.CrossStakeRequest) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(request)
+	store.Set(GetCrossStakeRequestKey(request.ChannelId, request.Sequence), bz)
+}
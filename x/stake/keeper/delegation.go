@@ -119,6 +119,9 @@ func (k Keeper) RemoveDelegation(ctx sdk.Context, delegation types.Delegation) {
 	k.OnDelegationRemoved(ctx, delegation.DelegatorAddr, delegation.ValidatorAddr)
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(GetDelegationKey(delegation.DelegatorAddr, delegation.ValidatorAddr))
+	if sdk.IsUpgrade(sdk.AutoCompound) {
+		store.Delete(GetAutoCompoundKey(delegation.DelegatorAddr, delegation.ValidatorAddr))
+	}
 
 	// sync delegation to the store with DelegationKeyByVal based
 	if len(ctx.SideChainId()) > 0 {
@@ -66,6 +66,9 @@ func (k Keeper) Distribute(ctx sdk.Context, sideChainId string) {
 				if _, _, err := k.BankKeeper.AddCoins(ctx, rewards[i].AccAddr, sdk.Coins{sdk.NewCoin(bondDenom, rewards[i].Amount)}); err != nil {
 					panic(err)
 				}
+				if sdk.IsUpgrade(sdk.AutoCompound) {
+					k.autoCompound(ctx, sideChainId, rewards[i].AccAddr, validator.OperatorAddr, rewards[i].Amount)
+				}
 				changedAddrs[i] = rewards[i].AccAddr
 			}
 
@@ -113,7 +116,7 @@ func (k Keeper) Distribute(ctx sdk.Context, sideChainId string) {
 		k.PbsbServer.Publish(event)
 	}
 
-	removeValidatorsAndDelegationsAtHeight(height, k, ctx, validators)
+	removeValidatorsAndDelegationsAtHeight(height, k, ctx, sideChainId, validators)
 }
 
 // DistributeInBreathBlock will 1) calculate rewards as Distribute does, 2) transfer commissions to all validators, and
@@ -128,6 +131,9 @@ func (k Keeper) DistributeInBreathBlock(ctx sdk.Context, sideChainId string) sdk
 		singleBatchEvents := k.distributeSingleBatch(ctx, sideChainId)
 		events = events.AppendEvents(singleBatchEvents)
 	}
+	if sdk.IsUpgrade(sdk.RewardLedger) {
+		k.pruneRewardLedger(ctx)
+	}
 
 	var daysBackward int
 	if sideChainId != types.ChainIDForBeaconChain {
@@ -296,7 +302,7 @@ func (k Keeper) DistributeInBreathBlock(ctx sdk.Context, sideChainId string) sdk
 		k.PbsbServer.Publish(event)
 	}
 
-	removeValidatorsAndDelegationsAtHeight(height, k, ctx, validators)
+	removeValidatorsAndDelegationsAtHeight(height, k, ctx, sideChainId, validators)
 	return events
 }
 
@@ -340,6 +346,10 @@ func (k Keeper) distributeSingleBatch(ctx sdk.Context, sideChainId string) sdk.E
 			distAddrBalanceMap[distAddr.String()] = reward.Amount
 		}
 
+		if sdk.IsUpgrade(sdk.RewardLedger) {
+			k.recordReward(ctx, reward)
+		}
+
 		if reward.CrossStake && sdk.IsUpgrade(sdk.BEP153) {
 			rewardCAoB := types.GetStakeCAoB(reward.AccAddr.Bytes(), types.RewardCAoBSalt)
 			crossStakeAddrSet = append(crossStakeAddrSet, rewardCAoB)
@@ -349,6 +359,9 @@ func (k Keeper) distributeSingleBatch(ctx sdk.Context, sideChainId string) sdk.E
 		if _, _, err := k.BankKeeper.AddCoins(ctx, reward.AccAddr, sdk.Coins{sdk.NewCoin(bondDenom, reward.Amount)}); err != nil {
 			panic(err)
 		}
+		if sdk.IsUpgrade(sdk.AutoCompound) && !reward.CrossStake {
+			k.autoCompound(ctx, sideChainId, reward.AccAddr, reward.ValAddr, reward.Amount)
+		}
 
 		toPublishRewards = append(toPublishRewards, reward)
 		changedAddrs = append(changedAddrs, reward.AccAddr)
@@ -429,7 +442,8 @@ func simDelsToSharers(simDels []types.SimplifiedDelegation) []types.Sharer {
 	return sharers
 }
 
-func removeValidatorsAndDelegationsAtHeight(height int64, k Keeper, ctx sdk.Context, validators []types.Validator) {
+func removeValidatorsAndDelegationsAtHeight(height int64, k Keeper, ctx sdk.Context, sideChainId string, validators []types.Validator) {
+	k.archiveSnapshot(ctx, sideChainId, height, validators)
 	for _, validator := range validators {
 		k.RemoveSimplifiedDelegations(ctx, height, validator.OperatorAddr)
 	}
@@ -437,16 +451,45 @@ func removeValidatorsAndDelegationsAtHeight(height int64, k Keeper, ctx sdk.Cont
 }
 
 func crossDistributeReward(k Keeper, ctx sdk.Context, rewardCAoB sdk.AccAddress, amount int64) (sdk.Events, error) {
-	denom := k.BondDenom(ctx)
-	relayFeeCalc := fees.GetCalculator(types.CrossDistributeRewardRelayFee)
-	if relayFeeCalc == nil {
-		return sdk.Events{}, fmt.Errorf("no fee calculator of transferOutRewards")
+	relayFee, err := crossRelayFee(k, ctx, types.CrossDistributeRewardRelayFee)
+	if err != nil {
+		return sdk.Events{}, err
 	}
-	relayFee := relayFeeCalc(nil)
-	if relayFee.Tokens.AmountOf(denom) >= amount {
+	if relayFee >= amount {
 		return sdk.Events{}, sdk.ErrInternal("not enough funds to cover relay fee")
 	}
-	axcRelayFee := axc.ConvertBCAmountToAXCAmount(relayFee.Tokens.AmountOf(denom))
+	return crossTransferReward(k, ctx, rewardCAoB, amount, relayFee, types.CrossStakeTypeDistributeReward)
+}
+
+// CrossClaimReward transfers all the reward of a cross stake delegator back to the side chain on its request
+func (k Keeper) CrossClaimReward(ctx sdk.Context, rewardCAoB sdk.AccAddress) (sdk.Events, sdk.Error) {
+	relayFee, err := crossRelayFee(k, ctx, types.CrossClaimRewardRelayFee)
+	if err != nil {
+		return sdk.Events{}, sdk.ErrInternal(err.Error())
+	}
+	amount := k.BankKeeper.GetCoins(ctx, rewardCAoB).AmountOf(k.BondDenom(ctx))
+	if relayFee >= amount {
+		return sdk.Events{}, types.ErrNotEnoughBalance("not enough reward to cover relay fee")
+	}
+	events, err := crossTransferReward(k, ctx, rewardCAoB, amount, relayFee, types.CrossStakeTypeClaimReward)
+	if err != nil {
+		return sdk.Events{}, sdk.ErrInternal(err.Error())
+	}
+	return events, nil
+}
+
+func crossRelayFee(k Keeper, ctx sdk.Context, feeName string) (int64, error) {
+	relayFeeCalc := fees.GetCalculator(feeName)
+	if relayFeeCalc == nil {
+		return 0, fmt.Errorf("no fee calculator of %s", feeName)
+	}
+	return relayFeeCalc(nil).Tokens.AmountOf(k.BondDenom(ctx)), nil
+}
+
+func crossTransferReward(k Keeper, ctx sdk.Context, rewardCAoB sdk.AccAddress, amount, relayFee int64,
+	eventType types.CrossStakeEventType) (sdk.Events, error) {
+	denom := k.BondDenom(ctx)
+	axcRelayFee := axc.ConvertBCAmountToAXCAmount(relayFee)
 
 	axcTransferAmount := new(big.Int).Sub(axc.ConvertBCAmountToAXCAmount(amount), axcRelayFee)
 	delAddr := types.GetStakeCAoB(rewardCAoB.Bytes(), types.RewardCAoBSalt)
@@ -458,7 +501,7 @@ func crossDistributeReward(k Keeper, ctx sdk.Context, rewardCAoB sdk.AccAddress,
 	}
 
 	transferPackage := types.CrossStakeDistributeRewardSynPackage{
-		EventType: types.CrossStakeTypeDistributeReward,
+		EventType: eventType,
 		Amount:    axcTransferAmount,
 		Recipient: recipient,
 	}
@@ -481,7 +524,7 @@ func crossDistributeReward(k Keeper, ctx sdk.Context, rewardCAoB sdk.AccAddress,
 	if ctx.IsDeliverTx() && k.PbsbServer != nil {
 		event := pubsub.CrossTransferEvent{
 			ChainId:    k.DestChainName,
-			RelayerFee: relayFee.Tokens.AmountOf(denom),
+			RelayerFee: relayFee,
 			Type:       types.TransferOutType,
 			From:       rewardCAoB.String(),
 			Denom:      denom,
@@ -491,7 +534,7 @@ func crossDistributeReward(k Keeper, ctx sdk.Context, rewardCAoB sdk.AccAddress,
 	}
 
 	resultTags := sdk.NewTags(
-		types.TagCrossStakePackageType, []byte{uint8(types.CrossStakeTypeDistributeReward)},
+		types.TagCrossStakePackageType, []byte{uint8(eventType)},
 		types.TagCrossStakeChannel, []byte{uint8(types.CrossStakeChannelID)},
 		types.TagCrossStakeSendSequence, []byte(strconv.FormatUint(sendSeq, 10)),
 	)
@@ -40,6 +40,9 @@ type Keeper struct {
 	DestChainName string
 
 	PbsbServer *pubsub.Server
+
+	// the archive of the removed validator-set snapshots, disabled by default
+	archive *SnapshotArchive
 }
 
 func NewKeeper(cdc *codec.Codec, key, rewardKey, tkey sdk.StoreKey, ck bank.Keeper, addrPool *sdk.Pool,
@@ -56,6 +59,7 @@ func NewKeeper(cdc *codec.Codec, key, rewardKey, tkey sdk.StoreKey, ck bank.Keep
 		codespace:      codespace,
 		DestChainId:    destChainId,
 		DestChainName:  destChainName,
+		archive:        &SnapshotArchive{},
 	}
 
 	return keeper
@@ -38,6 +38,7 @@ var (
 	RedelegationByValDstIndexKey     = []byte{0x36} // prefix for each key for an redelegation, by destination validator operator
 	DelegationKeyByVal               = []byte{0x37} // prefix for each key for a delegation, by validator operator and delegator
 	SimplifiedDelegationsKey         = []byte{0x38} // prefix for each key for an simplifiedDelegations, by height and validator operator
+	AutoCompoundKey                  = []byte{0x39} // prefix for each key for the auto-compound flag of a delegation, by delegator and validator operator
 
 	UnbondingQueueKey    = []byte{0x41} // prefix for the timestamps in unbonding queue
 	RedelegationQueueKey = []byte{0x42} // prefix for the timestamps in redelegations queue
@@ -45,6 +46,11 @@ var (
 
 	SideChainStorePrefixByIdKey = []byte{0x51} // prefix for each key to a side chain store prefix, by side chain id
 
+	CrossStakeRequestKey = []byte{0x61} // prefix for each key to a cross stake request, by channel and sequence
+
+	RewardLedgerKey       = []byte{0x71} // prefix for each key to a reward credited to a delegator, by delegator, time and validator
+	RewardLedgerByTimeKey = []byte{0x72} // prefix for each key to a reward credited to a delegator, by time, delegator and validator
+
 	// Keys for reward store prefix
 	RewardBatchKey       = []byte{0x01} // key for batch of rewards
 	RewardValDistAddrKey = []byte{0x02} // key for rewards' validator <-> distribution address mapping
@@ -330,3 +336,54 @@ func GetREDsByDelToValDstIndexKey(delAddr sdk.AccAddress, valDstAddr sdk.ValAddr
 		GetREDsToValDstIndexKey(valDstAddr),
 		delAddr.Bytes()...)
 }
+
+// gets the key for the auto-compound flag of the delegation
+// VALUE: none, the delegation is auto-compounded if the key exists
+func GetAutoCompoundKey(delAddr sdk.AccAddress, valAddr sdk.ValAddress) []byte {
+	return append(append(AutoCompoundKey, delAddr.Bytes()...), valAddr.Bytes()...)
+}
+
+//______________________________________________________________________________
+
+// gets the key for the cross stake request received on the channel with the sequence
+// VALUE: stake/types.CrossStakeRequest
+func GetCrossStakeRequestKey(channelId sdk.ChannelID, sequence uint64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, sequence)
+	return append(append(CrossStakeRequestKey, byte(channelId)), bz...)
+}
+
+//______________________________________________________________________________
+
+// gets the prefix keyspace for all the rewards credited to a delegator
+func GetRewardLedgerByDelKey(delAddr sdk.AccAddress) []byte {
+	return append(RewardLedgerKey, delAddr.Bytes()...)
+}
+
+// gets the key for the reward credited to a delegator for the delegation to a validator at a time
+// VALUE: the amount, the height and the cross stake flag of the reward
+func GetRewardLedgerKey(delAddr sdk.AccAddress, timestamp time.Time, valAddr sdk.ValAddress) []byte {
+	return append(append(GetRewardLedgerByDelKey(delAddr), sdk.FormatTimeBytes(timestamp)...), valAddr.Bytes()...)
+}
+
+// gets the index key for the reward credited to a delegator for the delegation to a validator at a time
+// VALUE: none (key rearrangement used)
+func GetRewardLedgerByTimeKey(timestamp time.Time, delAddr sdk.AccAddress, valAddr sdk.ValAddress) []byte {
+	return append(append(GetRewardLedgerTimeKey(timestamp), delAddr.Bytes()...), valAddr.Bytes()...)
+}
+
+// gets the prefix of the index keys for the rewards credited at a time
+func GetRewardLedgerTimeKey(timestamp time.Time) []byte {
+	return append(RewardLedgerByTimeKey, sdk.FormatTimeBytes(timestamp)...)
+}
+
+// rearranges the index key of a reward to the key of the reward
+func GetRewardLedgerKeyFromTimeIndexKey(indexKey []byte) []byte {
+	timeLen := len(sdk.SortableTimeFormat)
+	timeBz := indexKey[1 : 1+timeLen]
+	delAddr := indexKey[1+timeLen : 1+timeLen+sdk.AddrLen]
+	valAddr := indexKey[1+timeLen+sdk.AddrLen:]
+	key := make([]byte, 0, len(indexKey))
+	key = append(append(append(append(key, RewardLedgerKey...), delAddr...), timeBz...), valAddr...)
+	return key
+}
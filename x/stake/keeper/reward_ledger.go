@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// the fields of the reward which are not contained within the key for the store
+type rewardLedgerValue struct {
+	Height     int64
+	Amount     int64
+	CrossStake bool
+}
+
+// recordReward adds the reward credited to the delegator at the block time to the ledger
+func (k Keeper) recordReward(ctx sdk.Context, reward types.Reward) {
+	store := ctx.KVStore(k.storeKey)
+	blockTime := ctx.BlockHeader().Time
+	key := GetRewardLedgerKey(reward.AccAddr, blockTime, reward.ValAddr)
+
+	value := rewardLedgerValue{Height: ctx.BlockHeight(), Amount: reward.Amount, CrossStake: reward.CrossStake}
+	if bz := store.Get(key); bz != nil {
+		// the batches of the same distribution may be in blocks with the same time
+		var recorded rewardLedgerValue
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &recorded)
+		value.Amount += recorded.Amount
+	}
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(value))
+	store.Set(GetRewardLedgerByTimeKey(blockTime, reward.AccAddr, reward.ValAddr), []byte{})
+}
+
+// pruneRewardLedger removes the rewards credited before the retention
+func (k Keeper) pruneRewardLedger(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	end := GetRewardLedgerTimeKey(ctx.BlockHeader().Time.Add(-types.RewardLedgerRetention))
+	iterator := store.Iterator(RewardLedgerByTimeKey, end)
+	defer iterator.Close()
+
+	var indexKeys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		indexKeys = append(indexKeys, iterator.Key())
+	}
+	for _, indexKey := range indexKeys {
+		store.Delete(GetRewardLedgerKeyFromTimeIndexKey(indexKey))
+		store.Delete(indexKey)
+	}
+}
+
+// GetRewardLedger returns the rewards credited to the delegator in [start, end), the rewards are filtered by the
+// validator if it is not empty
+func (k Keeper) GetRewardLedger(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress,
+	start, end time.Time) (entries []types.RewardLedgerEntry) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := GetRewardLedgerByDelKey(delAddr)
+	iterator := store.Iterator(GetRewardLedgerKey(delAddr, start, nil), GetRewardLedgerKey(delAddr, end, nil))
+	defer iterator.Close()
+
+	timeLen := len(sdk.SortableTimeFormat)
+	entries = make([]types.RewardLedgerEntry, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		entryValAddr := sdk.ValAddress(key[len(prefix)+timeLen:])
+		if len(valAddr) != 0 && !entryValAddr.Equals(valAddr) {
+			continue
+		}
+		timestamp, err := sdk.ParseTimeBytes(key[len(prefix) : len(prefix)+timeLen])
+		if err != nil {
+			panic(err)
+		}
+		var value rewardLedgerValue
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &value)
+		entries = append(entries, types.RewardLedgerEntry{
+			DelegatorAddr: delAddr,
+			ValidatorAddr: entryValAddr,
+			Time:          timestamp,
+			Height:        value.Height,
+			Amount:        value.Amount,
+			CrossStake:    value.CrossStake,
+		})
+	}
+	return entries
+}
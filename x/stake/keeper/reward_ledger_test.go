@@ -0,0 +1,54 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestRewardLedger(t *testing.T) {
+	ctx, _, k := CreateTestInput(t, false, 0)
+	delAddr := Addrs[0]
+	valAddr1, valAddr2 := sdk.ValAddress(Addrs[1]), sdk.ValAddress(Addrs[2])
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		dayCtx := ctx.WithBlockHeader(abci.Header{Time: day.AddDate(0, 0, i)}).WithBlockHeight(int64(i + 1))
+		k.recordReward(dayCtx, types.Reward{ValAddr: valAddr1, AccAddr: delAddr, Amount: int64(i + 1)})
+		k.recordReward(dayCtx, types.Reward{ValAddr: valAddr2, AccAddr: delAddr, Amount: 10, CrossStake: true})
+	}
+	// the rewards of another delegator are not in the ledger of the delegator
+	k.recordReward(ctx.WithBlockHeader(abci.Header{Time: day}), types.Reward{ValAddr: valAddr1, AccAddr: Addrs[3], Amount: 100})
+
+	entries := k.GetRewardLedger(ctx, delAddr, nil, day, day.AddDate(0, 0, 2))
+	require.Len(t, entries, 4)
+	require.Equal(t, day, entries[0].Time)
+	require.Equal(t, int64(1), entries[0].Height)
+
+	entries = k.GetRewardLedger(ctx, delAddr, valAddr1, day.AddDate(0, 0, 1), day.AddDate(0, 0, 3))
+	require.Len(t, entries, 2)
+	require.Equal(t, int64(2), entries[0].Amount)
+	require.Equal(t, int64(3), entries[1].Amount)
+	require.Equal(t, valAddr1, entries[1].ValidatorAddr)
+	require.False(t, entries[1].CrossStake)
+
+	// the batches recorded in blocks with the same time are summed
+	k.recordReward(ctx.WithBlockHeader(abci.Header{Time: day.AddDate(0, 0, 2)}).WithBlockHeight(3),
+		types.Reward{ValAddr: valAddr2, AccAddr: delAddr, Amount: 5, CrossStake: true})
+	entries = k.GetRewardLedger(ctx, delAddr, valAddr2, day.AddDate(0, 0, 2), day.AddDate(0, 0, 3))
+	require.Len(t, entries, 1)
+	require.Equal(t, int64(15), entries[0].Amount)
+	require.True(t, entries[0].CrossStake)
+
+	// the rewards before the retention are pruned
+	k.pruneRewardLedger(ctx.WithBlockHeader(abci.Header{Time: day.AddDate(0, 0, 1).Add(types.RewardLedgerRetention)}))
+	entries = k.GetRewardLedger(ctx, delAddr, nil, day, day.AddDate(0, 0, 3))
+	require.Len(t, entries, 4)
+	require.Equal(t, day.AddDate(0, 0, 1), entries[0].Time)
+	require.Len(t, k.GetRewardLedger(ctx, Addrs[3], nil, day, day.AddDate(0, 0, 3)), 0)
+}
@@ -2,6 +2,7 @@ package querier
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -31,6 +32,11 @@ const (
 	QueryAllValidatorsCount            = "allValidatorsCount"
 	QueryAllUnJailValidatorsCount      = "allUnJailValidatorsCount"
 	QueryCrossStakeInfoByAxcAddress    = "crossStakeInfoByAxcAddress"
+	QueryCrossStakeRequest             = "crossStakeRequest"
+	QueryArchivedHeights               = "archivedHeights"
+	QueryArchivedValidators            = "archivedValidators"
+	QueryArchivedDelegations           = "archivedDelegations"
+	QueryRewardLedger                  = "rewardLedger"
 )
 
 // creates a querier for staking REST endpoints
@@ -163,6 +169,41 @@ func NewQuerier(k keep.Keeper, cdc *codec.Codec) sdk.Querier {
 				return res, err
 			}
 			return queryCrossStakeInfoByAxcAddress(ctx, cdc, p, k)
+		case QueryCrossStakeRequest:
+			p := new(QueryCrossStakeRequestParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryCrossStakeRequest(ctx, cdc, p, k)
+		case QueryArchivedHeights:
+			p := new(BaseParams)
+			_, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryArchivedHeights(cdc, p, k)
+		case QueryArchivedValidators:
+			p := new(QueryArchivedSnapshotParams)
+			_, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryArchivedValidators(cdc, p, k)
+		case QueryArchivedDelegations:
+			p := new(QueryArchivedSnapshotParams)
+			_, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryArchivedDelegations(cdc, p, k)
+		case QueryRewardLedger:
+			p := new(QueryRewardLedgerParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryRewardLedger(ctx, cdc, p, k)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown stake query endpoint")
 		}
@@ -251,6 +292,32 @@ type QueryCrossStakeInfoParams struct {
 	AxcAddress sdk.SmartChainAddress
 }
 
+// defines the params for the following queries:
+// - 'custom/stake/crossStakeRequest'
+type QueryCrossStakeRequestParams struct {
+	BaseParams
+	ChannelId sdk.ChannelID
+	Sequence  uint64
+}
+
+// defines the params for the following queries:
+// - 'custom/stake/archivedValidators'
+// - 'custom/stake/archivedDelegations'
+type QueryArchivedSnapshotParams struct {
+	BaseParams
+	Height        int64
+	ValidatorAddr sdk.ValAddress
+}
+
+// defines the params for 'custom/stake/rewardLedger', the rewards credited in [StartTime, EndTime) are queried
+type QueryRewardLedgerParams struct {
+	BaseParams
+	DelegatorAddr sdk.AccAddress
+	ValidatorAddr sdk.ValAddress
+	StartTime     time.Time
+	EndTime       time.Time
+}
+
 func queryValidators(ctx sdk.Context, cdc *codec.Codec, k keep.Keeper) (res []byte, err sdk.Error) {
 	stakeParams := k.GetParams(ctx)
 	validators := k.GetValidators(ctx, stakeParams.MaxValidators)
@@ -477,6 +544,79 @@ func queryCrossStakeInfoByAxcAddress(ctx sdk.Context, cdc *codec.Codec, params *
 	return res, nil
 }
 
+func queryCrossStakeRequest(ctx sdk.Context, cdc *codec.Codec, params *QueryCrossStakeRequestParams, k keep.Keeper) ([]byte, sdk.Error) {
+	request, found := k.GetCrossStakeRequest(ctx, params.ChannelId, params.Sequence)
+	if !found {
+		return []byte{}, types.ErrNoCrossStakeRequest(types.DefaultCodespace)
+	}
+	res, errRes := codec.MarshalJSONIndent(cdc, request)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+// the snapshots of the beacon chain are archived under its own chain id
+func archivedChainId(params types.SideChainIder) string {
+	if len(params.GetSideChainId()) == 0 {
+		return types.ChainIDForBeaconChain
+	}
+	return params.GetSideChainId()
+}
+
+func queryArchivedHeights(cdc *codec.Codec, params *BaseParams, k keep.Keeper) ([]byte, sdk.Error) {
+	if !k.SnapshotArchiveEnabled() {
+		return []byte{}, types.ErrSnapshotArchiveDisabled(types.DefaultCodespace)
+	}
+	res, errRes := codec.MarshalJSONIndent(cdc, k.GetArchivedHeights(archivedChainId(params)))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryArchivedValidators(cdc *codec.Codec, params *QueryArchivedSnapshotParams, k keep.Keeper) ([]byte, sdk.Error) {
+	if !k.SnapshotArchiveEnabled() {
+		return []byte{}, types.ErrSnapshotArchiveDisabled(types.DefaultCodespace)
+	}
+	validators, found := k.GetArchivedValidators(archivedChainId(params), params.Height)
+	if !found {
+		return []byte{}, types.ErrNoArchivedSnapshot(types.DefaultCodespace)
+	}
+	res, errRes := codec.MarshalJSONIndent(cdc, validators)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryArchivedDelegations(cdc *codec.Codec, params *QueryArchivedSnapshotParams, k keep.Keeper) ([]byte, sdk.Error) {
+	if !k.SnapshotArchiveEnabled() {
+		return []byte{}, types.ErrSnapshotArchiveDisabled(types.DefaultCodespace)
+	}
+	simDels, found := k.GetArchivedDelegations(archivedChainId(params), params.Height, params.ValidatorAddr)
+	if !found {
+		return []byte{}, types.ErrNoArchivedSnapshot(types.DefaultCodespace)
+	}
+	res, errRes := codec.MarshalJSONIndent(cdc, simDels)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryRewardLedger(ctx sdk.Context, cdc *codec.Codec, params *QueryRewardLedgerParams, k keep.Keeper) ([]byte, sdk.Error) {
+	if !params.StartTime.Before(params.EndTime) {
+		return []byte{}, types.ErrInvalidTimeRange(types.DefaultCodespace)
+	}
+	entries := k.GetRewardLedger(ctx, params.DelegatorAddr, params.ValidatorAddr, params.StartTime, params.EndTime)
+	res, errRes := codec.MarshalJSONIndent(cdc, entries)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
 func prepareSideChainCtx(ctx sdk.Context, k keep.Keeper, sideChainId string) (sdk.Context, sdk.Error) {
 	scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId)
 	if err != nil {
@@ -35,11 +35,21 @@ type (
 	QueryTopValidatorsParams   = querier.QueryTopValidatorsParams
 	BaseParams                 = querier.BaseParams
 
+	QueryCrossStakeRequestParams = querier.QueryCrossStakeRequestParams
+	CrossStakeRequest            = types.CrossStakeRequest
+
+	QueryArchivedSnapshotParams = querier.QueryArchivedSnapshotParams
+	SimplifiedDelegation        = types.SimplifiedDelegation
+
+	QueryRewardLedgerParams = querier.QueryRewardLedgerParams
+	RewardLedgerEntry       = types.RewardLedgerEntry
+
 	MsgCreateSideChainValidator = types.MsgCreateSideChainValidator
 	MsgEditSideChainValidator   = types.MsgEditSideChainValidator
 	MsgSideChainDelegate        = types.MsgSideChainDelegate
 	MsgSideChainRedelegate      = types.MsgSideChainRedelegate
 	MsgSideChainUndelegate      = types.MsgSideChainUndelegate
+	MsgSetAutoCompound          = types.MsgSetAutoCompound
 
 	DistributionEvent      = types.DistributionEvent
 	DistributionData       = types.DistributionData
@@ -111,6 +121,7 @@ var (
 	NewGenesisState         = types.NewGenesisState
 	DefaultGenesisState     = types.DefaultGenesisState
 	RegisterCodec           = types.RegisterCodec
+	RegisterJournalEvents   = types.RegisterJournalEvents
 
 	NewMsgCreateValidator           = types.NewMsgCreateValidator
 	NewMsgRemoveValidator           = types.NewMsgRemoveValidator
@@ -127,6 +138,7 @@ var (
 	NewMsgSideChainDelegate                  = types.NewMsgSideChainDelegate
 	NewMsgSideChainRedelegate                = types.NewMsgSideChainRedelegate
 	NewMsgSideChainUndelegate                = types.NewMsgSideChainUndelegate
+	NewMsgSetAutoCompound                    = types.NewMsgSetAutoCompound
 
 	NewQuerier    = querier.NewQuerier
 	NewBaseParams = querier.NewBaseParams
@@ -151,6 +163,11 @@ const (
 	QueryPool                          = querier.QueryPool
 	QueryParameters                    = querier.QueryParameters
 	QueryCrossStakeInfo                = querier.QueryCrossStakeInfoByAxcAddress
+	QueryCrossStakeRequest             = querier.QueryCrossStakeRequest
+	QueryArchivedHeights               = querier.QueryArchivedHeights
+	QueryArchivedValidators            = querier.QueryArchivedValidators
+	QueryArchivedDelegations           = querier.QueryArchivedDelegations
+	QueryRewardLedger                  = querier.QueryRewardLedger
 
 	Topic = types.Topic
 )
@@ -22,6 +22,8 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSideChainRedelegate{}, "cosmos-sdk/MsgSideChainRedelegate", nil)
 	cdc.RegisterConcrete(MsgSideChainUndelegate{}, "cosmos-sdk/MsgSideChainUndelegate", nil)
 
+	cdc.RegisterConcrete(MsgSetAutoCompound{}, "cosmos-sdk/MsgSetAutoCompound", nil)
+
 	cdc.RegisterConcrete(&Params{}, "params/StakeParamSet", nil)
 }
 
@@ -19,9 +19,12 @@ const (
 	TagCrossStakeChannel      = "CrossStakeChannel"
 	TagCrossStakePackageType  = "CrossStakePackageType"
 	TagCrossStakeSendSequence = "CrossStakeSendSequence"
+	TagCrossStakeErrorCode    = "CrossStakeErrorCode"
 
 	CrossDistributeRewardRelayFee      = "crossDistributeRewardRelayFee"
 	CrossDistributeUndelegatedRelayFee = "crossDistributeUndelegatedRelayFee"
+	CrossClaimRewardRelayFee           = "crossClaimRewardRelayFee"
+	CrossCompoundRewardFee             = "crossCompoundRewardFee"
 
 	CrossStakeFailed  CrossStakeStatus = 0
 	CrossStakeSuccess CrossStakeStatus = 1
@@ -31,6 +34,9 @@ const (
 	CrossStakeTypeRedelegate            CrossStakeEventType = 3
 	CrossStakeTypeDistributeReward      CrossStakeEventType = 4
 	CrossStakeTypeDistributeUndelegated CrossStakeEventType = 5
+	CrossStakeTypeClaimReward           CrossStakeEventType = 6
+	CrossStakeTypeCompoundReward        CrossStakeEventType = 7
+	CrossStakeTypeBatchDelegate         CrossStakeEventType = 8
 
 	TransferInType  string = "TI"
 	TransferOutType string = "TO"
@@ -39,6 +45,8 @@ const (
 	RewardCAoBSalt   string = "Reward"
 
 	MinRewardThreshold int64 = 1e8
+
+	MaxBatchDelegateValidators = 10
 )
 
 type CrossStakeAckPackage struct {
@@ -66,6 +74,26 @@ type CrossStakeRedelegateSynPackage struct {
 	Amount  *big.Int
 }
 
+// CrossStakeClaimRewardSynPackage asks to transfer all the accumulated reward of the delegator back to the side
+// chain, the reward is sent by a CrossStakeDistributeRewardSynPackage of event type CrossStakeTypeClaimReward
+type CrossStakeClaimRewardSynPackage struct {
+	DelAddr sdk.SmartChainAddress
+}
+
+// CrossStakeCompoundRewardSynPackage asks to delegate all the accumulated reward of the delegator to the validator
+type CrossStakeCompoundRewardSynPackage struct {
+	DelAddr   sdk.SmartChainAddress
+	Validator sdk.ValAddress
+}
+
+// CrossStakeBatchDelegateSynPackage delegates to several validators at once, either all the delegations succeed or
+// none of them is made
+type CrossStakeBatchDelegateSynPackage struct {
+	DelAddr    sdk.SmartChainAddress
+	Validators []sdk.ValAddress
+	Amounts    []*big.Int
+}
+
 type CrossStakeDistributeRewardSynPackage struct {
 	EventType CrossStakeEventType
 	Recipient sdk.SmartChainAddress
@@ -93,6 +121,64 @@ type CrossStakeRefundPackage struct {
 	ErrorCode RefundError
 }
 
+// CrossStakeRequestStatus is the status of a cross stake request received from the side chain
+type CrossStakeRequestStatus uint8
+
+const (
+	CrossStakeRequestReceived CrossStakeRequestStatus = 1
+	CrossStakeRequestExecuted CrossStakeRequestStatus = 2
+	// the request is rejected, the ack package tells the side chain why by the error code
+	CrossStakeRequestFailed CrossStakeRequestStatus = 3
+	// the execution crashed, the fail ack package makes the side chain refund the request
+	CrossStakeRequestRefunded CrossStakeRequestStatus = 4
+)
+
+func (status CrossStakeRequestStatus) String() string {
+	switch status {
+	case CrossStakeRequestReceived:
+		return "Received"
+	case CrossStakeRequestExecuted:
+		return "Executed"
+	case CrossStakeRequestFailed:
+		return "Failed"
+	case CrossStakeRequestRefunded:
+		return "Refunded"
+	default:
+		return fmt.Sprintf("Unknown(%d)", status)
+	}
+}
+
+// CrossStakeRequest records the lifecycle of a cross stake syn package received on a channel
+type CrossStakeRequest struct {
+	ChannelId sdk.ChannelID           `json:"channel_id"`
+	Sequence  uint64                  `json:"sequence"`
+	EventType CrossStakeEventType     `json:"event_type"`
+	DelAddr   sdk.SmartChainAddress   `json:"delegator_address"`
+	Validator sdk.ValAddress          `json:"validator_address"`
+	ValDst    sdk.ValAddress          `json:"validator_dst_address,omitempty"`
+	Amount    int64                   `json:"amount"`
+	Status    CrossStakeRequestStatus `json:"status"`
+	ErrorCode uint8                   `json:"error_code"`
+	Height    int64                   `json:"height"`
+}
+
+func (r CrossStakeRequest) HumanReadableString() (string, error) {
+	resp := "Cross Stake Request \n"
+	resp += fmt.Sprintf("Channel: %d\n", r.ChannelId)
+	resp += fmt.Sprintf("Sequence: %d\n", r.Sequence)
+	resp += fmt.Sprintf("Event type: %d\n", r.EventType)
+	resp += fmt.Sprintf("Delegator address: %s\n", r.DelAddr.String())
+	resp += fmt.Sprintf("Validator address: %s\n", r.Validator.String())
+	if len(r.ValDst) != 0 {
+		resp += fmt.Sprintf("Dst validator address: %s\n", r.ValDst.String())
+	}
+	resp += fmt.Sprintf("Amount: %d\n", r.Amount)
+	resp += fmt.Sprintf("Status: %s\n", r.Status)
+	resp += fmt.Sprintf("Error code: %d\n", r.ErrorCode)
+	resp += fmt.Sprintf("Height: %d", r.Height)
+	return resp, nil
+}
+
 func GetStakeCAoB(sourceAddr []byte, salt string) sdk.AccAddress {
 	saltBytes := []byte("Staking" + salt + "Address Anchor")
 	return sdk.XOR(tmhash.SumTruncated(saltBytes), sourceAddr)
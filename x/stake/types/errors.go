@@ -259,3 +259,23 @@ func ErrNotEnoughBalance(msg string) sdk.Error {
 func ErrNotSelfDelegate(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "only self delegate is allowed")
 }
+
+func ErrNoCrossStakeRequest(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidCrossChainPackage, "no cross stake request found")
+}
+
+func ErrBadBatchDelegation(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidCrossChainPackage, "invalid batch delegation, the validators and the amounts must match and be no more than the batch limit")
+}
+
+func ErrSnapshotArchiveDisabled(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeUnknownRequest, "the validator snapshots are not archived by the node")
+}
+
+func ErrNoArchivedSnapshot(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "no archived snapshot found")
+}
+
+func ErrInvalidTimeRange(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "the start time must be before the end time")
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+const MsgTypeSetAutoCompound = "set_auto_compound"
+
+// MsgSetAutoCompound opts a delegation in or out of delegating its rewards to the validator again,
+// the delegation is on the beacon chain if the side chain id is empty
+type MsgSetAutoCompound struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Enable        bool           `json:"enable"`
+
+	SideChainId string `json:"side_chain_id"`
+}
+
+func NewMsgSetAutoCompound(sideChainId string, delAddr sdk.AccAddress, valAddr sdk.ValAddress, enable bool) MsgSetAutoCompound {
+	return MsgSetAutoCompound{
+		DelegatorAddr: delAddr,
+		ValidatorAddr: valAddr,
+		Enable:        enable,
+		SideChainId:   sideChainId,
+	}
+}
+
+//nolint
+func (msg MsgSetAutoCompound) Route() string { return MsgRoute }
+func (msg MsgSetAutoCompound) Type() string  { return MsgTypeSetAutoCompound }
+func (msg MsgSetAutoCompound) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgSetAutoCompound) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgSetAutoCompound) ValidateBasic() sdk.Error {
+	if len(msg.DelegatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.DelegatorAddr)))
+	}
+	if len(msg.ValidatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected validator address length is %d, actual length is %d", sdk.AddrLen, len(msg.ValidatorAddr)))
+	}
+	if len(msg.SideChainId) > types.MaxSideChainIdLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id max length is 20 bytes")
+	}
+	return nil
+}
+
+func (msg MsgSetAutoCompound) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr, sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+func (msg MsgSetAutoCompound) GetSideChainId() string {
+	return msg.SideChainId
+}
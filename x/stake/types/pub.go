@@ -21,6 +21,26 @@ func (event StakeEvent) FromTx() bool {
 	return event.IsFromTx
 }
 
+// RegisterJournalEvents registers the events of stake, so that they are recorded in the pubsub journal
+func RegisterJournalEvents(journal *pubsub.Journal) {
+	journal.RegisterEvent(ValidatorUpdateEvent{}, "stake/ValidatorUpdateEvent")
+	journal.RegisterEvent(ValidatorRemovedEvent{}, "stake/ValidatorRemovedEvent")
+	journal.RegisterEvent(DelegationUpdateEvent{}, "stake/DelegationUpdateEvent")
+	journal.RegisterEvent(DelegationRemovedEvent{}, "stake/DelegationRemovedEvent")
+	journal.RegisterEvent(UBDUpdateEvent{}, "stake/UBDUpdateEvent")
+	journal.RegisterEvent(REDUpdateEvent{}, "stake/REDUpdateEvent")
+	journal.RegisterEvent(CompletedUBDEvent{}, "stake/CompletedUBDEvent")
+	journal.RegisterEvent(CompletedREDEvent{}, "stake/CompletedREDEvent")
+	journal.RegisterEvent(DistributionEvent{}, "stake/DistributionEvent")
+	journal.RegisterEvent(DelegateEvent{}, "stake/DelegateEvent")
+	journal.RegisterEvent(ChainDelegateEvent{}, "stake/ChainDelegateEvent")
+	journal.RegisterEvent(UndelegateEvent{}, "stake/UndelegateEvent")
+	journal.RegisterEvent(ChainUndelegateEvent{}, "stake/ChainUndelegateEvent")
+	journal.RegisterEvent(RedelegateEvent{}, "stake/RedelegateEvent")
+	journal.RegisterEvent(ChainRedelegateEvent{}, "stake/ChainRedelegateEvent")
+	journal.RegisterEvent(ElectedValidatorsEvent{}, "stake/ElectedValidatorsEvent")
+}
+
 //----------------------------------------------------------------------------------------------------
 
 // validator update event
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -54,3 +56,16 @@ func MustUnmarshalValDistAddrs(cdc *codec.Codec, value []byte) (valDistAddrs []S
 	}
 	return valDistAddrs
 }
+
+// the rewards in the ledger are pruned after the retention
+const RewardLedgerRetention = 365 * 24 * time.Hour
+
+// RewardLedgerEntry is a reward credited to a delegator for its delegation to a validator
+type RewardLedgerEntry struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Time          time.Time      `json:"time"`
+	Height        int64          `json:"height"`
+	Amount        int64          `json:"amount"`
+	CrossStake    bool           `json:"cross_stake"`
+}